@@ -0,0 +1,17 @@
+// Package nulltype provides type predicates shared by the nullable analyzers.
+package nulltype
+
+import "go/types"
+
+// Path is the import path of the nullable package.
+const Path = "github.com/manattan/nullable"
+
+// IsNullable reports whether t is an instantiation of nullable.Nullable.
+func IsNullable(t types.Type) bool {
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Origin().Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == Path && obj.Name() == "Nullable"
+}
@@ -0,0 +1,164 @@
+// Package nullcompare defines an Analyzer that reports comparisons which
+// silently mishandle null Nullable values.
+//
+// Two patterns are reported: comparing Nullable values directly with == or
+// !=, which also compares the V of null values, and comparing the V field of
+// a Nullable without a Valid check guarding it, which treats null as the zero
+// value.
+package nullcompare
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/manattan/nullable/analysis/internal/nulltype"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const doc = `report comparisons that mishandle null Nullable values
+
+The nullcompare analyzer reports == and != between Nullable values, and
+comparisons of a Nullable's V field that are not guarded by a check that
+the same value's Valid field is true: a Valid operand of an enclosing &&
+(or a !Valid operand of ||), an enclosing if condition, or an earlier if
+statement in the same block that returns when the value is null.`
+
+// Analyzer is the nullcompare analyzer.
+var Analyzer = &analysis.Analyzer{
+	Name:     "nullcompare",
+	Doc:      doc,
+	URL:      "https://pkg.go.dev/github.com/manattan/nullable/analysis/nullcompare",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	insp.WithStack([]ast.Node{(*ast.BinaryExpr)(nil)}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		expr := n.(*ast.BinaryExpr)
+		switch expr.Op {
+		case token.EQL, token.NEQ:
+			if isNullable(pass, expr.X) && isNullable(pass, expr.Y) {
+				pass.ReportRangef(expr, "comparing Nullable values with %s also compares the V of null values; use nullable.Equal or EqualFunc instead", expr.Op)
+				return true
+			}
+		case token.LSS, token.LEQ, token.GTR, token.GEQ:
+		default:
+			return true
+		}
+
+		for _, operand := range []ast.Expr{expr.X, expr.Y} {
+			base, ok := nullableValueField(pass, operand)
+			if !ok {
+				continue
+			}
+			if !guarded(pass, base, stack) {
+				pass.ReportRangef(expr, "comparison of %s.V without checking %s.Valid treats null as the zero value", types.ExprString(base), types.ExprString(base))
+				return true
+			}
+		}
+		return true
+	})
+	return nil, nil
+}
+
+func isNullable(pass *analysis.Pass, e ast.Expr) bool {
+	return nulltype.IsNullable(pass.TypesInfo.TypeOf(e))
+}
+
+// nullableValueField reports whether e is a selection x.V on a Nullable x,
+// returning x.
+func nullableValueField(pass *analysis.Pass, e ast.Expr) (ast.Expr, bool) {
+	sel, ok := ast.Unparen(e).(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "V" || !isNullable(pass, sel.X) {
+		return nil, false
+	}
+	return sel.X, true
+}
+
+// guarded reports whether the node at the top of stack only runs when
+// base.Valid is true: as the right operand of && after a condition that
+// implies it, of || after one whose falsity implies it, in an if branch
+// selected by such a condition, or after an earlier if statement in the
+// same block that leaves the block when base is null.
+func guarded(pass *analysis.Pass, base ast.Expr, stack []ast.Node) bool {
+	for i := len(stack) - 2; i >= 0; i-- {
+		child := stack[i+1]
+		switch parent := stack[i].(type) {
+		case *ast.BinaryExpr:
+			if child != parent.Y {
+				break
+			}
+			if parent.Op == token.LAND && impliesValid(pass, parent.X, base, true) ||
+				parent.Op == token.LOR && impliesValid(pass, parent.X, base, false) {
+				return true
+			}
+		case *ast.IfStmt:
+			if child == parent.Body && impliesValid(pass, parent.Cond, base, true) ||
+				child == parent.Else && impliesValid(pass, parent.Cond, base, false) {
+				return true
+			}
+		case *ast.BlockStmt:
+			for _, stmt := range parent.List {
+				if stmt == child {
+					break
+				}
+				if ifStmt, ok := stmt.(*ast.IfStmt); ok && ifStmt.Else == nil &&
+					terminates(ifStmt.Body) && impliesValid(pass, ifStmt.Cond, base, false) {
+					return true
+				}
+			}
+		case *ast.FuncDecl, *ast.FuncLit:
+			return false
+		}
+	}
+	return false
+}
+
+// impliesValid reports whether e evaluating to truth implies that
+// base.Valid is true.
+func impliesValid(pass *analysis.Pass, e ast.Expr, base ast.Expr, truth bool) bool {
+	switch e := ast.Unparen(e).(type) {
+	case *ast.SelectorExpr:
+		return truth && e.Sel.Name == "Valid" && isNullable(pass, e.X) &&
+			types.ExprString(e.X) == types.ExprString(base)
+	case *ast.UnaryExpr:
+		return e.Op == token.NOT && impliesValid(pass, e.X, base, !truth)
+	case *ast.BinaryExpr:
+		switch {
+		case e.Op == token.LAND && truth, e.Op == token.LOR && !truth:
+			// Both operands have the value of e.
+			return impliesValid(pass, e.X, base, truth) || impliesValid(pass, e.Y, base, truth)
+		case e.Op == token.LAND, e.Op == token.LOR:
+			// Either operand may have the value of e.
+			return impliesValid(pass, e.X, base, truth) && impliesValid(pass, e.Y, base, truth)
+		}
+	}
+	return false
+}
+
+// terminates reports whether block ends by leaving the enclosing block,
+// with a return, branch or panic.
+func terminates(block *ast.BlockStmt) bool {
+	if len(block.List) == 0 {
+		return false
+	}
+	switch stmt := block.List[len(block.List)-1].(type) {
+	case *ast.ReturnStmt, *ast.BranchStmt:
+		return true
+	case *ast.ExprStmt:
+		call, ok := stmt.X.(*ast.CallExpr)
+		if !ok {
+			return false
+		}
+		id, ok := ast.Unparen(call.Fun).(*ast.Ident)
+		return ok && id.Name == "panic"
+	}
+	return false
+}
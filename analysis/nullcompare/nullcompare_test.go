@@ -0,0 +1,12 @@
+package nullcompare_test
+
+import (
+	"testing"
+
+	"github.com/manattan/nullable/analysis/nullcompare"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), nullcompare.Analyzer, "a")
+}
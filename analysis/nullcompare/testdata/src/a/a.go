@@ -0,0 +1,105 @@
+package a
+
+import "github.com/manattan/nullable"
+
+type User struct {
+	Name nullable.Nullable[string]
+	Age  nullable.Nullable[int]
+}
+
+func direct(a, b nullable.Nullable[int]) bool {
+	if a == b { // want `comparing Nullable values with == also compares the V of null values`
+		return true
+	}
+	return a != b // want `comparing Nullable values with != also compares the V of null values`
+}
+
+func unguarded(u User) bool {
+	if u.Name.V == "alice" { // want `comparison of u.Name.V without checking u.Name.Valid`
+		return true
+	}
+	return u.Age.V > 18 // want `comparison of u.Age.V without checking u.Age.Valid`
+}
+
+func guardedAnd(u User) bool {
+	return u.Name.Valid && u.Name.V == "alice"
+}
+
+func guardedOr(u User) bool {
+	return !u.Age.Valid || u.Age.V > 18
+}
+
+func guardedIf(u User) bool {
+	if u.Age.Valid {
+		return u.Age.V >= 21
+	}
+	return false
+}
+
+func guardedEarlyReturn(u User) bool {
+	if !u.Age.Valid {
+		return false
+	}
+	return u.Age.V < 65
+}
+
+func wrongGuard(u User) bool {
+	return u.Name.Valid && u.Age.V == 3 // want `comparison of u.Age.V without checking u.Age.Valid`
+}
+
+func closure(u User) func() bool {
+	if !u.Age.Valid {
+		return nil
+	}
+	return func() bool {
+		return u.Age.V == 1 // want `comparison of u.Age.V without checking u.Age.Valid`
+	}
+}
+
+func plain(a, b int) bool {
+	return a == b
+}
+
+func negatedGuard(a, b nullable.Nullable[int]) bool {
+	if !a.Valid && a.V == b.V { // want `comparison of a.V without checking a.Valid`
+		return true
+	}
+	return false
+}
+
+func otherOperandGuard(a, b, other nullable.Nullable[int]) bool {
+	if other.Valid && a.Valid && a.V == b.V { // want `comparison of b.V without checking b.Valid`
+		return true
+	}
+	return other.Valid && a.V < 3 // want `comparison of a.V without checking a.Valid`
+}
+
+func orGuard(u User) bool {
+	return u.Age.Valid || u.Age.V > 18 // want `comparison of u.Age.V without checking u.Age.Valid`
+}
+
+func bothGuarded(a, b nullable.Nullable[int]) bool {
+	return a.Valid && (b.Valid && a.V == b.V)
+}
+
+func elseGuard(u User) bool {
+	if !u.Age.Valid {
+		return false
+	} else {
+		return u.Age.V > 18
+	}
+}
+
+func nonReturningCheck(u User) bool {
+	if !u.Age.Valid {
+		println("no age")
+	}
+	return u.Age.V > 18 // want `comparison of u.Age.V without checking u.Age.Valid`
+}
+
+func invertedEarlyReturn(u User) bool {
+	if u.Age.Valid {
+		return true
+	}
+	return u.Age.V > 18 // want `comparison of u.Age.V without checking u.Age.Valid`
+}
@@ -0,0 +1,169 @@
+// Package omitnull defines an Analyzer that reports Nullable struct fields
+// whose json tag lacks the omission option required by the project's policy.
+//
+// Without an omission option a null Nullable is always encoded as
+// "field": null. Note that encoding/json never treats a struct as empty, so
+// only omitzero actually drops null values; the omitempty policy exists for
+// codebases that post-process output or use encoders honoring it.
+package omitnull
+
+import (
+	"fmt"
+	"go/ast"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/manattan/nullable/analysis/internal/nulltype"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const doc = `report Nullable fields missing the json omission option
+
+The omitnull analyzer reports exported struct fields of type
+nullable.Nullable[T] whose json tag does not carry the option selected by
+-require (omitzero, omitempty, or any). Fields tagged json:"-" are ignored.`
+
+// Analyzer is the omitnull analyzer.
+var Analyzer = &analysis.Analyzer{
+	Name:     "omitnull",
+	Doc:      doc,
+	URL:      "https://pkg.go.dev/github.com/manattan/nullable/analysis/omitnull",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+var require = "omitzero"
+
+func init() {
+	Analyzer.Flags.StringVar(&require, "require", require,
+		"json option required on Nullable fields: omitzero, omitempty, or any")
+}
+
+var jsonTagRe = regexp.MustCompile(`(^|\s)json:"([^"]*)"`)
+
+func run(pass *analysis.Pass) (any, error) {
+	switch require {
+	case "omitzero", "omitempty", "any":
+	default:
+		return nil, fmt.Errorf("invalid -require value %q", require)
+	}
+
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	insp.Preorder([]ast.Node{(*ast.StructType)(nil)}, func(n ast.Node) {
+		for _, field := range n.(*ast.StructType).Fields.List {
+			checkField(pass, field)
+		}
+	})
+	return nil, nil
+}
+
+func checkField(pass *analysis.Pass, field *ast.Field) {
+	if !nulltype.IsNullable(pass.TypesInfo.TypeOf(field.Type)) {
+		return
+	}
+	if !hasExportedName(field) {
+		return
+	}
+
+	var tag string
+	if field.Tag != nil {
+		v, err := strconv.Unquote(field.Tag.Value)
+		if err != nil {
+			return
+		}
+		tag = v
+	}
+
+	m := jsonTagRe.FindStringSubmatchIndex(tag)
+	var opts []string
+	if m != nil {
+		value := tag[m[4]:m[5]]
+		if value == "-" {
+			return
+		}
+		opts = strings.Split(value, ",")[1:]
+	}
+	if satisfies(opts) {
+		return
+	}
+
+	option := require
+	if option == "any" {
+		option = "omitzero"
+	}
+	diag := analysis.Diagnostic{
+		Pos:     field.Pos(),
+		End:     field.End(),
+		Message: fmt.Sprintf("Nullable field %s has no %s json option; null values will be encoded as null", fieldName(field), describe(require)),
+	}
+	if fix, ok := suggestFix(field, tag, m, option); ok {
+		diag.SuggestedFixes = []analysis.SuggestedFix{fix}
+	}
+	pass.Report(diag)
+}
+
+func hasExportedName(field *ast.Field) bool {
+	if len(field.Names) == 0 {
+		return true
+	}
+	for _, n := range field.Names {
+		if n.IsExported() {
+			return true
+		}
+	}
+	return false
+}
+
+func fieldName(field *ast.Field) string {
+	if len(field.Names) == 0 {
+		return "(embedded)"
+	}
+	return field.Names[0].Name
+}
+
+func satisfies(opts []string) bool {
+	for _, o := range opts {
+		if o == require || (require == "any" && (o == "omitzero" || o == "omitempty")) {
+			return true
+		}
+	}
+	return false
+}
+
+func describe(policy string) string {
+	if policy == "any" {
+		return "omitzero or omitempty"
+	}
+	return policy
+}
+
+// suggestFix rewrites the field tag so its json key carries option. m is the
+// submatch index of the json key within tag, or nil if there is none.
+func suggestFix(field *ast.Field, tag string, m []int, option string) (analysis.SuggestedFix, bool) {
+	var newTag string
+	if m != nil {
+		newTag = tag[:m[5]] + "," + option + tag[m[5]:]
+	} else if tag == "" {
+		newTag = `json:",` + option + `"`
+	} else {
+		newTag = `json:",` + option + `" ` + tag
+	}
+	if strings.Contains(newTag, "`") {
+		return analysis.SuggestedFix{}, false
+	}
+
+	edit := analysis.TextEdit{NewText: []byte("`" + newTag + "`")}
+	if field.Tag != nil {
+		edit.Pos, edit.End = field.Tag.Pos(), field.Tag.End()
+	} else {
+		edit.Pos, edit.End = field.Type.End(), field.Type.End()
+		edit.NewText = append([]byte(" "), edit.NewText...)
+	}
+	return analysis.SuggestedFix{
+		Message:   "Add " + option + " to the json tag",
+		TextEdits: []analysis.TextEdit{edit},
+	}, true
+}
@@ -0,0 +1,12 @@
+package omitnull_test
+
+import (
+	"testing"
+
+	"github.com/manattan/nullable/analysis/omitnull"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), omitnull.Analyzer, "a")
+}
@@ -0,0 +1,20 @@
+package a
+
+import "github.com/manattan/nullable"
+
+type User struct {
+	ID      int64
+	Name    nullable.Nullable[string] `json:"name,omitzero"`
+	Email   nullable.Nullable[string] `json:"email"`         // want `Nullable field Email has no omitzero json option`
+	Age     nullable.Nullable[int]    `json:"age,omitempty"` // want `Nullable field Age has no omitzero json option`
+	Nick    nullable.Nullable[string] // want `Nullable field Nick has no omitzero json option`
+	Phone   nullable.Nullable[string] `db:"phone"` // want `Nullable field Phone has no omitzero json option`
+	Skipped nullable.Nullable[string] `json:"-"`
+	private nullable.Nullable[string]
+}
+
+type Alias = nullable.Nullable[string]
+
+type Wrapper struct {
+	A Alias `json:"a"` // want `Nullable field A has no omitzero json option`
+}
@@ -0,0 +1,6 @@
+package nullable
+
+type Nullable[T any] struct {
+	V     T
+	Valid bool
+}
@@ -0,0 +1,388 @@
+package nullable
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// AppendText implements the encoding.TextAppender interface. A null value
+// appends nothing; a valid value appends its text form, using the value's
+// own AppendText or MarshalText method when it has one.
+func (n Nullable[T]) AppendText(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return b, nil
+	}
+	// The common cases are handled without boxing n.V so that appending to a
+	// buffer with spare capacity does not allocate.
+	switch v := any(n.V).(type) {
+	case string:
+		return append(b, v...), nil
+	case int:
+		return strconv.AppendInt(b, int64(v), 10), nil
+	case int64:
+		return strconv.AppendInt(b, v, 10), nil
+	case int32:
+		return strconv.AppendInt(b, int64(v), 10), nil
+	case float64:
+		return strconv.AppendFloat(b, v, 'g', -1, 64), nil
+	case bool:
+		return strconv.AppendBool(b, v), nil
+	}
+
+	switch v := any(n.V).(type) {
+	case encoding.TextAppender:
+		return v.AppendText(b)
+	case encoding.TextMarshaler:
+		text, err := v.MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		return append(b, text...), nil
+	case []byte:
+		return append(b, v...), nil
+	}
+
+	rv := reflect.ValueOf(n.V)
+	switch rv.Kind() {
+	case reflect.String:
+		return append(b, rv.String()...), nil
+	case reflect.Bool:
+		return strconv.AppendBool(b, rv.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.AppendInt(b, rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.AppendUint(b, rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.AppendFloat(b, rv.Float(), 'g', -1, rv.Type().Bits()), nil
+	}
+	return fmt.Append(b, n.V), nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface with the text
+// form described for AppendText. A null value marshals as empty text.
+func (n Nullable[T]) MarshalText() ([]byte, error) {
+	if !n.Valid {
+		return []byte{}, nil
+	}
+	return n.AppendText(nil)
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. Empty
+// text decodes as null, so that it reads back what MarshalText writes; any
+// other text is parsed into the value, using the value's own UnmarshalText
+// method when it has one. On error n is left unchanged.
+func (n *Nullable[T]) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		n.Reset()
+		return nil
+	}
+	var v T
+	if err := parseText(reflect.ValueOf(&v).Elem(), string(text)); err != nil {
+		return err
+	}
+	n.V, n.Valid = v, true
+	return nil
+}
+
+// parseText stores the text form s in v, the inverse of AppendText for
+// strings, byte slices, booleans, numbers and encoding.TextUnmarshalers.
+// Empty text leaves other kinds at their zero value, as in encoding/xml.
+func parseText(v reflect.Value, s string) error {
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(s))
+	}
+	t := v.Type()
+	if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+		v.SetBytes([]byte(s))
+		return nil
+	}
+	if t.Kind() == reflect.String {
+		v.SetString(s)
+		return nil
+	}
+	if s == "" {
+		return nil
+	}
+
+	var err error
+	switch t.Kind() {
+	case reflect.Bool:
+		var b bool
+		if b, err = strconv.ParseBool(s); err == nil {
+			v.SetBool(b)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var x int64
+		if x, err = strconv.ParseInt(s, 10, t.Bits()); err == nil {
+			v.SetInt(x)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var x uint64
+		if x, err = strconv.ParseUint(s, 10, t.Bits()); err == nil {
+			v.SetUint(x)
+		}
+	case reflect.Float32, reflect.Float64:
+		var x float64
+		if x, err = strconv.ParseFloat(s, t.Bits()); err == nil {
+			v.SetFloat(x)
+		}
+	default:
+		return fmt.Errorf("nullable: no text decoding for %s", t)
+	}
+	if err != nil {
+		return fmt.Errorf("nullable: invalid %s %q", t, s)
+	}
+	return nil
+}
+
+// AppendBinary implements the encoding.BinaryAppender interface. The
+// encoding is a validity byte (0 for null, 1 for valid) followed, for valid
+// values, by the value: varints for integers, 8 little-endian bytes for
+// floats, one byte for booleans, the raw bytes for strings and byte slices,
+// or the value's own AppendBinary or MarshalBinary output.
+func (n Nullable[T]) AppendBinary(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return append(b, 0), nil
+	}
+	b = append(b, 1)
+	switch v := any(n.V).(type) {
+	case encoding.BinaryAppender:
+		return v.AppendBinary(b)
+	case encoding.BinaryMarshaler:
+		data, err := v.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		return append(b, data...), nil
+	case []byte:
+		return append(b, v...), nil
+	}
+
+	rv := reflect.ValueOf(n.V)
+	switch rv.Kind() {
+	case reflect.String:
+		return append(b, rv.String()...), nil
+	case reflect.Bool:
+		if rv.Bool() {
+			return append(b, 1), nil
+		}
+		return append(b, 0), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return binary.AppendVarint(b, rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return binary.AppendUvarint(b, rv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return binary.LittleEndian.AppendUint64(b, math.Float64bits(rv.Float())), nil
+	}
+	return nil, fmt.Errorf("nullable: no binary encoding for %T", n.V)
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface with the
+// encoding described for AppendBinary, so that Nullables can be stored
+// directly in caches and key-value stores.
+func (n Nullable[T]) MarshalBinary() ([]byte, error) {
+	return n.AppendBinary(nil)
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface,
+// decoding the output of MarshalBinary. On error n is left unchanged.
+func (n *Nullable[T]) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return errors.New("nullable: empty binary data")
+	}
+	switch data[0] {
+	case 0:
+		if len(data) > 1 {
+			return errors.New("nullable: trailing data after binary null")
+		}
+		n.Reset()
+		return nil
+	case 1:
+	default:
+		return fmt.Errorf("nullable: invalid binary validity byte %d", data[0])
+	}
+
+	var v T
+	if err := unmarshalBinaryValue(reflect.ValueOf(&v).Elem(), data[1:]); err != nil {
+		return err
+	}
+	n.V, n.Valid = v, true
+	return nil
+}
+
+func unmarshalBinaryValue(v reflect.Value, data []byte) error {
+	t := v.Type()
+	if u, ok := v.Addr().Interface().(encoding.BinaryUnmarshaler); ok {
+		return u.UnmarshalBinary(data)
+	}
+	if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+		v.SetBytes(bytes.Clone(data))
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		v.SetString(string(data))
+		return nil
+	case reflect.Bool:
+		if len(data) != 1 || data[0] > 1 {
+			return fmt.Errorf("nullable: invalid binary %s", t)
+		}
+		v.SetBool(data[0] == 1)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		x, k := binary.Varint(data)
+		if k <= 0 || k != len(data) {
+			return fmt.Errorf("nullable: invalid binary %s", t)
+		}
+		if v.OverflowInt(x) {
+			return fmt.Errorf("nullable: binary value %d overflows %s", x, t)
+		}
+		v.SetInt(x)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		x, k := binary.Uvarint(data)
+		if k <= 0 || k != len(data) {
+			return fmt.Errorf("nullable: invalid binary %s", t)
+		}
+		if v.OverflowUint(x) {
+			return fmt.Errorf("nullable: binary value %d overflows %s", x, t)
+		}
+		v.SetUint(x)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		if len(data) != 8 {
+			return fmt.Errorf("nullable: invalid binary %s", t)
+		}
+		v.SetFloat(math.Float64frombits(binary.LittleEndian.Uint64(data)))
+		return nil
+	}
+	return fmt.Errorf("nullable: no binary encoding for %s", t)
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface like
+// Nullable.UnmarshalText, rejecting invalid addresses.
+func (e *Email) UnmarshalText(text []byte) error {
+	return decodeChecked(&e.Nullable, validateEmail, func(dst *Nullable[string]) error {
+		return dst.UnmarshalText(text)
+	})
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface like
+// Nullable.UnmarshalText, rejecting numbers that are not E.164.
+func (p *Phone) UnmarshalText(text []byte) error {
+	return decodeChecked(&p.Nullable, validatePhone, func(dst *Nullable[string]) error {
+		return dst.UnmarshalText(text)
+	})
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface like
+// Nullable.UnmarshalText, rejecting negative values.
+func (n *NonNegative[T]) UnmarshalText(text []byte) error {
+	return decodeChecked(&n.Nullable, validateNonNegative[T], func(dst *Nullable[T]) error {
+		return dst.UnmarshalText(text)
+	})
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface like
+// Nullable.UnmarshalText, rejecting values that are not positive.
+func (n *Positive[T]) UnmarshalText(text []byte) error {
+	return decodeChecked(&n.Nullable, validatePositive[T], func(dst *Nullable[T]) error {
+		return dst.UnmarshalText(text)
+	})
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface like
+// Nullable.UnmarshalText, rejecting values outside 0 to 100.
+func (n *Percentage[T]) UnmarshalText(text []byte) error {
+	return decodeChecked(&n.Nullable, validatePercentage[T], func(dst *Nullable[T]) error {
+		return dst.UnmarshalText(text)
+	})
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface like
+// Nullable.UnmarshalText, marking o present.
+func (o *Omittable[T]) UnmarshalText(text []byte) error {
+	if err := o.Nullable.UnmarshalText(text); err != nil {
+		return err
+	}
+	o.present = true
+	return nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface like
+// Nullable.UnmarshalBinary, rejecting invalid addresses.
+func (e *Email) UnmarshalBinary(data []byte) error {
+	return decodeChecked(&e.Nullable, validateEmail, func(dst *Nullable[string]) error {
+		return dst.UnmarshalBinary(data)
+	})
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface like
+// Nullable.UnmarshalBinary, rejecting numbers that are not E.164.
+func (p *Phone) UnmarshalBinary(data []byte) error {
+	return decodeChecked(&p.Nullable, validatePhone, func(dst *Nullable[string]) error {
+		return dst.UnmarshalBinary(data)
+	})
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface like
+// Nullable.UnmarshalBinary, rejecting negative values.
+func (n *NonNegative[T]) UnmarshalBinary(data []byte) error {
+	return decodeChecked(&n.Nullable, validateNonNegative[T], func(dst *Nullable[T]) error {
+		return dst.UnmarshalBinary(data)
+	})
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface like
+// Nullable.UnmarshalBinary, rejecting values that are not positive.
+func (n *Positive[T]) UnmarshalBinary(data []byte) error {
+	return decodeChecked(&n.Nullable, validatePositive[T], func(dst *Nullable[T]) error {
+		return dst.UnmarshalBinary(data)
+	})
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface like
+// Nullable.UnmarshalBinary, rejecting values outside 0 to 100.
+func (n *Percentage[T]) UnmarshalBinary(data []byte) error {
+	return decodeChecked(&n.Nullable, validatePercentage[T], func(dst *Nullable[T]) error {
+		return dst.UnmarshalBinary(data)
+	})
+}
+
+// AppendBinary implements the encoding.BinaryAppender interface. An absent
+// value appends nothing, and a present one appends like
+// Nullable.AppendBinary, so that the encoding keeps presence as gob does.
+func (o Omittable[T]) AppendBinary(b []byte) ([]byte, error) {
+	if !o.present {
+		return b, nil
+	}
+	return o.Nullable.AppendBinary(b)
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface with the
+// encoding described for AppendBinary.
+func (o Omittable[T]) MarshalBinary() ([]byte, error) {
+	return o.AppendBinary([]byte{})
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface,
+// decoding the output of MarshalBinary: empty data is absent, and anything
+// else is decoded like Nullable.UnmarshalBinary and marks o present.
+func (o *Omittable[T]) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		o.Reset()
+		return nil
+	}
+	if err := o.Nullable.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	o.present = true
+	return nil
+}
@@ -0,0 +1,231 @@
+package nullable
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var (
+	_ encoding.TextAppender      = Nullable[int]{}
+	_ encoding.BinaryAppender    = Nullable[int]{}
+	_ encoding.BinaryMarshaler   = Nullable[int]{}
+	_ encoding.BinaryUnmarshaler = (*Nullable[int])(nil)
+)
+
+type appendStatus string
+
+func TestAppendText(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name string
+		fn   func([]byte) ([]byte, error)
+		want string
+	}{
+		{"null", NewNull[int]().AppendText, "x="},
+		{"int", NewNullable(-42).AppendText, "x=-42"},
+		{"uint", NewNullable(uint8(7)).AppendText, "x=7"},
+		{"float", NewNullable(1.5).AppendText, "x=1.5"},
+		{"bool", NewNullable(true).AppendText, "x=true"},
+		{"string", NewNullable("hi").AppendText, "x=hi"},
+		{"defined string", NewNullable(appendStatus("on")).AppendText, "x=on"},
+		{"bytes", NewNullable([]byte("raw")).AppendText, "x=raw"},
+		{"time", NewNullable(ts).AppendText, "x=2024-01-02T03:04:05Z"},
+		{"decimal", NewNullable(MustParseDecimal("1.50")).AppendText, "x=1.50"},
+		{"struct", NewNullable(struct{ A int }{1}).AppendText, "x={1}"},
+	}
+	for _, tt := range tests {
+		got, err := tt.fn([]byte("x="))
+		if err != nil {
+			t.Errorf("%s: Unexpected error: %v", tt.name, err)
+			continue
+		}
+		if string(got) != tt.want {
+			t.Errorf("%s: Expected %q, got %q", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestAppendTextAllocations(t *testing.T) {
+	n := NewNullable(int64(123456))
+	buf := make([]byte, 0, 64)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf, _ = n.AppendText(buf[:0])
+	})
+	if allocs > 0 {
+		t.Errorf("Expected no allocations, got %v", allocs)
+	}
+}
+
+func TestAppendBinary(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func([]byte) ([]byte, error)
+		want []byte
+	}{
+		{"null", NewNull[string]().AppendBinary, []byte{0}},
+		{"int", NewNullable(-1).AppendBinary, []byte{1, 1}},
+		{"uint", NewNullable(uint(300)).AppendBinary, []byte{1, 0xac, 0x02}},
+		{"bool", NewNullable(true).AppendBinary, []byte{1, 1}},
+		{"string", NewNullable("ab").AppendBinary, []byte{1, 'a', 'b'}},
+		{"float", NewNullable(1.0).AppendBinary, append([]byte{1}, 0, 0, 0, 0, 0, 0, 0xf0, 0x3f)},
+	}
+	for _, tt := range tests {
+		got, err := tt.fn(nil)
+		if err != nil {
+			t.Errorf("%s: Unexpected error: %v", tt.name, err)
+			continue
+		}
+		if !bytes.Equal(got, tt.want) {
+			t.Errorf("%s: Expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	got, err := NewNullable(ts).AppendBinary(nil)
+	want, _ := ts.MarshalBinary()
+	if err != nil || got[0] != 1 || !bytes.Equal(got[1:], want) {
+		t.Errorf("Expected time binary encoding, got %v (%v)", got, err)
+	}
+
+	if _, err := NewNullable(map[string]int{}).AppendBinary(nil); err == nil {
+		t.Error("Expected error for unsupported type")
+	}
+}
+
+func TestMarshalBinary(t *testing.T) {
+	roundTrip := func(name string, src encoding.BinaryMarshaler, dst encoding.BinaryUnmarshaler, get func() any, want any) {
+		t.Helper()
+		data, err := src.MarshalBinary()
+		if err != nil {
+			t.Errorf("%s: Unexpected error: %v", name, err)
+			return
+		}
+		if err := dst.UnmarshalBinary(data); err != nil {
+			t.Errorf("%s: Unexpected error: %v", name, err)
+			return
+		}
+		if got := get(); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: Expected %v, got %v", name, want, got)
+		}
+	}
+
+	var s Nullable[string]
+	roundTrip("string", NewNullable("ab"), &s, func() any { return s }, NewNullable("ab"))
+	var i Nullable[int8]
+	roundTrip("int8", NewNullable(int8(-100)), &i, func() any { return i }, NewNullable(int8(-100)))
+	var u Nullable[uint]
+	roundTrip("uint", NewNullable(uint(300)), &u, func() any { return u }, NewNullable(uint(300)))
+	var f Nullable[float32]
+	roundTrip("float32", NewNullable(float32(1.5)), &f, func() any { return f }, NewNullable(float32(1.5)))
+	var b Nullable[bool]
+	roundTrip("bool", NewNullable(true), &b, func() any { return b }, NewNullable(true))
+	var raw Nullable[[]byte]
+	roundTrip("bytes", NewNullable([]byte{1, 2}), &raw, func() any { return raw }, NewNullable([]byte{1, 2}))
+
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	var tm Nullable[time.Time]
+	roundTrip("time", NewNullable(ts), &tm, func() any { return tm.V.Equal(ts) && tm.Valid }, true)
+
+	n := NewNullable("stale")
+	roundTrip("null", NewNull[string](), &n, func() any { return n }, NewNull[string]())
+}
+
+func TestUnmarshalBinaryErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		dst  encoding.BinaryUnmarshaler
+		data []byte
+	}{
+		{"empty", new(Nullable[int]), nil},
+		{"bad flag", new(Nullable[int]), []byte{2}},
+		{"trailing null", new(Nullable[int]), []byte{0, 1}},
+		{"truncated varint", new(Nullable[int]), []byte{1, 0x80}},
+		{"trailing varint", new(Nullable[int]), []byte{1, 1, 1}},
+		{"overflow", new(Nullable[int8]), append([]byte{1}, binary.AppendVarint(nil, 1000)...)},
+		{"short float", new(Nullable[float64]), []byte{1, 0, 0}},
+		{"bad bool", new(Nullable[bool]), []byte{1, 2}},
+		{"unsupported", new(Nullable[map[string]int]), []byte{1}},
+	}
+	for _, tt := range tests {
+		if err := tt.dst.UnmarshalBinary(tt.data); err == nil {
+			t.Errorf("%s: Expected error", tt.name)
+		}
+	}
+
+	n := NewNullable(7)
+	if err := n.UnmarshalBinary([]byte{1, 0x80}); err == nil || n != NewNullable(7) {
+		t.Errorf("Expected error and unchanged value, got %v (%v)", n, err)
+	}
+}
+
+func TestValidatedUnmarshalBinary(t *testing.T) {
+	good, _ := NewNullable("ada@example.com").MarshalBinary()
+	bad, _ := NewNullable("nope").MarshalBinary()
+	var e Email
+	if err := e.UnmarshalBinary(good); err != nil || e.V != "ada@example.com" {
+		t.Errorf("Expected ada@example.com, got %v (%v)", e, err)
+	}
+	var fe *FormatError
+	if err := e.UnmarshalBinary(bad); !errors.As(err, &fe) || e.V != "ada@example.com" {
+		t.Errorf("Expected *FormatError and unchanged value, got %v (%v)", e, err)
+	}
+	var p Phone
+	if err := p.UnmarshalBinary(bad); !errors.As(err, &fe) || p.Valid {
+		t.Errorf("Expected *FormatError, got %v (%v)", p, err)
+	}
+
+	negative, _ := NewNullable(-1).MarshalBinary()
+	zero, _ := NewNullable(0).MarshalBinary()
+	tests := []struct {
+		name string
+		dst  encoding.BinaryUnmarshaler
+		data []byte
+	}{
+		{"non-negative", new(NonNegative[int]), negative},
+		{"positive", new(Positive[int]), zero},
+		{"percentage", new(Percentage[int]), negative},
+	}
+	for _, tt := range tests {
+		var re *RangeError
+		if err := tt.dst.UnmarshalBinary(tt.data); !errors.As(err, &re) {
+			t.Errorf("%s: Expected *RangeError, got %v", tt.name, err)
+		}
+	}
+	var q NonNegative[int]
+	if err := q.UnmarshalBinary(zero); err != nil || !q.Valid {
+		t.Errorf("Expected valid 0, got %v (%v)", q, err)
+	}
+}
+
+func TestOmittableBinary(t *testing.T) {
+	tests := []struct {
+		name string
+		in   Omittable[int]
+		data []byte
+	}{
+		{"absent", Omittable[int]{}, []byte{}},
+		{"null", NewOmittableNull[int](), []byte{0}},
+		{"set", NewOmittable(5), []byte{1, 10}},
+	}
+	for _, tt := range tests {
+		data, err := tt.in.MarshalBinary()
+		if err != nil || !bytes.Equal(data, tt.data) {
+			t.Errorf("%s: Expected %v, got %v (%v)", tt.name, tt.data, data, err)
+			continue
+		}
+		out := NewOmittable(-1)
+		if err := out.UnmarshalBinary(data); err != nil || out != tt.in {
+			t.Errorf("%s: Expected %v, got %v (%v)", tt.name, tt.in, out, err)
+		}
+	}
+
+	o := NewOmittable(7)
+	if err := o.UnmarshalBinary([]byte{2}); err == nil || o != NewOmittable(7) {
+		t.Errorf("Expected error and unchanged value, got %v (%v)", o, err)
+	}
+}
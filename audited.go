@@ -0,0 +1,88 @@
+package nullable
+
+import "reflect"
+
+// Audited tracks a nullable value as originally loaded alongside any
+// pending new value, for change-tracking persistence layers and audit
+// trails:
+//
+//	u.Email.Set(nullable.NewNullable("ada@example.com"))
+//	if u.Email.Changed() {
+//		old, new := u.Email.OldNew()
+//		audit.Record("email", old, new)
+//	}
+//
+// The zero value was loaded as null and has no pending change. Values are
+// compared with their Equal method when they have one, such as time.Time,
+// and with reflect.DeepEqual otherwise.
+type Audited[T any] struct {
+	old, new Nullable[T]
+	pending  bool
+}
+
+// NewAudited returns an Audited loaded with n and no pending change.
+func NewAudited[T any](n Nullable[T]) Audited[T] {
+	return Audited[T]{old: n}
+}
+
+// Set records n as the pending new value.
+func (a *Audited[T]) Set(n Nullable[T]) {
+	a.new, a.pending = n, true
+}
+
+// Get returns the pending new value if one was set, and the original value
+// otherwise.
+func (a Audited[T]) Get() Nullable[T] {
+	if a.pending {
+		return a.new
+	}
+	return a.old
+}
+
+// Changed reports whether a pending new value differs from the original.
+// Setting the value it already had is not a change.
+func (a Audited[T]) Changed() bool {
+	return a.pending && !nullablesEqual(a.old, a.new)
+}
+
+// OldNew returns the original value and the current one, which is the
+// original when nothing is pending.
+func (a Audited[T]) OldNew() (old, new Nullable[T]) {
+	return a.old, a.Get()
+}
+
+// Commit makes the current value the original, typically after it has been
+// saved.
+func (a *Audited[T]) Commit() {
+	a.old, a.new, a.pending = a.Get(), Nullable[T]{}, false
+}
+
+// Reset discards any pending new value.
+func (a *Audited[T]) Reset() {
+	a.new, a.pending = Nullable[T]{}, false
+}
+
+// Scan implements the sql.Scanner interface. It loads the original value
+// and discards any pending one, so an Audited field can be scanned
+// directly from a row.
+func (a *Audited[T]) Scan(value any) error {
+	var n Nullable[T]
+	if err := n.Scan(value); err != nil {
+		return err
+	}
+	*a = NewAudited(n)
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface by encoding the
+// current value.
+func (a Audited[T]) MarshalJSON() ([]byte, error) {
+	return a.Get().MarshalJSON()
+}
+
+func nullablesEqual[T any](x, y Nullable[T]) bool {
+	if !x.Valid || !y.Valid {
+		return x.Valid == y.Valid
+	}
+	return valuesEqual(reflect.ValueOf(&x.V).Elem(), reflect.ValueOf(&y.V).Elem())
+}
@@ -0,0 +1,85 @@
+package nullable
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestAudited(t *testing.T) {
+	a := NewAudited(NewNullable("draft"))
+	if a.Changed() || a.Get() != NewNullable("draft") {
+		t.Errorf("Expected unchanged draft, got %+v", a)
+	}
+
+	a.Set(NewNullable("draft"))
+	if a.Changed() {
+		t.Error("Expected setting the same value not to be a change")
+	}
+
+	a.Set(NewNull[string]())
+	if !a.Changed() {
+		t.Error("Expected change to null")
+	}
+	old, new := a.OldNew()
+	if old != NewNullable("draft") || new.Valid {
+		t.Errorf("Expected draft -> null, got %v -> %v", old, new)
+	}
+
+	a.Reset()
+	if a.Changed() || a.Get() != NewNullable("draft") {
+		t.Errorf("Expected reset to draft, got %+v", a)
+	}
+
+	a.Set(NewNullable("published"))
+	a.Commit()
+	if a.Changed() {
+		t.Error("Expected no change after commit")
+	}
+	if old, new := a.OldNew(); old != NewNullable("published") || new != old {
+		t.Errorf("Expected published -> published, got %v -> %v", old, new)
+	}
+
+	var zero Audited[int]
+	if zero.Changed() || zero.Get().Valid {
+		t.Errorf("Expected null zero value, got %+v", zero)
+	}
+	zero.Set(NewNull[int]())
+	if zero.Changed() {
+		t.Error("Expected null -> null not to be a change")
+	}
+}
+
+func TestAuditedEqualMethod(t *testing.T) {
+	when := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	a := NewAudited(NewNullable(when))
+	a.Set(NewNullable(when.In(time.FixedZone("CET", 3600))))
+	if a.Changed() {
+		t.Error("Expected the same instant in another zone not to be a change")
+	}
+	b := NewAudited(NewNullable([]string{"a"}))
+	b.Set(NewNullable([]string{"a"}))
+	if b.Changed() {
+		t.Error("Expected equal slices not to be a change")
+	}
+}
+
+func TestAuditedScanJSON(t *testing.T) {
+	var a Audited[int64]
+	a.Set(NewNullable[int64](1))
+	if err := a.Scan(int64(42)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if a.Changed() || a.Get() != NewNullable[int64](42) {
+		t.Errorf("Expected loaded 42, got %+v", a)
+	}
+
+	a.Set(NewNullable[int64](7))
+	data, err := json.Marshal(a)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(data) != "7" {
+		t.Errorf("Expected 7, got %s", data)
+	}
+}
@@ -0,0 +1,489 @@
+package nullable
+
+import (
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+
+	"github.com/manattan/nullable/internal/nullreflect"
+)
+
+// BinaryVersion is the version of the encoding written by EncodeBinary.
+const BinaryVersion = 1
+
+const binaryMagic = 'N'
+
+// ErrBinaryVersion is returned by DecodeBinary for data that is not in the
+// binary format or was written by a newer, unknown version.
+var ErrBinaryVersion = errors.New("nullable: unsupported binary encoding version")
+
+// EncodeBinary returns a compact binary encoding of v for caches such as
+// bigcache, groupcache or memcached. The encoding starts with a version
+// header; Nullables are a null flag byte followed by the value; strings,
+// byte slices, struct fields and values implementing encoding.BinaryMarshaler
+// or encoding.TextMarshaler are length-prefixed. Struct fields are
+// keyed by Go field name, so data written before fields were added or
+// removed still decodes: unknown fields are skipped and missing fields keep
+// their zero value. Integers are varints, so widening an integer field is
+// also compatible.
+func EncodeBinary(v any) ([]byte, error) {
+	buf := []byte{binaryMagic, BinaryVersion}
+	return appendBinaryValue(buf, reflect.ValueOf(v))
+}
+
+// DecodeBinary decodes data produced by EncodeBinary into the value pointed
+// to by v.
+func DecodeBinary(data []byte, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("nullable: DecodeBinary requires a non-nil pointer, got %T", v)
+	}
+	if len(data) < 2 || data[0] != binaryMagic || data[1] == 0 || data[1] > BinaryVersion {
+		return ErrBinaryVersion
+	}
+	r := &binaryReader{data: data[2:]}
+	if err := decodeBinaryValue(r, rv.Elem()); err != nil {
+		return err
+	}
+	if len(r.data) != 0 {
+		return fmt.Errorf("nullable: %d trailing bytes after binary value", len(r.data))
+	}
+	return nil
+}
+
+var (
+	binaryMarshalerType   = reflect.TypeFor[encoding.BinaryMarshaler]()
+	binaryUnmarshalerType = reflect.TypeFor[encoding.BinaryUnmarshaler]()
+	textMarshalerType     = reflect.TypeFor[encoding.TextMarshaler]()
+	textUnmarshalerType   = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+func appendBinaryValue(buf []byte, v reflect.Value) ([]byte, error) {
+	if !v.IsValid() {
+		return nil, errors.New("nullable: cannot binary-encode nil")
+	}
+	t := v.Type()
+	if nullreflect.IsNullable(t) {
+		if !nullreflect.Valid(v) {
+			return append(buf, 0), nil
+		}
+		return appendBinaryValue(append(buf, 1), nullreflect.Inner(v))
+	}
+	// Wrapper types inherit MarshalBinary and MarshalText from their
+	// embedded Nullable but keep being encoded as structs, so that existing
+	// data still decodes.
+	if t.Implements(binaryMarshalerType) && t.Kind() != reflect.Pointer && !nullreflect.IsWrapper(t) {
+		data, err := v.Interface().(encoding.BinaryMarshaler).MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		return appendBinaryBytes(buf, data), nil
+	}
+	if t.Implements(textMarshalerType) && t.Kind() != reflect.Pointer && !nullreflect.IsWrapper(t) {
+		data, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		return appendBinaryBytes(buf, data), nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			return append(buf, 1), nil
+		}
+		return append(buf, 0), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return binary.AppendVarint(buf, v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return binary.AppendUvarint(buf, v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return binary.LittleEndian.AppendUint64(buf, math.Float64bits(v.Float())), nil
+	case reflect.String:
+		return appendBinaryBytes(buf, []byte(v.String())), nil
+	case reflect.Pointer:
+		if v.IsNil() {
+			return append(buf, 0), nil
+		}
+		return appendBinaryValue(append(buf, 1), v.Elem())
+	case reflect.Slice:
+		if v.IsNil() {
+			return append(buf, 0), nil
+		}
+		buf = append(buf, 1)
+		if t.Elem().Kind() == reflect.Uint8 {
+			return appendBinaryBytes(buf, v.Bytes()), nil
+		}
+		fallthrough
+	case reflect.Array:
+		buf = binary.AppendUvarint(buf, uint64(v.Len()))
+		for i := 0; i < v.Len(); i++ {
+			var err error
+			if buf, err = appendBinaryValue(buf, v.Index(i)); err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	case reflect.Map:
+		if v.IsNil() {
+			return append(buf, 0), nil
+		}
+		buf = append(buf, 1)
+		buf = binary.AppendUvarint(buf, uint64(v.Len()))
+		keys := v.MapKeys()
+		encoded := make([][]byte, len(keys))
+		for i, k := range keys {
+			var err error
+			if encoded[i], err = appendBinaryValue(nil, k); err != nil {
+				return nil, err
+			}
+		}
+		order := make([]int, len(keys))
+		for i := range order {
+			order[i] = i
+		}
+		sort.Slice(order, func(i, j int) bool { return string(encoded[order[i]]) < string(encoded[order[j]]) })
+		for _, i := range order {
+			buf = append(buf, encoded[i]...)
+			var err error
+			if buf, err = appendBinaryValue(buf, v.MapIndex(keys[i])); err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	case reflect.Struct:
+		return appendBinaryStruct(buf, v)
+	}
+	return nil, fmt.Errorf("nullable: cannot binary-encode %s", t)
+}
+
+// binaryPresence is implemented by *Omittable, whose unexported presence
+// flag is encoded as an extra struct field named binaryPresenceField. In
+// data written without it, valid values decode as present and nulls as
+// absent.
+type binaryPresence interface {
+	IsPresent() bool
+	setPresent(present bool)
+}
+
+const binaryPresenceField = "present"
+
+var binaryPresenceType = reflect.TypeFor[binaryPresence]()
+
+func (o *Omittable[T]) setPresent(present bool) {
+	o.present = present
+}
+
+func appendBinaryStruct(buf []byte, v reflect.Value) ([]byte, error) {
+	t := v.Type()
+	var fields []int
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			fields = append(fields, i)
+		}
+	}
+	hasPresence := reflect.PointerTo(t).Implements(binaryPresenceType)
+	n := len(fields)
+	if hasPresence {
+		n++
+	}
+	buf = binary.AppendUvarint(buf, uint64(n))
+	for _, i := range fields {
+		buf = appendBinaryBytes(buf, []byte(t.Field(i).Name))
+		data, err := appendBinaryValue(nil, v.Field(i))
+		if err != nil {
+			return nil, fmt.Errorf("%w (field %s.%s)", err, t.Name(), t.Field(i).Name)
+		}
+		buf = appendBinaryBytes(buf, data)
+	}
+	if hasPresence {
+		present := byte(0)
+		if v.Interface().(interface{ IsPresent() bool }).IsPresent() {
+			present = 1
+		}
+		buf = appendBinaryBytes(buf, []byte(binaryPresenceField))
+		buf = appendBinaryBytes(buf, []byte{present})
+	}
+	return buf, nil
+}
+
+func appendBinaryBytes(buf, data []byte) []byte {
+	return append(binary.AppendUvarint(buf, uint64(len(data))), data...)
+}
+
+type binaryReader struct {
+	data []byte
+}
+
+var errBinaryTruncated = errors.New("nullable: truncated binary data")
+
+func (r *binaryReader) byte() (byte, error) {
+	if len(r.data) == 0 {
+		return 0, errBinaryTruncated
+	}
+	b := r.data[0]
+	r.data = r.data[1:]
+	return b, nil
+}
+
+func (r *binaryReader) flag() (bool, error) {
+	b, err := r.byte()
+	if err != nil {
+		return false, err
+	}
+	if b > 1 {
+		return false, fmt.Errorf("nullable: invalid flag byte %d", b)
+	}
+	return b == 1, nil
+}
+
+func (r *binaryReader) uvarint() (uint64, error) {
+	x, n := binary.Uvarint(r.data)
+	if n <= 0 {
+		return 0, errBinaryTruncated
+	}
+	r.data = r.data[n:]
+	return x, nil
+}
+
+func (r *binaryReader) varint() (int64, error) {
+	x, n := binary.Varint(r.data)
+	if n <= 0 {
+		return 0, errBinaryTruncated
+	}
+	r.data = r.data[n:]
+	return x, nil
+}
+
+func (r *binaryReader) bytes() ([]byte, error) {
+	n, err := r.uvarint()
+	if err != nil {
+		return nil, err
+	}
+	if n > uint64(len(r.data)) {
+		return nil, errBinaryTruncated
+	}
+	b := r.data[:n]
+	r.data = r.data[n:]
+	return b, nil
+}
+
+// length reads an element count, bounding it by the remaining data so
+// corrupt input cannot trigger huge allocations.
+func (r *binaryReader) length() (int, error) {
+	n, err := r.uvarint()
+	if err != nil {
+		return 0, err
+	}
+	if n > uint64(len(r.data)) {
+		return 0, errBinaryTruncated
+	}
+	return int(n), nil
+}
+
+func decodeBinaryValue(r *binaryReader, v reflect.Value) error {
+	t := v.Type()
+	if nullreflect.IsNullable(t) {
+		valid, err := r.flag()
+		if err != nil {
+			return err
+		}
+		v.SetZero()
+		if !valid {
+			return nil
+		}
+		v.FieldByName("Valid").SetBool(true)
+		return decodeBinaryValue(r, nullreflect.Inner(v))
+	}
+	if reflect.PointerTo(t).Implements(binaryUnmarshalerType) && t.Kind() != reflect.Pointer && !nullreflect.IsWrapper(t) {
+		data, err := r.bytes()
+		if err != nil {
+			return err
+		}
+		return v.Addr().Interface().(encoding.BinaryUnmarshaler).UnmarshalBinary(data)
+	}
+	if t.Implements(textMarshalerType) && reflect.PointerTo(t).Implements(textUnmarshalerType) && t.Kind() != reflect.Pointer && !nullreflect.IsWrapper(t) {
+		data, err := r.bytes()
+		if err != nil {
+			return err
+		}
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText(data)
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		b, err := r.flag()
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		x, err := r.varint()
+		if err != nil {
+			return err
+		}
+		if v.OverflowInt(x) {
+			return fmt.Errorf("nullable: binary value %d overflows %s", x, t)
+		}
+		v.SetInt(x)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		x, err := r.uvarint()
+		if err != nil {
+			return err
+		}
+		if v.OverflowUint(x) {
+			return fmt.Errorf("nullable: binary value %d overflows %s", x, t)
+		}
+		v.SetUint(x)
+	case reflect.Float32, reflect.Float64:
+		if len(r.data) < 8 {
+			return errBinaryTruncated
+		}
+		v.SetFloat(math.Float64frombits(binary.LittleEndian.Uint64(r.data)))
+		r.data = r.data[8:]
+	case reflect.String:
+		b, err := r.bytes()
+		if err != nil {
+			return err
+		}
+		v.SetString(string(b))
+	case reflect.Pointer:
+		ok, err := r.flag()
+		if err != nil || !ok {
+			v.SetZero()
+			return err
+		}
+		elem := reflect.New(t.Elem())
+		if err := decodeBinaryValue(r, elem.Elem()); err != nil {
+			return err
+		}
+		v.Set(elem)
+	case reflect.Slice:
+		ok, err := r.flag()
+		if err != nil || !ok {
+			v.SetZero()
+			return err
+		}
+		if t.Elem().Kind() == reflect.Uint8 {
+			b, err := r.bytes()
+			if err != nil {
+				return err
+			}
+			v.SetBytes(append(make([]byte, 0, len(b)), b...))
+			return nil
+		}
+		n, err := r.length()
+		if err != nil {
+			return err
+		}
+		s := reflect.MakeSlice(t, n, n)
+		for i := 0; i < n; i++ {
+			if err := decodeBinaryValue(r, s.Index(i)); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+	case reflect.Array:
+		n, err := r.length()
+		if err != nil {
+			return err
+		}
+		if n != v.Len() {
+			return fmt.Errorf("nullable: binary array of length %d does not fit %s", n, t)
+		}
+		for i := 0; i < n; i++ {
+			if err := decodeBinaryValue(r, v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		ok, err := r.flag()
+		if err != nil || !ok {
+			v.SetZero()
+			return err
+		}
+		n, err := r.length()
+		if err != nil {
+			return err
+		}
+		m := reflect.MakeMapWithSize(t, n)
+		for i := 0; i < n; i++ {
+			k := reflect.New(t.Key()).Elem()
+			if err := decodeBinaryValue(r, k); err != nil {
+				return err
+			}
+			e := reflect.New(t.Elem()).Elem()
+			if err := decodeBinaryValue(r, e); err != nil {
+				return err
+			}
+			m.SetMapIndex(k, e)
+		}
+		v.Set(m)
+	case reflect.Struct:
+		if !reflect.PointerTo(t).Implements(validatorType) {
+			return decodeBinaryStruct(r, v)
+		}
+		// Decode validated wrappers into a copy, so that rejected values
+		// leave v unchanged.
+		decoded := reflect.New(t).Elem()
+		decoded.Set(v)
+		if err := decodeBinaryStruct(r, decoded); err != nil {
+			return err
+		}
+		if err := decoded.Addr().Interface().(validator).validate(); err != nil {
+			return err
+		}
+		v.Set(decoded)
+	default:
+		return fmt.Errorf("nullable: cannot binary-decode into %s", t)
+	}
+	return nil
+}
+
+func decodeBinaryStruct(r *binaryReader, v reflect.Value) error {
+	n, err := r.length()
+	if err != nil {
+		return err
+	}
+	t := v.Type()
+	presence, hasPresence := v.Addr().Interface().(binaryPresence)
+	sawPresence := false
+	for i := 0; i < n; i++ {
+		name, err := r.bytes()
+		if err != nil {
+			return err
+		}
+		data, err := r.bytes()
+		if err != nil {
+			return err
+		}
+		if hasPresence && string(name) == binaryPresenceField {
+			present, err := (&binaryReader{data: data}).flag()
+			if err != nil || len(data) != 1 {
+				return fmt.Errorf("nullable: invalid presence flag in %s", t.Name())
+			}
+			presence.setPresent(present)
+			sawPresence = true
+			continue
+		}
+		sf, ok := t.FieldByName(string(name))
+		if !ok || !sf.IsExported() || len(sf.Index) != 1 {
+			continue // field removed in this version
+		}
+		fr := &binaryReader{data: data}
+		if err := decodeBinaryValue(fr, v.Field(sf.Index[0])); err != nil {
+			return fmt.Errorf("%w (field %s.%s)", err, t.Name(), sf.Name)
+		}
+		if len(fr.data) != 0 {
+			return fmt.Errorf("nullable: %d trailing bytes in field %s.%s", len(fr.data), t.Name(), sf.Name)
+		}
+	}
+	if hasPresence && !sawPresence {
+		presence.setPresent(nullreflect.Valid(v.Field(0)))
+	}
+	return nil
+}
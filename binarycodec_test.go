@@ -0,0 +1,241 @@
+package nullable
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type cachedUser struct {
+	ID       int64
+	Name     Nullable[string]
+	Age      Nullable[int]
+	Score    float64
+	Tags     []string
+	Avatar   []byte
+	Manager  *cachedUser
+	Attrs    map[string]Nullable[int]
+	Joined   Nullable[time.Time]
+	Balance  Nullable[Decimal]
+	Location Nullable[Point]
+	Version  Nullable[Semver]
+	internal string
+}
+
+func TestBinaryRoundTrip(t *testing.T) {
+	joined := time.Date(2024, 3, 5, 10, 30, 0, 0, time.UTC)
+	in := cachedUser{
+		ID:       7,
+		Name:     NewNullable("Ada"),
+		Score:    -1.5,
+		Tags:     []string{"a", ""},
+		Avatar:   []byte{},
+		Manager:  &cachedUser{ID: 1, Age: NewNullable(50)},
+		Attrs:    map[string]Nullable[int]{"x": NewNullable(1), "y": NewNull[int]()},
+		Joined:   NewNullable(joined),
+		Balance:  NewNullable(MustParseDecimal("10.25")),
+		Location: NewNullable(Point{Lat: 1, Lon: 2}),
+		Version:  NewNullable(MustParseSemver("1.2.3")),
+		internal: "dropped",
+	}
+	data, err := EncodeBinary(in)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if data[0] != 'N' || data[1] != BinaryVersion {
+		t.Errorf("Expected version header, got %v", data[:2])
+	}
+
+	var out cachedUser
+	if err := DecodeBinary(data, &out); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	in.internal = ""
+	if !reflect.DeepEqual(in, out) {
+		t.Errorf("Expected %+v, got %+v", in, out)
+	}
+
+	again, _ := EncodeBinary(out)
+	if string(again) != string(data) {
+		t.Error("Expected deterministic encoding")
+	}
+}
+
+func TestBinaryNewerVersionReadsOlderData(t *testing.T) {
+	type v1 struct {
+		ID      int32
+		Name    Nullable[string]
+		Removed string
+	}
+	type v2 struct {
+		ID    int64
+		Name  Nullable[string]
+		Email Nullable[string]
+	}
+	data, err := EncodeBinary(v1{ID: 1 << 30, Name: NewNullable("Ada"), Removed: "x"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	out := v2{Email: NewNullable("stale")}
+	if err := DecodeBinary(data, &out); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if out.ID != 1<<30 || out.Name != NewNullable("Ada") {
+		t.Errorf("Expected ID and Name to decode, got %+v", out)
+	}
+	if out.Email != NewNullable("stale") {
+		t.Errorf("Expected missing field to be left alone, got %+v", out.Email)
+	}
+}
+
+func TestBinaryErrors(t *testing.T) {
+	var out cachedUser
+	for _, data := range [][]byte{nil, {'N'}, {'X', 1}, {'N', 0}, {'N', BinaryVersion + 1}} {
+		if err := DecodeBinary(data, &out); !errors.Is(err, ErrBinaryVersion) {
+			t.Errorf("%v: Expected ErrBinaryVersion, got %v", data, err)
+		}
+	}
+
+	data, _ := EncodeBinary(NewNullable("hello"))
+	var s Nullable[string]
+	if err := DecodeBinary(data[:len(data)-1], &s); err == nil {
+		t.Error("Expected error for truncated data")
+	}
+	if err := DecodeBinary(append(data, 0), &s); err == nil {
+		t.Error("Expected error for trailing data")
+	}
+	if err := DecodeBinary(data, s); err == nil {
+		t.Error("Expected error for non-pointer")
+	}
+
+	big, _ := EncodeBinary(int64(300))
+	var small int8
+	if err := DecodeBinary(big, &small); err == nil {
+		t.Error("Expected overflow error")
+	}
+	if _, err := EncodeBinary(make(chan int)); err == nil {
+		t.Error("Expected error for unsupported type")
+	}
+}
+
+func TestBinaryNulls(t *testing.T) {
+	type row struct {
+		A Nullable[int]
+		B *int
+		C []int
+		D map[string]int
+	}
+	data, err := EncodeBinary(row{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	out := row{A: NewNullable(1), B: new(int), C: []int{1}, D: map[string]int{}}
+	if err := DecodeBinary(data, &out); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if out.A.Valid || out.B != nil || out.C != nil || out.D != nil {
+		t.Errorf("Expected all null, got %+v", out)
+	}
+}
+
+func TestBinaryWrapperEncodedAsStruct(t *testing.T) {
+	p, _ := NewPositive(3)
+	data, err := EncodeBinary(p)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want, _ := EncodeBinary(struct{ Nullable Nullable[int] }{NewNullable(3)})
+	if string(data) != string(want) {
+		t.Errorf("Expected struct encoding %v, got %v", want, data)
+	}
+
+	var out Positive[int]
+	if err := DecodeBinary(data, &out); err != nil || out != p {
+		t.Errorf("Expected %v, got %v (%v)", p, out, err)
+	}
+}
+
+func TestBinaryOmittable(t *testing.T) {
+	type patch struct {
+		Name  Omittable[string]
+		Age   Omittable[int]
+		Email Omittable[string]
+	}
+	in := patch{Name: NewOmittable("Ada"), Age: NewOmittableNull[int]()}
+	data, err := EncodeBinary(in)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	out := patch{Email: NewOmittable("stale")}
+	if err := DecodeBinary(data, &out); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if out != in {
+		t.Errorf("Expected %v, got %v", in, out)
+	}
+	if !out.Age.IsNull() || out.Email.IsPresent() {
+		t.Errorf("Expected null age and absent email, got %v and %v", out.Age, out.Email)
+	}
+
+	// Data written before the presence flag was encoded keeps valid values
+	// present.
+	old, _ := EncodeBinary(struct{ Nullable Nullable[int] }{NewNullable(3)})
+	var o Omittable[int]
+	if err := DecodeBinary(old, &o); err != nil || o != NewOmittable(3) {
+		t.Errorf("Expected present 3, got %#v (%v)", o, err)
+	}
+	old, _ = EncodeBinary(struct{ Nullable Nullable[int] }{})
+	o = NewOmittable(1)
+	if err := DecodeBinary(old, &o); err != nil || o.IsPresent() {
+		t.Errorf("Expected absent, got %#v (%v)", o, err)
+	}
+}
+
+func TestBinaryValidatedWrappers(t *testing.T) {
+	type contact struct {
+		Email Email
+		Phone Phone
+		Seats Positive[int]
+		Spent NonNegative[float64]
+		Share Percentage[int]
+	}
+	email, _ := NewEmail("ada@example.com")
+	in := contact{Email: email, Seats: Positive[int]{NewNullable(2)}}
+	data, err := EncodeBinary(in)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var out contact
+	if err := DecodeBinary(data, &out); err != nil || out != in {
+		t.Errorf("Expected %+v, got %+v (%v)", in, out, err)
+	}
+
+	// Wrappers are encoded as structs holding a Nullable field, so these
+	// stand-ins produce data that decodes into contact.
+	type wrapped[T any] struct{ Nullable Nullable[T] }
+	tests := []struct {
+		name string
+		in   any
+	}{
+		{"email", struct{ Email wrapped[string] }{wrapped[string]{NewNullable("nope")}}},
+		{"phone", struct{ Phone wrapped[string] }{wrapped[string]{NewNullable("555")}}},
+		{"positive", struct{ Seats wrapped[int] }{wrapped[int]{NewNullable(0)}}},
+		{"non-negative", struct{ Spent wrapped[float64] }{wrapped[float64]{NewNullable(-1.0)}}},
+		{"percentage", struct{ Share wrapped[int] }{wrapped[int]{NewNullable(101)}}},
+	}
+	for _, tt := range tests {
+		bad, err := EncodeBinary(tt.in)
+		if err != nil {
+			t.Fatalf("%s: Unexpected error: %v", tt.name, err)
+		}
+		var fe *FormatError
+		var re *RangeError
+		if err := DecodeBinary(bad, &out); !errors.As(err, &fe) && !errors.As(err, &re) {
+			t.Errorf("%s: Expected validation error, got %v", tt.name, err)
+		}
+	}
+	if out.Email != email || out.Seats.V != 2 {
+		t.Errorf("Expected rejected values to leave fields unchanged, got %+v", out)
+	}
+}
@@ -0,0 +1,128 @@
+package nullable
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/bits"
+)
+
+// Unsigned is the set of unsigned integer types that can back a Bitmask.
+type Unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// FlagNamer is implemented by flag types that encode a Bitmask as a JSON list
+// of names. FlagNames returns the name of each bit, lowest first; an empty
+// name leaves that bit unnamed.
+type FlagNamer interface {
+	FlagNames() []string
+}
+
+// Bitmask is a nullable set of bit flags, for optional permission or
+// feature-flag columns. It scans like any Nullable integer. In JSON it is an
+// integer, or a list of names when T implements FlagNamer:
+//
+//	type Perm uint8
+//
+//	const (
+//		Read Perm = 1 << iota
+//		Write
+//	)
+//
+//	func (Perm) FlagNames() []string { return []string{"read", "write"} }
+//
+//	var p nullable.Bitmask[Perm]
+//	p.Add(Read | Write) // encodes as ["read","write"]
+//
+// Both forms are accepted when decoding.
+type Bitmask[T Unsigned] struct {
+	Nullable[T]
+}
+
+// NewBitmask creates a valid Bitmask with flags set.
+func NewBitmask[T Unsigned](flags T) Bitmask[T] {
+	return Bitmask[T]{NewNullable(flags)}
+}
+
+// Has reports whether b is valid and every bit of flags is set.
+func (b Bitmask[T]) Has(flags T) bool {
+	return b.Valid && b.V&flags == flags
+}
+
+// Add sets the bits of flags, making b valid. Unlike Set, which replaces
+// the value, it keeps the bits already set.
+func (b *Bitmask[T]) Add(flags T) {
+	b.V |= flags
+	b.Valid = true
+}
+
+// Clear clears the bits of flags. A null Bitmask stays null.
+func (b *Bitmask[T]) Clear(flags T) {
+	b.V &^= flags
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (b Bitmask[T]) MarshalJSON() ([]byte, error) {
+	if !b.Valid {
+		return []byte("null"), nil
+	}
+	namer, ok := any(b.V).(FlagNamer)
+	if !ok {
+		return json.Marshal(uint64(b.V))
+	}
+	names := namer.FlagNames()
+	list := []string{}
+	for v := uint64(b.V); v != 0; v &= v - 1 {
+		bit := bits.TrailingZeros64(v)
+		if bit >= len(names) || names[bit] == "" {
+			return nil, fmt.Errorf("nullable: bit %d of %T has no flag name", bit, b.V)
+		}
+		list = append(list, names[bit])
+	}
+	return json.Marshal(list)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (b *Bitmask[T]) UnmarshalJSON(data []byte) error {
+	if isNull(data) {
+		b.V, b.Valid = 0, false
+		return nil
+	}
+	if jsonKind(data) != "array" {
+		var v uint64
+		if err := json.Unmarshal(data, &v); err != nil {
+			return err
+		}
+		if uint64(T(v)) != v {
+			return fmt.Errorf("nullable: bitmask %d overflows %T", v, b.V)
+		}
+		b.V, b.Valid = T(v), true
+		return nil
+	}
+
+	namer, ok := any(b.V).(FlagNamer)
+	if !ok {
+		return fmt.Errorf("nullable: %T does not implement FlagNamer; cannot decode a flag list", b.V)
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	names := namer.FlagNames()
+	var v T
+	for _, name := range list {
+		bit := -1
+		for i, n := range names {
+			if n != "" && n == name {
+				bit = i
+				break
+			}
+		}
+		if bit < 0 {
+			return fmt.Errorf("nullable: unknown flag %q for %T", name, b.V)
+		}
+		v |= T(1) << bit
+	}
+	b.V, b.Valid = v, true
+	return nil
+}
@@ -0,0 +1,109 @@
+package nullable
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type testPerm uint8
+
+const (
+	permRead testPerm = 1 << iota
+	permWrite
+	permAdmin
+	permUnnamed
+)
+
+func (testPerm) FlagNames() []string { return []string{"read", "write", "admin"} }
+
+func TestBitmaskHelpers(t *testing.T) {
+	var b Bitmask[uint16]
+	if b.Has(0) {
+		t.Error("Expected null bitmask to have no flags")
+	}
+	b.Clear(1)
+	if b.Valid {
+		t.Error("Expected Clear to keep a null bitmask null")
+	}
+	b.Add(1 | 4)
+	if !b.Valid || !b.Has(1) || !b.Has(1|4) || b.Has(2) {
+		t.Errorf("Expected flags 1 and 4, got %+v", b)
+	}
+	b.Clear(1)
+	if b.Has(1) || !b.Has(4) {
+		t.Errorf("Expected only flag 4, got %+v", b)
+	}
+	b.Add(2)
+	b.Set(8)
+	if b.V != 8 {
+		t.Errorf("Expected Set to replace the flags, got %+v", b)
+	}
+	if n := NewBitmask[uint16](6); !n.Valid || n.V != 6 {
+		t.Errorf("Expected valid 6, got %+v", n)
+	}
+}
+
+func TestBitmaskJSONInteger(t *testing.T) {
+	data, err := json.Marshal(NewBitmask[uint32](5))
+	if err != nil || string(data) != "5" {
+		t.Errorf("Expected 5, got %s (%v)", data, err)
+	}
+	data, _ = json.Marshal(Bitmask[uint32]{})
+	if string(data) != "null" {
+		t.Errorf("Expected null, got %s", data)
+	}
+
+	var b Bitmask[uint8]
+	if err := json.Unmarshal([]byte("129"), &b); err != nil || b.V != 129 {
+		t.Errorf("Expected 129, got %+v (%v)", b, err)
+	}
+	if err := json.Unmarshal([]byte("256"), &b); err == nil {
+		t.Error("Expected overflow error")
+	}
+	if err := json.Unmarshal([]byte(`["read"]`), &b); err == nil {
+		t.Error("Expected error decoding a list without FlagNamer")
+	}
+	if err := json.Unmarshal([]byte("null"), &b); err != nil || b.Valid {
+		t.Errorf("Expected null, got %+v (%v)", b, err)
+	}
+}
+
+func TestBitmaskJSONNames(t *testing.T) {
+	data, err := json.Marshal(NewBitmask(permRead | permAdmin))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := `["read","admin"]`; string(data) != want {
+		t.Errorf("Expected %s, got %s", want, data)
+	}
+	if data, _ := json.Marshal(NewBitmask[testPerm](0)); string(data) != "[]" {
+		t.Errorf("Expected [], got %s", data)
+	}
+	if _, err := json.Marshal(NewBitmask(permUnnamed)); err == nil {
+		t.Error("Expected error for unnamed bit")
+	}
+
+	var b Bitmask[testPerm]
+	if err := json.Unmarshal([]byte(`["write","read"]`), &b); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if b.V != permRead|permWrite {
+		t.Errorf("Expected read|write, got %v", b.V)
+	}
+	if err := json.Unmarshal([]byte("4"), &b); err != nil || b.V != permAdmin {
+		t.Errorf("Expected integer form to decode, got %+v (%v)", b, err)
+	}
+	if err := json.Unmarshal([]byte(`["delete"]`), &b); err == nil {
+		t.Error("Expected error for unknown flag")
+	}
+}
+
+func TestBitmaskScan(t *testing.T) {
+	var b Bitmask[uint32]
+	if err := b.Scan(int64(10)); err != nil || !b.Has(8|2) {
+		t.Errorf("Expected 10, got %+v (%v)", b, err)
+	}
+	if err := b.Scan(nil); err != nil || b.Valid {
+		t.Errorf("Expected null, got %+v (%v)", b, err)
+	}
+}
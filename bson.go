@@ -0,0 +1,81 @@
+package nullable
+
+import "go.mongodb.org/mongo-driver/v2/bson"
+
+// MarshalBSONValue implements the bson.ValueMarshaler interface of
+// go.mongodb.org/mongo-driver/v2. A null value encodes as BSON null and a
+// valid one as the value, using the default registry.
+func (n Nullable[T]) MarshalBSONValue() (byte, []byte, error) {
+	if !n.Valid {
+		return byte(bson.TypeNull), nil, nil
+	}
+	t, data, err := bson.MarshalValue(n.V)
+	return byte(t), data, err
+}
+
+// UnmarshalBSONValue implements the bson.ValueUnmarshaler interface. BSON
+// null and undefined decode as null; any other value is decoded into the
+// value. On error n is left unchanged.
+func (n *Nullable[T]) UnmarshalBSONValue(typ byte, data []byte) error {
+	switch bson.Type(typ) {
+	case bson.TypeNull, bson.TypeUndefined:
+		n.Reset()
+		return nil
+	}
+	var v T
+	if err := bson.UnmarshalValue(bson.Type(typ), data, &v); err != nil {
+		return err
+	}
+	n.V, n.Valid = v, true
+	return nil
+}
+
+// UnmarshalBSONValue implements the bson.ValueUnmarshaler interface like
+// Nullable.UnmarshalBSONValue, rejecting invalid addresses.
+func (e *Email) UnmarshalBSONValue(typ byte, data []byte) error {
+	return decodeChecked(&e.Nullable, validateEmail, func(dst *Nullable[string]) error {
+		return dst.UnmarshalBSONValue(typ, data)
+	})
+}
+
+// UnmarshalBSONValue implements the bson.ValueUnmarshaler interface like
+// Nullable.UnmarshalBSONValue, rejecting numbers that are not E.164.
+func (p *Phone) UnmarshalBSONValue(typ byte, data []byte) error {
+	return decodeChecked(&p.Nullable, validatePhone, func(dst *Nullable[string]) error {
+		return dst.UnmarshalBSONValue(typ, data)
+	})
+}
+
+// UnmarshalBSONValue implements the bson.ValueUnmarshaler interface like
+// Nullable.UnmarshalBSONValue, rejecting negative values.
+func (n *NonNegative[T]) UnmarshalBSONValue(typ byte, data []byte) error {
+	return decodeChecked(&n.Nullable, validateNonNegative[T], func(dst *Nullable[T]) error {
+		return dst.UnmarshalBSONValue(typ, data)
+	})
+}
+
+// UnmarshalBSONValue implements the bson.ValueUnmarshaler interface like
+// Nullable.UnmarshalBSONValue, rejecting values that are not positive.
+func (n *Positive[T]) UnmarshalBSONValue(typ byte, data []byte) error {
+	return decodeChecked(&n.Nullable, validatePositive[T], func(dst *Nullable[T]) error {
+		return dst.UnmarshalBSONValue(typ, data)
+	})
+}
+
+// UnmarshalBSONValue implements the bson.ValueUnmarshaler interface like
+// Nullable.UnmarshalBSONValue, rejecting values outside 0 to 100.
+func (n *Percentage[T]) UnmarshalBSONValue(typ byte, data []byte) error {
+	return decodeChecked(&n.Nullable, validatePercentage[T], func(dst *Nullable[T]) error {
+		return dst.UnmarshalBSONValue(typ, data)
+	})
+}
+
+// UnmarshalBSONValue implements the bson.ValueUnmarshaler interface like
+// Nullable.UnmarshalBSONValue, marking o present.
+func (o *Omittable[T]) UnmarshalBSONValue(typ byte, data []byte) error {
+	if err := o.Nullable.UnmarshalBSONValue(typ, data); err != nil {
+		return err
+	}
+	o.present = true
+	return nil
+}
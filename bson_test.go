@@ -0,0 +1,111 @@
+package nullable
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+var (
+	_ bson.ValueMarshaler   = Nullable[int]{}
+	_ bson.ValueUnmarshaler = (*Nullable[int])(nil)
+)
+
+type bsonUser struct {
+	ID      bson.ObjectID       `bson:"_id"`
+	Name    Nullable[string]    `bson:"name"`
+	Age     Nullable[int32]     `bson:"age"`
+	Deleted Nullable[time.Time] `bson:"deleted,omitempty"`
+	Tags    Nullable[[]string]  `bson:"tags"`
+}
+
+func TestBSONRoundTrip(t *testing.T) {
+	in := bsonUser{ID: bson.NewObjectID(), Name: NewNullable("Ada"), Tags: NewNullable([]string{"a"})}
+	data, err := bson.Marshal(in)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var raw bson.M
+	if err := bson.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if v, ok := raw["age"]; !ok || v != nil {
+		t.Errorf("Expected age to be BSON null, got %v", v)
+	}
+	if _, ok := raw["deleted"]; ok {
+		t.Error("Expected null omitempty field to be omitted")
+	}
+	if raw["name"] != "Ada" {
+		t.Errorf("Expected name Ada, got %v", raw["name"])
+	}
+
+	out := bsonUser{Age: NewNullable(int32(9))}
+	if err := bson.Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if out.ID != in.ID || out.Name != in.Name || out.Age.Valid || out.Deleted.Valid || len(out.Tags.V) != 1 {
+		t.Errorf("Expected %+v, got %+v", in, out)
+	}
+}
+
+func TestUnmarshalBSONValue(t *testing.T) {
+	n := NewNullable(int32(1))
+	if err := n.UnmarshalBSONValue(byte(bson.TypeUndefined), nil); err != nil || n.Valid {
+		t.Errorf("Expected null, got %v (%v)", n, err)
+	}
+
+	typ, data, _ := bson.MarshalValue("x")
+	n = NewNullable(int32(1))
+	if err := n.UnmarshalBSONValue(byte(typ), data); err == nil || n != NewNullable(int32(1)) {
+		t.Errorf("Expected error and unchanged value, got %v (%v)", n, err)
+	}
+
+	typ, data, _ = bson.MarshalValue(int32(7))
+	if err := n.UnmarshalBSONValue(byte(typ), data); err != nil || n != NewNullable(int32(7)) {
+		t.Errorf("Expected 7, got %v (%v)", n, err)
+	}
+}
+
+func TestValidatedBSON(t *testing.T) {
+	type record struct {
+		Email Email            `bson:"email"`
+		Count Positive[int]    `bson:"count"`
+		Score Percentage[int]  `bson:"score"`
+		Extra Omittable[int]   `bson:"extra"`
+		Other Omittable[int]   `bson:"other"`
+		Phone Phone            `bson:"phone"`
+		Total NonNegative[int] `bson:"total"`
+	}
+	data, _ := bson.Marshal(bson.M{"email": "ada@example.com", "count": 2, "extra": nil, "total": 0})
+	var r record
+	if err := bson.Unmarshal(data, &r); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if r.Email.V != "ada@example.com" || r.Count.V != 2 || !r.Total.Valid {
+		t.Errorf("Unexpected decode: %+v", r)
+	}
+	if !r.Extra.IsNull() || r.Other.IsPresent() {
+		t.Errorf("Expected extra present and null, other absent, got %v and %v", r.Extra, r.Other)
+	}
+
+	for _, in := range []bson.M{
+		{"email": "nope"},
+		{"phone": "555"},
+		{"count": 0},
+		{"score": 101},
+		{"total": -1},
+	} {
+		data, _ := bson.Marshal(in)
+		var fe *FormatError
+		var re *RangeError
+		if err := bson.Unmarshal(data, &r); !errors.As(err, &fe) && !errors.As(err, &re) {
+			t.Errorf("%v: Expected validation error, got %v", in, err)
+		}
+	}
+	if r.Email.V != "ada@example.com" || r.Count.V != 2 {
+		t.Errorf("Expected rejected values to leave fields unchanged, got %+v", r)
+	}
+}
@@ -0,0 +1,152 @@
+package nullable
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// BytesEncoding selects the JSON representation of a Bytes value. The
+// implementations in this package are empty marker types; custom encodings
+// may be added by implementing the interface on another empty struct.
+type BytesEncoding interface {
+	// EncodeJSON returns the JSON encoding of b.
+	EncodeJSON(b []byte) ([]byte, error)
+	// DecodeJSON decodes the non-null JSON value data.
+	DecodeJSON(data []byte) ([]byte, error)
+}
+
+// StdBase64Encoding encodes bytes as a padded standard base64 string, like
+// encoding/json does for []byte.
+type StdBase64Encoding struct{}
+
+// URLBase64Encoding encodes bytes as a padded URL-safe base64 string.
+type URLBase64Encoding struct{}
+
+// RawURLBase64Encoding encodes bytes as an unpadded URL-safe base64 string.
+type RawURLBase64Encoding struct{}
+
+// HexEncoding encodes bytes as a lowercase hexadecimal string.
+type HexEncoding struct{}
+
+// ArrayEncoding encodes bytes as a JSON array of numbers.
+type ArrayEncoding struct{}
+
+// Bytes is a nullable byte slice encoded in JSON according to E:
+//
+//	Digest nullable.Bytes[nullable.HexEncoding] `json:"digest"`
+type Bytes[E BytesEncoding] struct {
+	Nullable[[]byte]
+}
+
+// NewBytes creates a valid Bytes.
+func NewBytes[E BytesEncoding](b []byte) Bytes[E] {
+	return Bytes[E]{NewNullable(b)}
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (b Bytes[E]) MarshalJSON() ([]byte, error) {
+	if !b.Valid {
+		return []byte("null"), nil
+	}
+	var enc E
+	return enc.EncodeJSON(b.V)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (b *Bytes[E]) UnmarshalJSON(data []byte) error {
+	if isNull(data) {
+		b.V, b.Valid = nil, false
+		return nil
+	}
+	var enc E
+	v, err := enc.DecodeJSON(data)
+	if err != nil {
+		return err
+	}
+	b.V, b.Valid = v, true
+	return nil
+}
+
+// EncodeJSON implements BytesEncoding.
+func (StdBase64Encoding) EncodeJSON(b []byte) ([]byte, error) {
+	return encodeString(base64.StdEncoding.EncodeToString(b))
+}
+
+// DecodeJSON implements BytesEncoding.
+func (StdBase64Encoding) DecodeJSON(data []byte) ([]byte, error) {
+	return decodeString(data, base64.StdEncoding.DecodeString)
+}
+
+// EncodeJSON implements BytesEncoding.
+func (URLBase64Encoding) EncodeJSON(b []byte) ([]byte, error) {
+	return encodeString(base64.URLEncoding.EncodeToString(b))
+}
+
+// DecodeJSON implements BytesEncoding.
+func (URLBase64Encoding) DecodeJSON(data []byte) ([]byte, error) {
+	return decodeString(data, base64.URLEncoding.DecodeString)
+}
+
+// EncodeJSON implements BytesEncoding.
+func (RawURLBase64Encoding) EncodeJSON(b []byte) ([]byte, error) {
+	return encodeString(base64.RawURLEncoding.EncodeToString(b))
+}
+
+// DecodeJSON implements BytesEncoding.
+func (RawURLBase64Encoding) DecodeJSON(data []byte) ([]byte, error) {
+	return decodeString(data, base64.RawURLEncoding.DecodeString)
+}
+
+// EncodeJSON implements BytesEncoding.
+func (HexEncoding) EncodeJSON(b []byte) ([]byte, error) {
+	return encodeString(hex.EncodeToString(b))
+}
+
+// DecodeJSON implements BytesEncoding.
+func (HexEncoding) DecodeJSON(data []byte) ([]byte, error) {
+	return decodeString(data, hex.DecodeString)
+}
+
+// EncodeJSON implements BytesEncoding.
+func (ArrayEncoding) EncodeJSON(b []byte) ([]byte, error) {
+	nums := make([]uint16, len(b))
+	for i, c := range b {
+		nums[i] = uint16(c)
+	}
+	return json.Marshal(nums)
+}
+
+// DecodeJSON implements BytesEncoding.
+func (ArrayEncoding) DecodeJSON(data []byte) ([]byte, error) {
+	// []uint8 would be decoded from base64 by encoding/json.
+	var nums []uint16
+	if err := json.Unmarshal(data, &nums); err != nil {
+		return nil, err
+	}
+	b := make([]byte, len(nums))
+	for i, n := range nums {
+		if n > 0xff {
+			return nil, fmt.Errorf("nullable: byte value %d out of range", n)
+		}
+		b[i] = byte(n)
+	}
+	return b, nil
+}
+
+func encodeString(s string) ([]byte, error) {
+	return json.Marshal(s)
+}
+
+func decodeString(data []byte, decode func(string) ([]byte, error)) ([]byte, error) {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	b, err := decode(s)
+	if err != nil {
+		return nil, fmt.Errorf("nullable: decoding bytes: %w", err)
+	}
+	return b, nil
+}
@@ -0,0 +1,64 @@
+package nullable
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func checkBytesJSON[E BytesEncoding](t *testing.T, data []byte, want string) {
+	t.Helper()
+	got, err := json.Marshal(NewBytes[E](data))
+	if err != nil {
+		t.Fatalf("%T: Unexpected error: %v", *new(E), err)
+	}
+	if string(got) != want {
+		t.Errorf("%T: Expected %s, got %s", *new(E), want, got)
+	}
+
+	var b Bytes[E]
+	if err := json.Unmarshal(got, &b); err != nil {
+		t.Fatalf("%T: Unexpected error: %v", *new(E), err)
+	}
+	if !b.Valid || !bytes.Equal(b.V, data) {
+		t.Errorf("%T: Round trip mismatch, got %+v", *new(E), b)
+	}
+}
+
+func TestBytesJSON(t *testing.T) {
+	data := []byte{0xfb, 0xff, 0x01}
+	checkBytesJSON[StdBase64Encoding](t, data, `"+/8B"`)
+	checkBytesJSON[URLBase64Encoding](t, data, `"-_8B"`)
+	checkBytesJSON[RawURLBase64Encoding](t, []byte{0xfb}, `"-w"`)
+	checkBytesJSON[HexEncoding](t, data, `"fbff01"`)
+	checkBytesJSON[ArrayEncoding](t, data, `[251,255,1]`)
+}
+
+func TestBytesNull(t *testing.T) {
+	var b Bytes[HexEncoding]
+	data, err := json.Marshal(b)
+	if err != nil || string(data) != "null" {
+		t.Errorf("Expected null, got %s (%v)", data, err)
+	}
+	b = NewBytes[HexEncoding]([]byte{1})
+	if err := json.Unmarshal([]byte("null"), &b); err != nil || b.Valid || b.V != nil {
+		t.Errorf("Expected null, got %+v (%v)", b, err)
+	}
+}
+
+func TestBytesDecodeErrors(t *testing.T) {
+	var h Bytes[HexEncoding]
+	if err := json.Unmarshal([]byte(`"zz"`), &h); err == nil {
+		t.Error("Expected error for invalid hex")
+	}
+	if err := json.Unmarshal([]byte(`12`), &h); err == nil {
+		t.Error("Expected error for non-string")
+	}
+	var a Bytes[ArrayEncoding]
+	if err := json.Unmarshal([]byte(`[256]`), &a); err == nil {
+		t.Error("Expected error for out of range byte")
+	}
+	if err := json.Unmarshal([]byte(`[]`), &a); err != nil || !a.Valid || len(a.V) != 0 {
+		t.Errorf("Expected valid empty bytes, got %+v (%v)", a, err)
+	}
+}
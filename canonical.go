@@ -0,0 +1,252 @@
+package nullable
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// NullPolicy selects how MarshalCanonical treats null object members.
+type NullPolicy int
+
+const (
+	// KeepNulls writes null members, so a null field and an absent one
+	// produce different signatures.
+	KeepNulls NullPolicy = iota
+	// OmitNulls drops null object members, so adding a null field to a
+	// struct does not change the signature of existing payloads. Null
+	// array elements are always kept.
+	OmitNulls
+)
+
+// CanonicalNulls sets the null policy of MarshalCanonical.
+func CanonicalNulls(p NullPolicy) EncodeOption {
+	return func(o *encodeOptions) {
+		o.nulls = p
+	}
+}
+
+// MarshalCanonical returns the canonical JSON encoding of v following the
+// JSON Canonicalization Scheme of RFC 8785, for hashing and signing: object
+// keys are sorted by their UTF-16 code units, numbers use the shortest
+// ECMAScript form, strings escape only what JSON requires, and there is no
+// whitespace. v is first encoded with Marshal and opts; CanonicalNulls
+// selects whether null members are kept.
+//
+// Numbers are IEEE 754 doubles in RFC 8785, so integers that a float64
+// cannot hold exactly are rejected instead of silently rounded; encode
+// such values as strings.
+func MarshalCanonical(v any, opts ...EncodeOption) ([]byte, error) {
+	var o encodeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	data, err := Marshal(v, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return Canonicalize(data, o.nulls)
+}
+
+// Canonicalize rewrites the JSON document in data in the canonical form
+// produced by MarshalCanonical, for verifying signatures over payloads
+// received from elsewhere. Duplicate object keys are an error.
+func Canonicalize(data []byte, nulls NullPolicy) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	c := canonicalizer{dec: dec, nulls: nulls}
+	if err := c.value(); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("nullable: canonical JSON: trailing data after value")
+	}
+	return c.buf, nil
+}
+
+type canonicalizer struct {
+	dec   *json.Decoder
+	nulls NullPolicy
+	buf   []byte
+}
+
+func (c *canonicalizer) value() error {
+	tok, err := c.dec.Token()
+	if err != nil {
+		return err
+	}
+	switch t := tok.(type) {
+	case nil:
+		c.buf = append(c.buf, "null"...)
+	case bool:
+		c.buf = strconv.AppendBool(c.buf, t)
+	case string:
+		c.buf = appendCanonicalString(c.buf, t)
+	case json.Number:
+		c.buf, err = appendCanonicalNumber(c.buf, t)
+		return err
+	case json.Delim:
+		if t == '[' {
+			return c.array()
+		}
+		return c.object()
+	}
+	return nil
+}
+
+func (c *canonicalizer) array() error {
+	c.buf = append(c.buf, '[')
+	for i := 0; c.dec.More(); i++ {
+		if i > 0 {
+			c.buf = append(c.buf, ',')
+		}
+		if err := c.value(); err != nil {
+			return err
+		}
+	}
+	c.buf = append(c.buf, ']')
+	_, err := c.dec.Token()
+	return err
+}
+
+type member struct {
+	key   string
+	value []byte
+}
+
+func (c *canonicalizer) object() error {
+	var members []member
+	seen := make(map[string]bool)
+	outer := c.buf
+	for c.dec.More() {
+		tok, err := c.dec.Token()
+		if err != nil {
+			return err
+		}
+		key := tok.(string)
+		if seen[key] {
+			return fmt.Errorf("nullable: canonical JSON: duplicate key %q", key)
+		}
+		seen[key] = true
+		c.buf = nil
+		if err := c.value(); err != nil {
+			return err
+		}
+		if c.nulls == OmitNulls && string(c.buf) == "null" {
+			continue
+		}
+		members = append(members, member{key, c.buf})
+	}
+	if _, err := c.dec.Token(); err != nil {
+		return err
+	}
+
+	slices.SortFunc(members, func(a, b member) int {
+		return slices.Compare(utf16.Encode([]rune(a.key)), utf16.Encode([]rune(b.key)))
+	})
+	c.buf = append(outer, '{')
+	for i, m := range members {
+		if i > 0 {
+			c.buf = append(c.buf, ',')
+		}
+		c.buf = appendCanonicalString(c.buf, m.key)
+		c.buf = append(c.buf, ':')
+		c.buf = append(c.buf, m.value...)
+	}
+	c.buf = append(c.buf, '}')
+	return nil
+}
+
+// appendCanonicalString escapes only quotation marks, backslashes and
+// control characters, using the short escapes where JSON has them.
+func appendCanonicalString(buf []byte, s string) []byte {
+	const hex = "0123456789abcdef"
+	buf = append(buf, '"')
+	for i := 0; i < len(s); i++ {
+		switch b := s[i]; b {
+		case '"', '\\':
+			buf = append(buf, '\\', b)
+		case '\b':
+			buf = append(buf, `\b`...)
+		case '\f':
+			buf = append(buf, `\f`...)
+		case '\n':
+			buf = append(buf, `\n`...)
+		case '\r':
+			buf = append(buf, `\r`...)
+		case '\t':
+			buf = append(buf, `\t`...)
+		default:
+			if b < 0x20 {
+				buf = append(buf, '\\', 'u', '0', '0', hex[b>>4], hex[b&0xf])
+			} else {
+				buf = append(buf, b)
+			}
+		}
+	}
+	return append(buf, '"')
+}
+
+// appendCanonicalNumber formats n like ECMAScript's Number.prototype.toString.
+func appendCanonicalNumber(buf []byte, n json.Number) ([]byte, error) {
+	f, err := strconv.ParseFloat(string(n), 64)
+	if err != nil {
+		return nil, fmt.Errorf("nullable: canonical JSON: number %s: %w", n, err)
+	}
+	if !strings.ContainsAny(string(n), ".eE") && strconv.FormatFloat(f, 'f', -1, 64) != string(n) {
+		return nil, fmt.Errorf("nullable: canonical JSON: integer %s cannot be represented exactly as a double", n)
+	}
+	return appendES6Float(buf, f), nil
+}
+
+func appendES6Float(buf []byte, f float64) []byte {
+	if f == 0 {
+		return append(buf, '0') // also for negative zero
+	}
+	if f < 0 {
+		buf = append(buf, '-')
+		f = -f
+	}
+	// Shortest round-trip digits and the decimal exponent, as d.ddde±x.
+	s := strconv.FormatFloat(f, 'e', -1, 64)
+	mantissa, exp, _ := strings.Cut(s, "e")
+	digits := strings.Replace(mantissa, ".", "", 1)
+	e, _ := strconv.Atoi(exp)
+	k, point := len(digits), e+1
+
+	switch {
+	case k <= point && point <= 21:
+		buf = append(buf, digits...)
+		for range point - k {
+			buf = append(buf, '0')
+		}
+	case 0 < point && point <= 21:
+		buf = append(buf, digits[:point]...)
+		buf = append(buf, '.')
+		buf = append(buf, digits[point:]...)
+	case -6 < point && point <= 0:
+		buf = append(buf, "0."...)
+		for range -point {
+			buf = append(buf, '0')
+		}
+		buf = append(buf, digits...)
+	default:
+		buf = append(buf, digits[0])
+		if k > 1 {
+			buf = append(buf, '.')
+			buf = append(buf, digits[1:]...)
+		}
+		buf = append(buf, 'e')
+		if e >= 0 {
+			buf = append(buf, '+')
+		}
+		buf = strconv.AppendInt(buf, int64(e), 10)
+	}
+	return buf
+}
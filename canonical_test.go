@@ -0,0 +1,120 @@
+package nullable
+
+import "testing"
+
+func TestCanonicalize(t *testing.T) {
+	// The example from RFC 8785, section 3.2.2.
+	in := `{
+		"numbers": [333333333.33333329, 1E30, 4.50, 2e-3, 0.000000000000000000000000001],
+		"string": "\u20ac$\u000F\u000aA'\u0042\u0022\u005c\\\"\/",
+		"literals": [null, true, false]
+	}`
+	want := `{"literals":[null,true,false],"numbers":[333333333.3333333,1e+30,4.5,0.002,1e-27],"string":"€$\u000f\nA'B\"\\\\\"/"}`
+	got, err := Canonicalize([]byte(in), KeepNulls)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(got) != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
+
+func TestCanonicalKeyOrder(t *testing.T) {
+	// Keys sort by UTF-16 code units, so U+1F600 (a surrogate pair)
+	// sorts before U+FB33.
+	in := "{\"\uFB33\":1,\"\U0001F600\":2,\"b\":3,\"a\":{\"z\":null,\"y\":[]},\"A\":4}"
+	want := "{\"A\":4,\"a\":{\"y\":[],\"z\":null},\"b\":3,\"\U0001F600\":2,\"\uFB33\":1}"
+	got, err := Canonicalize([]byte(in), KeepNulls)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(got) != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
+
+func TestCanonicalNumbers(t *testing.T) {
+	tests := map[string]string{
+		"0":                       "0",
+		"-0":                      "0",
+		"1":                       "1",
+		"-1.5":                    "-1.5",
+		"100":                     "100",
+		"1e20":                    "100000000000000000000",
+		"1e21":                    "1e+21",
+		"0.000001":                "0.000001",
+		"0.0000001":               "1e-7",
+		"1.7976931348623157e308":  "1.7976931348623157e+308",
+		"5e-324":                  "5e-324",
+		"9007199254740992":        "9007199254740992",
+		"-9007199254740992":       "-9007199254740992",
+		"12345678.9":              "12345678.9",
+		"0.1":                     "0.1",
+		"295147905179352830000.5": "295147905179352830000",
+	}
+	for in, want := range tests {
+		got, err := Canonicalize([]byte(in), KeepNulls)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", in, err)
+			continue
+		}
+		if string(got) != want {
+			t.Errorf("%s: expected %s, got %s", in, want, got)
+		}
+	}
+
+	for _, in := range []string{"9007199254740993", "123456789012345678901"} {
+		if _, err := Canonicalize([]byte(in), KeepNulls); err == nil {
+			t.Errorf("%s: expected error for inexact integer", in)
+		}
+	}
+}
+
+func TestCanonicalizeErrors(t *testing.T) {
+	for _, in := range []string{`{"a":1,"a":2}`, `[1,`, `1 2`, ``, `{"a" 1}`} {
+		if _, err := Canonicalize([]byte(in), KeepNulls); err == nil {
+			t.Errorf("%q: expected error", in)
+		}
+	}
+}
+
+func TestMarshalCanonical(t *testing.T) {
+	type payment struct {
+		Amount    float64            `json:"amount"`
+		Reference Nullable[string]   `json:"reference"`
+		Payee     string             `json:"payee"`
+		Memo      Nullable[string]   `json:"memo"`
+		Meta      map[string]any     `json:"meta"`
+		Tags      []Nullable[string] `json:"tags"`
+	}
+	// encoding/json escapes U+2028 and HTML characters; RFC 8785 does not.
+	p := payment{
+		Amount: 10.50,
+		Payee:  "<Ada & Co>",
+		Memo:   NewNullable("line\u2028break"),
+		Meta:   map[string]any{"z": 1, "a": nil},
+		Tags:   []Nullable[string]{NewNull[string](), NewNullable("x")},
+	}
+
+	got, err := MarshalCanonical(p)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := `{"amount":10.5,"memo":"line` + "\u2028" + `break","meta":{"a":null,"z":1},"payee":"<Ada & Co>","reference":null,"tags":[null,"x"]}`
+	if string(got) != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+
+	got, err = MarshalCanonical(p, CanonicalNulls(OmitNulls))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want = `{"amount":10.5,"memo":"line` + "\u2028" + `break","meta":{"z":1},"payee":"<Ada & Co>","tags":[null,"x"]}`
+	if string(got) != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+
+	if _, err := MarshalCanonical(struct{ ID int64 }{1 << 60}); err == nil {
+		t.Error("Expected error for int64 beyond 2^53")
+	}
+}
@@ -0,0 +1,86 @@
+package nullable
+
+import "github.com/fxamacker/cbor/v2"
+
+// cborNull and cborUndefined are the encodings of the CBOR simple values
+// null and undefined.
+const (
+	cborNull      = 0xf6
+	cborUndefined = 0xf7
+)
+
+// MarshalCBOR implements the cbor.Marshaler interface of
+// github.com/fxamacker/cbor/v2. A null value encodes as CBOR null and a
+// valid one as the value, using the package's default encoding options.
+func (n Nullable[T]) MarshalCBOR() ([]byte, error) {
+	if !n.Valid {
+		return []byte{cborNull}, nil
+	}
+	return cbor.Marshal(n.V)
+}
+
+// UnmarshalCBOR implements the cbor.Unmarshaler interface. CBOR null and
+// undefined decode as null; any other data item is decoded into the value.
+// On error n is left unchanged.
+func (n *Nullable[T]) UnmarshalCBOR(data []byte) error {
+	if len(data) == 1 && (data[0] == cborNull || data[0] == cborUndefined) {
+		n.Reset()
+		return nil
+	}
+	var v T
+	if err := cbor.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	n.V, n.Valid = v, true
+	return nil
+}
+
+// UnmarshalCBOR implements the cbor.Unmarshaler interface like
+// Nullable.UnmarshalCBOR, rejecting invalid addresses.
+func (e *Email) UnmarshalCBOR(data []byte) error {
+	return decodeChecked(&e.Nullable, validateEmail, func(dst *Nullable[string]) error {
+		return dst.UnmarshalCBOR(data)
+	})
+}
+
+// UnmarshalCBOR implements the cbor.Unmarshaler interface like
+// Nullable.UnmarshalCBOR, rejecting numbers that are not E.164.
+func (p *Phone) UnmarshalCBOR(data []byte) error {
+	return decodeChecked(&p.Nullable, validatePhone, func(dst *Nullable[string]) error {
+		return dst.UnmarshalCBOR(data)
+	})
+}
+
+// UnmarshalCBOR implements the cbor.Unmarshaler interface like
+// Nullable.UnmarshalCBOR, rejecting negative values.
+func (n *NonNegative[T]) UnmarshalCBOR(data []byte) error {
+	return decodeChecked(&n.Nullable, validateNonNegative[T], func(dst *Nullable[T]) error {
+		return dst.UnmarshalCBOR(data)
+	})
+}
+
+// UnmarshalCBOR implements the cbor.Unmarshaler interface like
+// Nullable.UnmarshalCBOR, rejecting values that are not positive.
+func (n *Positive[T]) UnmarshalCBOR(data []byte) error {
+	return decodeChecked(&n.Nullable, validatePositive[T], func(dst *Nullable[T]) error {
+		return dst.UnmarshalCBOR(data)
+	})
+}
+
+// UnmarshalCBOR implements the cbor.Unmarshaler interface like
+// Nullable.UnmarshalCBOR, rejecting values outside 0 to 100.
+func (n *Percentage[T]) UnmarshalCBOR(data []byte) error {
+	return decodeChecked(&n.Nullable, validatePercentage[T], func(dst *Nullable[T]) error {
+		return dst.UnmarshalCBOR(data)
+	})
+}
+
+// UnmarshalCBOR implements the cbor.Unmarshaler interface like
+// Nullable.UnmarshalCBOR, marking o present.
+func (o *Omittable[T]) UnmarshalCBOR(data []byte) error {
+	if err := o.Nullable.UnmarshalCBOR(data); err != nil {
+		return err
+	}
+	o.present = true
+	return nil
+}
@@ -0,0 +1,119 @@
+package nullable
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+var (
+	_ cbor.Marshaler   = Nullable[int]{}
+	_ cbor.Unmarshaler = (*Nullable[int])(nil)
+)
+
+type cborReading struct {
+	Sensor  string              `cbor:"sensor"`
+	Temp    Nullable[float64]   `cbor:"temp"`
+	Battery Nullable[uint8]     `cbor:"battery"`
+	Tags    Nullable[[]string]  `cbor:"tags"`
+	Taken   Nullable[time.Time] `cbor:"taken"`
+	Payload Nullable[[]byte]    `cbor:"payload"`
+}
+
+func TestCBORRoundTrip(t *testing.T) {
+	taken := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	in := cborReading{
+		Sensor:  "s1",
+		Temp:    NewNullable(21.5),
+		Tags:    NewNullable([]string{"a"}),
+		Taken:   NewNullable(taken),
+		Payload: NewNullable([]byte{1, 2}),
+	}
+	data, err := cbor.Marshal(in)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var out cborReading
+	if err := cbor.Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if out.Sensor != in.Sensor || out.Temp != in.Temp || out.Battery.Valid {
+		t.Errorf("Expected %+v, got %+v", in, out)
+	}
+	if len(out.Tags.V) != 1 || out.Tags.V[0] != "a" || !out.Taken.V.Equal(taken) || !bytes.Equal(out.Payload.V, in.Payload.V) {
+		t.Errorf("Expected %+v, got %+v", in, out)
+	}
+
+	var generic map[string]any
+	if err := cbor.Unmarshal(data, &generic); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if v, ok := generic["battery"]; !ok || v != nil {
+		t.Errorf("Expected battery to be CBOR null, got %v", v)
+	}
+}
+
+func TestUnmarshalCBOR(t *testing.T) {
+	for _, data := range [][]byte{{0xf6}, {0xf7}} {
+		n := NewNullable(1)
+		if err := n.UnmarshalCBOR(data); err != nil || n.Valid {
+			t.Errorf("%x: Expected null, got %v (%v)", data, n, err)
+		}
+	}
+
+	n := NewNullable(1)
+	if err := n.UnmarshalCBOR([]byte{0x63, 'a', 'b', 'c'}); err == nil || n != NewNullable(1) {
+		t.Errorf("Expected error and unchanged value, got %v (%v)", n, err)
+	}
+
+	nested := NewNullable(NewNull[int]())
+	data, err := cbor.Marshal(nested)
+	if err != nil || !bytes.Equal(data, []byte{0xf6}) {
+		t.Errorf("Expected CBOR null, got %x (%v)", data, err)
+	}
+}
+
+func TestValidatedCBOR(t *testing.T) {
+	type record struct {
+		Email Email            `cbor:"email"`
+		Count Positive[int]    `cbor:"count"`
+		Score Percentage[int]  `cbor:"score"`
+		Extra Omittable[int]   `cbor:"extra"`
+		Other Omittable[int]   `cbor:"other"`
+		Phone Phone            `cbor:"phone"`
+		Total NonNegative[int] `cbor:"total"`
+	}
+	data, _ := cbor.Marshal(map[string]any{"email": "ada@example.com", "count": 2, "extra": nil, "total": 0})
+	var r record
+	if err := cbor.Unmarshal(data, &r); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if r.Email.V != "ada@example.com" || r.Count.V != 2 || !r.Total.Valid {
+		t.Errorf("Unexpected decode: %+v", r)
+	}
+	if !r.Extra.IsNull() || r.Other.IsPresent() {
+		t.Errorf("Expected extra present and null, other absent, got %v and %v", r.Extra, r.Other)
+	}
+
+	for _, in := range []map[string]any{
+		{"email": "nope"},
+		{"phone": "555"},
+		{"count": 0},
+		{"score": 101},
+		{"total": -1},
+	} {
+		data, _ := cbor.Marshal(in)
+		var fe *FormatError
+		var re *RangeError
+		if err := cbor.Unmarshal(data, &r); !errors.As(err, &fe) && !errors.As(err, &re) {
+			t.Errorf("%v: Expected validation error, got %v", in, err)
+		}
+	}
+	if r.Email.V != "ada@example.com" || r.Count.V != 2 {
+		t.Errorf("Expected rejected values to leave fields unchanged, got %+v", r)
+	}
+}
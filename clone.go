@@ -0,0 +1,101 @@
+package nullable
+
+import "reflect"
+
+// Cloner is implemented by types that make their own deep copies. Clone
+// uses it for the value of a Nullable and for any value reached while
+// copying.
+type Cloner[T any] interface {
+	Clone() T
+}
+
+// Clone returns a deep copy of n, so that mutating slices, maps or pointed-to
+// values of the copy does not affect n. Values implementing Cloner are
+// copied with their Clone method. Others are copied recursively through
+// pointers, slices, maps, arrays, interfaces and exported struct fields;
+// unexported fields, channels and functions are copied shallowly, which
+// keeps types such as time.Time intact. Pointers shared within n stay
+// shared in the copy, and cycles are preserved.
+func (n Nullable[T]) Clone() Nullable[T] {
+	if !n.Valid {
+		return NewNull[T]()
+	}
+	if c, ok := any(n.V).(Cloner[T]); ok {
+		return NewNullable(c.Clone())
+	}
+	v := deepCopy(reflect.ValueOf(&n.V).Elem(), make(map[seenPointer]reflect.Value))
+	return NewNullable(v.Interface().(T))
+}
+
+// seenPointer identifies a pointer already copied by deepCopy. The type is
+// part of the key because a pointer to a struct and a pointer to its first
+// field share an address.
+type seenPointer struct {
+	p uintptr
+	t reflect.Type
+}
+
+func deepCopy(src reflect.Value, seen map[seenPointer]reflect.Value) reflect.Value {
+	t := src.Type()
+	if m, ok := t.MethodByName("Clone"); ok && m.Type.NumIn() == 1 && m.Type.NumOut() == 1 && m.Type.Out(0) == t &&
+		(t.Kind() != reflect.Pointer || !src.IsNil()) && src.CanInterface() {
+		return src.Method(m.Index).Call(nil)[0]
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		if src.IsNil() {
+			return src
+		}
+		key := seenPointer{src.Pointer(), t}
+		if dst, ok := seen[key]; ok {
+			return dst
+		}
+		dst := reflect.New(t.Elem())
+		seen[key] = dst
+		dst.Elem().Set(deepCopy(src.Elem(), seen))
+		return dst
+	case reflect.Slice:
+		if src.IsNil() {
+			return src
+		}
+		dst := reflect.MakeSlice(t, src.Len(), src.Cap())
+		for i := 0; i < src.Len(); i++ {
+			dst.Index(i).Set(deepCopy(src.Index(i), seen))
+		}
+		return dst
+	case reflect.Map:
+		if src.IsNil() {
+			return src
+		}
+		dst := reflect.MakeMapWithSize(t, src.Len())
+		iter := src.MapRange()
+		for iter.Next() {
+			dst.SetMapIndex(iter.Key(), deepCopy(iter.Value(), seen))
+		}
+		return dst
+	case reflect.Array:
+		dst := reflect.New(t).Elem()
+		for i := 0; i < src.Len(); i++ {
+			dst.Index(i).Set(deepCopy(src.Index(i), seen))
+		}
+		return dst
+	case reflect.Struct:
+		dst := reflect.New(t).Elem()
+		dst.Set(src)
+		for i := 0; i < t.NumField(); i++ {
+			if f := dst.Field(i); f.CanSet() {
+				f.Set(deepCopy(src.Field(i), seen))
+			}
+		}
+		return dst
+	case reflect.Interface:
+		if src.IsNil() {
+			return src
+		}
+		dst := reflect.New(t).Elem()
+		dst.Set(deepCopy(src.Elem(), seen))
+		return dst
+	}
+	return src
+}
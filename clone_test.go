@@ -0,0 +1,116 @@
+package nullable
+
+import (
+	"testing"
+	"time"
+)
+
+type cloneDTO struct {
+	Tags    []string
+	Meta    map[string]any
+	Address *cloneAddress
+	Created time.Time
+	Notes   Nullable[[]string]
+	secret  *int
+}
+
+type cloneAddress struct {
+	City string
+}
+
+type countingCloner struct {
+	Items  []int
+	clones *int
+}
+
+func (c countingCloner) Clone() countingCloner {
+	*c.clones++
+	return countingCloner{Items: append([]int(nil), c.Items...), clones: c.clones}
+}
+
+func TestClone(t *testing.T) {
+	secret := 1
+	orig := NewNullable(cloneDTO{
+		Tags:    []string{"a"},
+		Meta:    map[string]any{"list": []int{1}},
+		Address: &cloneAddress{City: "London"},
+		Created: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		Notes:   NewNullable([]string{"n"}),
+		secret:  &secret,
+	})
+	c := orig.Clone()
+	c.V.Tags[0] = "b"
+	c.V.Meta["list"].([]int)[0] = 2
+	c.V.Meta["new"] = true
+	c.V.Address.City = "Paris"
+	c.V.Notes.V[0] = "changed"
+
+	if orig.V.Tags[0] != "a" || orig.V.Meta["list"].([]int)[0] != 1 || len(orig.V.Meta) != 1 {
+		t.Errorf("Expected slices and maps to be copied, got %+v", orig.V)
+	}
+	if orig.V.Address.City != "London" || orig.V.Notes.V[0] != "n" {
+		t.Errorf("Expected pointers and nested Nullables to be copied, got %+v", orig.V)
+	}
+	if !c.V.Created.Equal(orig.V.Created) || c.V.secret != orig.V.secret {
+		t.Errorf("Expected time and unexported fields to be kept, got %+v", c.V)
+	}
+
+	if n := NewNullable([]int(nil)).Clone(); !n.Valid || n.V != nil {
+		t.Errorf("Expected valid nil slice, got %+v", n)
+	}
+	stale := NewNull[[]int]()
+	stale.V = []int{1}
+	if n := stale.Clone(); n.Valid || n.V != nil {
+		t.Errorf("Expected zero null, got %+v", n)
+	}
+}
+
+func TestCloneCloner(t *testing.T) {
+	clones := 0
+	orig := NewNullable(countingCloner{Items: []int{1}, clones: &clones})
+	c := orig.Clone()
+	c.V.Items[0] = 2
+	if clones != 1 || orig.V.Items[0] != 1 {
+		t.Errorf("Expected Clone method to be used once, got %d calls and %v", clones, orig.V.Items)
+	}
+
+	nested := NewNullable([]countingCloner{{Items: []int{1}, clones: &clones}})
+	nested.Clone()
+	if clones != 2 {
+		t.Errorf("Expected Clone method to be used for nested values, got %d calls", clones)
+	}
+}
+
+func TestCloneCycles(t *testing.T) {
+	type node struct {
+		Next *node
+		Val  int
+	}
+	a := &node{Val: 1}
+	a.Next = a
+	c := NewNullable(a).Clone()
+	if c.V == a || c.V.Next != c.V || c.V.Val != 1 {
+		t.Errorf("Expected a copied cycle, got %+v", c.V)
+	}
+
+	shared := &cloneAddress{City: "Rome"}
+	pair := NewNullable([2]*cloneAddress{shared, shared}).Clone()
+	if pair.V[0] != pair.V[1] || pair.V[0] == shared {
+		t.Error("Expected shared pointers to stay shared in the copy")
+	}
+}
+
+func TestCloneFirstFieldPointer(t *testing.T) {
+	type inner struct{ N int }
+	type outer struct {
+		In  inner
+		Out *outer
+		Ptr *inner
+	}
+	o := &outer{In: inner{N: 1}}
+	o.Out, o.Ptr = o, &o.In
+	c := NewNullable(o).Clone()
+	if c.V == o || c.V.Out != c.V || c.V.Ptr == &o.In || c.V.Ptr.N != 1 {
+		t.Errorf("Expected a deep copy, got %+v", c.V)
+	}
+}
@@ -0,0 +1,19 @@
+// Command nullablecheck runs the nullable analyzers.
+//
+// Usage:
+//
+//	nullablecheck [-omitnull.require=omitzero] ./...
+package main
+
+import (
+	"github.com/manattan/nullable/analysis/nullcompare"
+	"github.com/manattan/nullable/analysis/omitnull"
+	"golang.org/x/tools/go/analysis/multichecker"
+)
+
+func main() {
+	multichecker.Main(
+		nullcompare.Analyzer,
+		omitnull.Analyzer,
+	)
+}
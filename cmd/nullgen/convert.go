@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+)
+
+// parseMappings parses "From.Field=To.Field" overrides into a map from the
+// destination field name to the source field name.
+func parseMappings(s string) (map[string]string, error) {
+	m := make(map[string]string)
+	if s == "" {
+		return m, nil
+	}
+	for _, pair := range strings.Split(s, ",") {
+		src, dst, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid mapping %q: want From.Field=To.Field", pair)
+		}
+		m[fieldPart(dst)] = fieldPart(src)
+	}
+	return m, nil
+}
+
+func fieldPart(s string) string {
+	if i := strings.LastIndex(s, "."); i >= 0 {
+		return s[i+1:]
+	}
+	return s
+}
+
+// generateConvert emits conversion functions in both directions between the
+// structs named from and to.
+func generateConvert(pkg *pkgInfo, from, to string, overrides map[string]string) ([]byte, error) {
+	src, ok := pkg.structs[from]
+	if !ok {
+		return nil, fmt.Errorf("struct %s not found in package %s", from, pkg.name)
+	}
+	dst, ok := pkg.structs[to]
+	if !ok {
+		return nil, fmt.Errorf("struct %s not found in package %s", to, pkg.name)
+	}
+
+	reverse := make(map[string]string, len(overrides))
+	for d, s := range overrides {
+		reverse[s] = d
+	}
+
+	var body bytes.Buffer
+	usesNullable := writeConvertFunc(&body, src, dst, overrides)
+	body.WriteString("\n")
+	usesNullable = writeConvertFunc(&body, dst, src, reverse) || usesNullable
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by nullgen; DO NOT EDIT.\n\npackage %s\n\n", pkg.name)
+	if usesNullable {
+		fmt.Fprintf(&buf, "import %q\n\n", nullablePath)
+	}
+	buf.Write(body.Bytes())
+
+	out, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+	return out, nil
+}
+
+// writeConvertFunc writes a function converting src into dst and reports
+// whether the generated code references the nullable package.
+func writeConvertFunc(buf *bytes.Buffer, src, dst *structInfo, overrides map[string]string) bool {
+	usesNullable := false
+	fmt.Fprintf(buf, "// %sTo%s converts a %s into a %s.\n", src.name, dst.name, src.name, dst.name)
+	fmt.Fprintf(buf, "func %sTo%s(in %s) %s {\n", src.name, dst.name, src.name, dst.name)
+	fmt.Fprintf(buf, "var out %s\n", dst.name)
+	for _, df := range dst.fields {
+		sf, ok := matchField(src, df, overrides)
+		if !ok {
+			fmt.Fprintf(buf, "// %s: no matching field in %s\n", df.name, src.name)
+			continue
+		}
+		code, nullableRef := convertField(sf, df)
+		buf.WriteString(code)
+		usesNullable = usesNullable || nullableRef
+	}
+	buf.WriteString("return out\n}\n")
+	return usesNullable
+}
+
+// matchField finds the source field for df, first by explicit override, then
+// by Go field name, then by json or db tag name.
+func matchField(src *structInfo, df fieldInfo, overrides map[string]string) (fieldInfo, bool) {
+	if name, ok := overrides[df.name]; ok {
+		for _, sf := range src.fields {
+			if sf.name == name {
+				return sf, true
+			}
+		}
+		return fieldInfo{}, false
+	}
+	for _, sf := range src.fields {
+		if sf.name == df.name {
+			return sf, true
+		}
+	}
+	dstNames := tagNames(df)
+	for _, sf := range src.fields {
+		for _, n := range tagNames(sf) {
+			for _, d := range dstNames {
+				if n == d {
+					return sf, true
+				}
+			}
+		}
+	}
+	return fieldInfo{}, false
+}
+
+func tagNames(f fieldInfo) []string {
+	var names []string
+	for _, key := range []string{"json", "db"} {
+		name, _, _ := strings.Cut(f.tag.Get(key), ",")
+		if name != "" && name != "-" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// convertField returns the statements assigning in.<sf> to out.<df> and
+// whether they reference the nullable package.
+func convertField(sf, df fieldInfo) (string, bool) {
+	in, out := "in."+sf.name, "out."+df.name
+	if sf.typ.inner != df.typ.inner {
+		return fmt.Sprintf("// %s: cannot convert %s to %s\n", df.name, sf.typ, df.typ), false
+	}
+
+	switch {
+	case sf.typ.kind == df.typ.kind:
+		return fmt.Sprintf("%s = %s\n", out, in), false
+	case sf.typ.kind == kindNullable && df.typ.kind == kindPointer:
+		return fmt.Sprintf("%s = %s.Ptr()\n", out, in), false
+	case sf.typ.kind == kindNullable && df.typ.kind == kindPlain:
+		return fmt.Sprintf("if %s.Valid {\n%s = %s.V\n}\n", in, out, in), false
+	case sf.typ.kind == kindPointer && df.typ.kind == kindNullable:
+		return fmt.Sprintf("if %s != nil {\n%s = nullable.NewNullable(*%s)\n}\n", in, out, in), true
+	case sf.typ.kind == kindPlain && df.typ.kind == kindNullable:
+		return fmt.Sprintf("%s = nullable.NewNullable(%s)\n", out, in), true
+	case sf.typ.kind == kindPointer && df.typ.kind == kindPlain:
+		return fmt.Sprintf("if %s != nil {\n%s = *%s\n}\n", in, out, in), false
+	default: // plain to pointer
+		return fmt.Sprintf("{\nv := %s\n%s = &v\n}\n", in, out), false
+	}
+}
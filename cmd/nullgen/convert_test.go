@@ -0,0 +1,134 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const convertFixture = `package models
+
+import (
+	"time"
+
+	null "github.com/manattan/nullable"
+)
+
+type UserRow struct {
+	ID        int64
+	Name      null.Nullable[string]
+	Email     null.Nullable[string] ` + "`db:\"email_address\"`" + `
+	Age       null.Nullable[int]
+	CreatedAt time.Time
+	Nick      *string
+	Score     int
+}
+
+type UserDTO struct {
+	ID        int64
+	Name      *string
+	Mail      *string ` + "`json:\"email_address\"`" + `
+	Age       int
+	CreatedAt time.Time
+	Nick      null.Nullable[string]
+	Score     *int
+	Extra     string
+}
+`
+
+func writeFixture(t *testing.T, src string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "models.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestGenerateConvert(t *testing.T) {
+	pkg, err := loadPackage(writeFixture(t, convertFixture))
+	if err != nil {
+		t.Fatalf("loadPackage: %v", err)
+	}
+
+	out, err := generateConvert(pkg, "UserRow", "UserDTO", nil)
+	if err != nil {
+		t.Fatalf("generateConvert: %v", err)
+	}
+	code := string(out)
+
+	for _, want := range []string{
+		"// Code generated by nullgen; DO NOT EDIT.",
+		`import "github.com/manattan/nullable"`,
+		"func UserRowToUserDTO(in UserRow) UserDTO {",
+		"func UserDTOToUserRow(in UserDTO) UserRow {",
+		"out.ID = in.ID",
+		"out.Name = in.Name.Ptr()",
+		"out.Mail = in.Email.Ptr()",
+		"if in.Age.Valid {\n\t\tout.Age = in.Age.V\n\t}",
+		"if in.Nick != nil {\n\t\tout.Nick = nullable.NewNullable(*in.Nick)\n\t}",
+		"v := in.Score\n\t\tout.Score = &v",
+		"// Extra: no matching field in UserRow",
+		"out.Age = nullable.NewNullable(in.Age)",
+		"if in.Score != nil {\n\t\tout.Score = *in.Score\n\t}",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("generated code missing %q\n%s", want, code)
+		}
+	}
+}
+
+func TestGenerateConvertOverrides(t *testing.T) {
+	pkg, err := loadPackage(writeFixture(t, convertFixture))
+	if err != nil {
+		t.Fatalf("loadPackage: %v", err)
+	}
+
+	overrides, err := parseMappings("UserRow.Name=UserDTO.Extra")
+	if err != nil {
+		t.Fatalf("parseMappings: %v", err)
+	}
+	out, err := generateConvert(pkg, "UserRow", "UserDTO", overrides)
+	if err != nil {
+		t.Fatalf("generateConvert: %v", err)
+	}
+	code := string(out)
+	if !strings.Contains(code, "if in.Name.Valid {\n\t\tout.Extra = in.Name.V\n\t}") {
+		t.Errorf("override not applied:\n%s", code)
+	}
+	if !strings.Contains(code, "out.Name = nullable.NewNullable(in.Extra)") {
+		t.Errorf("reverse override not applied:\n%s", code)
+	}
+}
+
+func TestGenerateConvertIncompatible(t *testing.T) {
+	pkg, err := loadPackage(writeFixture(t, `package models
+
+type A struct{ X int }
+type B struct{ X string }
+`))
+	if err != nil {
+		t.Fatalf("loadPackage: %v", err)
+	}
+	out, err := generateConvert(pkg, "A", "B", nil)
+	if err != nil {
+		t.Fatalf("generateConvert: %v", err)
+	}
+	if strings.Contains(string(out), "import") {
+		t.Errorf("unexpected import:\n%s", out)
+	}
+	if !strings.Contains(string(out), "// X: cannot convert int to string") {
+		t.Errorf("missing incompatibility note:\n%s", out)
+	}
+}
+
+func TestGenerateConvertMissingStruct(t *testing.T) {
+	pkg, err := loadPackage(writeFixture(t, convertFixture))
+	if err != nil {
+		t.Fatalf("loadPackage: %v", err)
+	}
+	if _, err := generateConvert(pkg, "Nope", "UserDTO", nil); err == nil {
+		t.Error("Expected error for unknown struct")
+	}
+}
@@ -0,0 +1,115 @@
+// Command nullgen generates code for structs that use nullable.Nullable fields.
+//
+// Usage:
+//
+//	nullgen -mode=convert -from=UserRow -to=UserDTO [-dir=.] [-o=file.go]
+//	nullgen -mode=typescript [-types=User,Order] [-dir=.] [-o=file.ts]
+//	nullgen -mode=patch -types=User [-bitset] [-dir=.] [-o=file.go]
+//
+// The convert mode emits conversion functions between two structs. The
+// typescript mode emits TypeScript interfaces mirroring the JSON encoding of
+// the package's structs, mapping Nullable[T] to "T | null". The patch mode
+// emits partial-update structs that record which fields were present in the
+// decoded JSON and apply them to the original struct.
+//
+// It is intended to be run from go:generate directives.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const nullablePath = "github.com/manattan/nullable"
+
+// options holds the command-line flags.
+type options struct {
+	mode     string
+	dir      string
+	from     string
+	to       string
+	mappings string
+	types    string
+	bitset   bool
+	output   string
+}
+
+func main() {
+	var o options
+	flag.StringVar(&o.mode, "mode", "", "generation mode: convert, typescript or patch")
+	flag.StringVar(&o.dir, "dir", ".", "directory of the package containing the structs")
+	flag.StringVar(&o.from, "from", "", "source struct name (convert mode)")
+	flag.StringVar(&o.to, "to", "", "destination struct name (convert mode)")
+	flag.StringVar(&o.mappings, "map", "", "comma-separated From.Field=To.Field overrides (convert mode)")
+	flag.StringVar(&o.types, "types", "", "comma-separated struct names (default all structs)")
+	flag.BoolVar(&o.bitset, "bitset", false, "track field presence in a shared bitset instead of a bool per field (patch mode)")
+	flag.StringVar(&o.output, "o", "", "output file (default derived from the struct or package name)")
+	flag.Parse()
+
+	if err := run(o); err != nil {
+		fmt.Fprintln(os.Stderr, "nullgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(o options) error {
+	pkg, err := loadPackage(o.dir)
+	if err != nil {
+		return err
+	}
+
+	var src []byte
+	output := o.output
+	switch o.mode {
+	case "convert":
+		if o.from == "" || o.to == "" {
+			return fmt.Errorf("convert mode requires -from and -to")
+		}
+		overrides, err := parseMappings(o.mappings)
+		if err != nil {
+			return err
+		}
+		src, err = generateConvert(pkg, o.from, o.to, overrides)
+		if err != nil {
+			return err
+		}
+		if output == "" {
+			output = strings.ToLower(o.from) + "_" + strings.ToLower(o.to) + "_convert.go"
+		}
+	case "typescript":
+		src, err = generateTypeScript(pkg, splitList(o.types))
+		if err != nil {
+			return err
+		}
+		if output == "" {
+			output = pkg.name + ".ts"
+		}
+	case "patch":
+		types := splitList(o.types)
+		src, err = generatePatch(pkg, types, o.bitset)
+		if err != nil {
+			return err
+		}
+		if output == "" {
+			output = strings.ToLower(strings.Join(types, "_")) + "_patch.go"
+		}
+	default:
+		return fmt.Errorf("unknown mode %q", o.mode)
+	}
+
+	return os.WriteFile(filepath.Join(o.dir, output), src, 0o644)
+}
+
+// splitList splits a comma-separated flag value, dropping empty entries.
+func splitList(s string) []string {
+	var list []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
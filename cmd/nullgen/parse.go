@@ -0,0 +1,215 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// pkgInfo holds the struct declarations of a single parsed package.
+type pkgInfo struct {
+	name    string
+	structs map[string]*structInfo
+	// order lists the struct names in declaration order.
+	order []string
+	// named maps the other named types of the package to their underlying
+	// type expressions.
+	named map[string]ast.Expr
+}
+
+// structInfo describes a struct declaration.
+type structInfo struct {
+	name   string
+	fields []fieldInfo
+	// embeds holds the types of embedded fields.
+	embeds []embedInfo
+	// alias is the local name of the nullable package in the declaring
+	// file, or "" if it is not imported.
+	alias string
+	// imports maps the local names of the declaring file's imports to
+	// their paths.
+	imports map[string]string
+}
+
+// embedInfo describes an embedded struct field.
+type embedInfo struct {
+	tag  reflect.StructTag
+	expr ast.Expr
+}
+
+// fieldInfo describes a single named struct field.
+type fieldInfo struct {
+	name string
+	tag  reflect.StructTag
+	typ  typeInfo
+	expr ast.Expr
+}
+
+// typeKind classifies how a field stores its value.
+type typeKind int
+
+const (
+	kindPlain typeKind = iota
+	kindPointer
+	kindNullable
+)
+
+// typeInfo is a field type split into its wrapping kind and inner type.
+type typeInfo struct {
+	kind  typeKind
+	inner string
+}
+
+func (t typeInfo) String() string {
+	switch t.kind {
+	case kindPointer:
+		return "*" + t.inner
+	case kindNullable:
+		return "nullable.Nullable[" + t.inner + "]"
+	default:
+		return t.inner
+	}
+}
+
+// loadPackage parses the non-test Go files in dir.
+func loadPackage(dir string) (*pkgInfo, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	info := &pkgInfo{structs: make(map[string]*structInfo), named: make(map[string]ast.Expr)}
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		if info.name != "" && info.name != file.Name.Name {
+			return nil, fmt.Errorf("multiple packages in %s: %s and %s", dir, info.name, file.Name.Name)
+		}
+		info.name = file.Name.Name
+		collectStructs(info, file)
+	}
+	if info.name == "" {
+		return nil, fmt.Errorf("no Go files in %s", dir)
+	}
+	return info, nil
+}
+
+func collectStructs(info *pkgInfo, file *ast.File) {
+	alias := nullableAlias(file)
+	imports := fileImports(file)
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			st, ok := ts.Type.(*ast.StructType)
+			if !ok {
+				info.named[ts.Name.Name] = ts.Type
+				continue
+			}
+			s := &structInfo{name: ts.Name.Name, alias: alias, imports: imports}
+			for _, field := range st.Fields.List {
+				tag := fieldTag(field)
+				if len(field.Names) == 0 {
+					s.embeds = append(s.embeds, embedInfo{tag: tag, expr: field.Type})
+					continue
+				}
+				typ := classify(field.Type, alias)
+				for _, n := range field.Names {
+					if n.IsExported() {
+						s.fields = append(s.fields, fieldInfo{name: n.Name, tag: tag, typ: typ, expr: field.Type})
+					}
+				}
+			}
+			info.structs[s.name] = s
+			info.order = append(info.order, s.name)
+		}
+	}
+}
+
+// fieldTag returns the struct tag of field.
+func fieldTag(field *ast.Field) reflect.StructTag {
+	if field.Tag == nil {
+		return ""
+	}
+	v, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return ""
+	}
+	return reflect.StructTag(v)
+}
+
+// fileImports maps the local names of the imports of file to their paths.
+func fileImports(file *ast.File) map[string]string {
+	imports := make(map[string]string)
+	for _, imp := range file.Imports {
+		path, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+		name := importName(path)
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		imports[name] = path
+	}
+	return imports
+}
+
+// importName returns the default package name for an import path, skipping
+// a trailing major version element such as "/v2".
+func importName(path string) string {
+	elems := strings.Split(path, "/")
+	name := elems[len(elems)-1]
+	if len(elems) > 1 && len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+		name = elems[len(elems)-2]
+	}
+	if i := strings.IndexAny(name, ".-"); i >= 0 {
+		name = name[:i]
+	}
+	return name
+}
+
+// nullableAlias returns the local name under which the nullable package is
+// imported in file, or "" if it is not imported.
+func nullableAlias(file *ast.File) string {
+	for _, imp := range file.Imports {
+		if path, _ := strconv.Unquote(imp.Path.Value); path == nullablePath {
+			if imp.Name != nil {
+				return imp.Name.Name
+			}
+			return "nullable"
+		}
+	}
+	return ""
+}
+
+func classify(expr ast.Expr, alias string) typeInfo {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return typeInfo{kind: kindPointer, inner: types.ExprString(e.X)}
+	case *ast.IndexExpr:
+		if sel, ok := e.X.(*ast.SelectorExpr); ok && alias != "" && sel.Sel.Name == "Nullable" {
+			if id, ok := sel.X.(*ast.Ident); ok && id.Name == alias {
+				return typeInfo{kind: kindNullable, inner: types.ExprString(e.Index)}
+			}
+		}
+	}
+	return typeInfo{kind: kindPlain, inner: types.ExprString(expr)}
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"sort"
+	"strings"
+)
+
+// generatePatch emits a <Name>Patch struct for each named struct. A patch
+// holds every field as a Nullable together with whether the field was
+// present in the decoded JSON, so null ("clear the field") can be told apart
+// from absent ("leave it alone"). With bitset set, presence is tracked in a
+// shared bitset instead of a bool per field, which keeps wide patch structs
+// compact.
+func generatePatch(pkg *pkgInfo, names []string, bitset bool) ([]byte, error) {
+	if len(names) == 0 {
+		return nil, fmt.Errorf("patch mode requires -types")
+	}
+	alias := "nullable"
+	imports := map[string]string{"json": "encoding/json"}
+	var body bytes.Buffer
+	for _, name := range names {
+		s, ok := pkg.structs[name]
+		if !ok {
+			return nil, fmt.Errorf("struct %s not found in package %s", name, pkg.name)
+		}
+		if s.alias != "" {
+			alias = s.alias
+		}
+		for _, f := range s.fields {
+			for _, pkgName := range referencedPackages(f.typ.inner) {
+				if path, ok := s.imports[pkgName]; ok {
+					imports[pkgName] = path
+				}
+			}
+		}
+		writePatch(&body, s, alias, bitset)
+	}
+	imports[alias] = nullablePath
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by nullgen; DO NOT EDIT.\n\npackage %s\n\nimport (\n", pkg.name)
+	localNames := make([]string, 0, len(imports))
+	for n := range imports {
+		localNames = append(localNames, n)
+	}
+	sort.Slice(localNames, func(i, j int) bool {
+		pi, pj := imports[localNames[i]], imports[localNames[j]]
+		if isStdlib(pi) != isStdlib(pj) {
+			return isStdlib(pi)
+		}
+		return pi < pj
+	})
+	for i, n := range localNames {
+		if i > 0 && isStdlib(imports[localNames[i-1]]) && !isStdlib(imports[n]) {
+			buf.WriteString("\n")
+		}
+		if path := imports[n]; importName(path) == n {
+			fmt.Fprintf(&buf, "%q\n", path)
+		} else {
+			fmt.Fprintf(&buf, "%s %q\n", n, path)
+		}
+	}
+	buf.WriteString(")\n")
+	buf.Write(body.Bytes())
+
+	out, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+	return out, nil
+}
+
+// isStdlib reports whether path is a standard library import path.
+func isStdlib(path string) bool {
+	first, _, _ := strings.Cut(path, "/")
+	return !strings.Contains(first, ".")
+}
+
+// referencedPackages returns the package names qualifying identifiers in
+// the type expression src.
+func referencedPackages(src string) []string {
+	expr, err := parser.ParseExpr(src)
+	if err != nil {
+		return nil
+	}
+	var names []string
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if id, ok := sel.X.(*ast.Ident); ok {
+				names = append(names, id.Name)
+			}
+		}
+		return true
+	})
+	return names
+}
+
+// patchField is a field of a generated patch struct.
+type patchField struct {
+	fieldInfo
+	key string
+}
+
+func patchFields(s *structInfo) []patchField {
+	var fields []patchField
+	for _, f := range s.fields {
+		tag := f.tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		key, _, _ := strings.Cut(tag, ",")
+		if key == "" {
+			key = f.name
+		}
+		fields = append(fields, patchField{fieldInfo: f, key: key})
+	}
+	return fields
+}
+
+func writePatch(buf *bytes.Buffer, s *structInfo, alias string, bitset bool) {
+	patch := s.name + "Patch"
+	field := patch + "Field"
+	fields := patchFields(s)
+
+	fmt.Fprintf(buf, "\n// %s identifies a field of %s.\ntype %s int\n\n", field, patch, field)
+	buf.WriteString("const (\n")
+	for i, f := range fields {
+		if i == 0 {
+			fmt.Fprintf(buf, "%s%s %s = iota\n", patch, f.name, field)
+		} else {
+			fmt.Fprintf(buf, "%s%s\n", patch, f.name)
+		}
+	}
+	buf.WriteString(")\n\n")
+
+	fmt.Fprintf(buf, "// %s is a partial update of %s. Only fields present in the decoded JSON\n", patch, s.name)
+	fmt.Fprintf(buf, "// are applied; a present null clears the field.\ntype %s struct {\n", patch)
+	for _, f := range fields {
+		fmt.Fprintf(buf, "%s %s.Nullable[%s]\n", f.name, alias, f.typ.inner)
+	}
+	if bitset {
+		fmt.Fprintf(buf, "\npresent [%d]uint64\n", (len(fields)+63)/64)
+	} else if len(fields) > 0 {
+		buf.WriteString("\n")
+		for _, f := range fields {
+			fmt.Fprintf(buf, "%s bool\n", presenceName(f.name))
+		}
+	}
+	buf.WriteString("}\n\n")
+
+	// Has and Mark.
+	fmt.Fprintf(buf, "// Has reports whether field f is present in the patch.\nfunc (p *%s) Has(f %s) bool {\n", patch, field)
+	if bitset {
+		buf.WriteString("return p.present[f/64]&(1<<(f%64)) != 0\n}\n\n")
+	} else {
+		buf.WriteString("switch f {\n")
+		for _, f := range fields {
+			fmt.Fprintf(buf, "case %s%s:\nreturn p.%s\n", patch, f.name, presenceName(f.name))
+		}
+		buf.WriteString("}\nreturn false\n}\n\n")
+	}
+	fmt.Fprintf(buf, "// Mark marks field f as present, so Apply copies it.\nfunc (p *%s) Mark(f %s) {\n", patch, field)
+	if bitset {
+		buf.WriteString("p.present[f/64] |= 1 << (f % 64)\n}\n\n")
+	} else {
+		buf.WriteString("switch f {\n")
+		for _, f := range fields {
+			fmt.Fprintf(buf, "case %s%s:\np.%s = true\n", patch, f.name, presenceName(f.name))
+		}
+		buf.WriteString("}\n}\n\n")
+	}
+
+	// Reset.
+	fmt.Fprintf(buf, "// Reset clears p to an empty patch, so it can be reused across decodes\n// and returned to a nullable.Pool.\nfunc (p *%s) Reset() {\n*p = %s{}\n}\n\n", patch, patch)
+
+	// UnmarshalJSON.
+	fmt.Fprintf(buf, "// UnmarshalJSON implements the json.Unmarshaler interface, recording which\n// fields are present. Unknown keys are ignored.\n")
+	fmt.Fprintf(buf, "func (p *%s) UnmarshalJSON(data []byte) error {\n", patch)
+	buf.WriteString("var raw map[string]json.RawMessage\nif err := json.Unmarshal(data, &raw); err != nil {\nreturn err\n}\n")
+	buf.WriteString("p.Reset()\n")
+	for _, f := range fields {
+		fmt.Fprintf(buf, "if v, ok := raw[%q]; ok {\n", f.key)
+		fmt.Fprintf(buf, "if err := json.Unmarshal(v, &p.%s); err != nil {\nreturn err\n}\n", f.name)
+		fmt.Fprintf(buf, "p.Mark(%s%s)\n}\n", patch, f.name)
+	}
+	buf.WriteString("return nil\n}\n\n")
+
+	// MarshalJSON.
+	fmt.Fprintf(buf, "// MarshalJSON implements the json.Marshaler interface, encoding only the\n// present fields.\n")
+	fmt.Fprintf(buf, "func (p %s) MarshalJSON() ([]byte, error) {\n", patch)
+	buf.WriteString("m := make(map[string]any)\n")
+	for _, f := range fields {
+		fmt.Fprintf(buf, "if p.Has(%s%s) {\nm[%q] = p.%s\n}\n", patch, f.name, f.key, f.name)
+	}
+	buf.WriteString("return json.Marshal(m)\n}\n\n")
+
+	// Apply.
+	fmt.Fprintf(buf, "// Apply copies the present fields of p to dst.\nfunc (p *%s) Apply(dst *%s) {\n", patch, s.name)
+	for _, f := range fields {
+		fmt.Fprintf(buf, "if p.Has(%s%s) {\n", patch, f.name)
+		switch f.typ.kind {
+		case kindNullable:
+			fmt.Fprintf(buf, "dst.%s = p.%s\n", f.name, f.name)
+		case kindPointer:
+			fmt.Fprintf(buf, "dst.%s = p.%s.Ptr()\n", f.name, f.name)
+		default:
+			fmt.Fprintf(buf, "if p.%s.Valid {\ndst.%s = p.%s.V\n} else {\ndst.%s = *new(%s)\n}\n", f.name, f.name, f.name, f.name, f.typ.inner)
+		}
+		buf.WriteString("}\n")
+	}
+	buf.WriteString("}\n")
+}
+
+// presenceName returns the unexported presence flag name for a field.
+func presenceName(field string) string {
+	return strings.ToLower(field[:1]) + field[1:] + "Present"
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const patchFixture = `package models
+
+import (
+	"time"
+
+	null "github.com/manattan/nullable"
+)
+
+type User struct {
+	ID     int64                 ` + "`json:\"id\"`" + `
+	Name   null.Nullable[string] ` + "`json:\"name\"`" + `
+	Email  *string               ` + "`json:\"email,omitempty\"`" + `
+	Born   time.Time
+	Secret string ` + "`json:\"-\"`" + `
+}
+`
+
+func TestGeneratePatch(t *testing.T) {
+	pkg, err := loadPackage(writeFixture(t, patchFixture))
+	if err != nil {
+		t.Fatalf("loadPackage: %v", err)
+	}
+	out, err := generatePatch(pkg, []string{"User"}, false)
+	if err != nil {
+		t.Fatalf("generatePatch: %v", err)
+	}
+	code := string(out)
+
+	for _, want := range []string{
+		"import (\n\t\"encoding/json\"\n\t\"time\"\n\n\tnull \"github.com/manattan/nullable\"\n)",
+		"UserPatchID UserPatchField = iota",
+		"\tName  null.Nullable[string]\n",
+		"\tBorn  null.Nullable[time.Time]\n",
+		"\tnamePresent  bool\n",
+		"case UserPatchEmail:\n\t\treturn p.emailPresent",
+		`if v, ok := raw["email"]; ok {`,
+		`if v, ok := raw["Born"]; ok {`,
+		"dst.Name = p.Name\n",
+		"dst.Email = p.Email.Ptr()\n",
+		"if p.ID.Valid {\n\t\t\tdst.ID = p.ID.V\n\t\t} else {\n\t\t\tdst.ID = *new(int64)\n\t\t}",
+		`m["name"] = p.Name`,
+		"func (p *UserPatch) Reset() {\n\t*p = UserPatch{}\n}",
+		"return err\n\t}\n\tp.Reset()\n",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("generated code missing %q\n%s", want, code)
+		}
+	}
+	if strings.Contains(code, "Secret") {
+		t.Errorf("Expected json:\"-\" field to be skipped:\n%s", code)
+	}
+	if strings.Contains(code, "present [") {
+		t.Errorf("Expected no bitset in bool mode:\n%s", code)
+	}
+}
+
+func TestGeneratePatchBitset(t *testing.T) {
+	pkg, err := loadPackage(writeFixture(t, patchFixture))
+	if err != nil {
+		t.Fatalf("loadPackage: %v", err)
+	}
+	out, err := generatePatch(pkg, []string{"User"}, true)
+	if err != nil {
+		t.Fatalf("generatePatch: %v", err)
+	}
+	code := string(out)
+	for _, want := range []string{
+		"present [1]uint64",
+		"return p.present[f/64]&(1<<(f%64)) != 0",
+		"p.present[f/64] |= 1 << (f % 64)",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("generated code missing %q\n%s", want, code)
+		}
+	}
+	if strings.Contains(code, "Present bool") {
+		t.Errorf("Expected no per-field flags in bitset mode:\n%s", code)
+	}
+}
+
+func TestGeneratePatchErrors(t *testing.T) {
+	pkg, err := loadPackage(writeFixture(t, patchFixture))
+	if err != nil {
+		t.Fatalf("loadPackage: %v", err)
+	}
+	if _, err := generatePatch(pkg, nil, false); err == nil {
+		t.Error("Expected error without types")
+	}
+	if _, err := generatePatch(pkg, []string{"Nope"}, false); err == nil {
+		t.Error("Expected error for unknown struct")
+	}
+}
+
+func TestRunPatch(t *testing.T) {
+	dir := writeFixture(t, patchFixture)
+	if err := run(options{mode: "patch", dir: dir, types: "User", bitset: true}); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "user_patch.go")); err != nil {
+		t.Errorf("Expected user_patch.go: %v", err)
+	}
+}
+
+func TestImportName(t *testing.T) {
+	for path, want := range map[string]string{
+		"time":                        "time",
+		"github.com/xuri/excelize/v2": "excelize",
+		"gopkg.in/yaml.v3":            "yaml",
+		"github.com/go-chi/chi":       "chi",
+	} {
+		if got := importName(path); got != want {
+			t.Errorf("%s: Expected %s, got %s", path, want, got)
+		}
+	}
+}
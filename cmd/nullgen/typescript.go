@@ -0,0 +1,204 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strings"
+)
+
+// nullableTS maps the non-generic types of the nullable package to the
+// TypeScript types of their JSON encoding.
+var nullableTS = map[string]string{
+	"Decimal":       "number",
+	"Semver":        "string",
+	"Point":         `{ type: "Point"; coordinates: number[] }`,
+	"HardwareAddr":  "string | null",
+	"Email":         "string | null",
+	"Phone":         "string | null",
+	"UnixTime":      "number | null",
+	"UnixMilliTime": "number | null",
+	"LenientString": "string | null",
+}
+
+// generateTypeScript emits a TypeScript interface for each named struct,
+// or for every struct of the package in declaration order if names is
+// empty.
+func generateTypeScript(pkg *pkgInfo, names []string) ([]byte, error) {
+	if len(names) == 0 {
+		names = pkg.order
+	}
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by nullgen; DO NOT EDIT.\n")
+	for _, name := range names {
+		s, ok := pkg.structs[name]
+		if !ok {
+			return nil, fmt.Errorf("struct %s not found in package %s", name, pkg.name)
+		}
+		buf.WriteString("\n")
+		writeInterface(&buf, pkg, s)
+	}
+	return buf.Bytes(), nil
+}
+
+func writeInterface(buf *bytes.Buffer, pkg *pkgInfo, s *structInfo) {
+	var extends []string
+	for _, e := range s.embeds {
+		if name, ok := e.expr.(*ast.Ident); ok && pkg.structs[name.Name] != nil && e.tag.Get("json") == "" {
+			extends = append(extends, name.Name)
+		}
+	}
+	fmt.Fprintf(buf, "export interface %s ", s.name)
+	if len(extends) > 0 {
+		fmt.Fprintf(buf, "extends %s ", strings.Join(extends, ", "))
+	}
+	buf.WriteString("{\n")
+	writeProperties(buf, pkg, s.fields, s.alias, "  ")
+	buf.WriteString("}\n")
+}
+
+func writeProperties(buf *bytes.Buffer, pkg *pkgInfo, fields []fieldInfo, alias, indent string) {
+	for _, f := range fields {
+		tag := f.tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = f.name
+		}
+		optional := false
+		asString := false
+		for _, o := range strings.Split(opts, ",") {
+			switch o {
+			case "omitempty", "omitzero":
+				optional = true
+			case "string":
+				asString = true
+			}
+		}
+		typ, omittable := tsType(pkg, f.expr, alias, indent)
+		if asString {
+			typ = strings.Replace(typ, "number", "string", 1)
+			typ = strings.Replace(typ, "boolean", "string", 1)
+		}
+		if optional || omittable {
+			name += "?"
+		}
+		fmt.Fprintf(buf, "%s%s: %s;\n", indent, tsName(name), typ)
+	}
+}
+
+// tsName quotes property names that are not valid identifiers.
+func tsName(name string) string {
+	base := strings.TrimSuffix(name, "?")
+	for i, r := range base {
+		if r != '_' && r != '$' && !('a' <= r && r <= 'z') && !('A' <= r && r <= 'Z') && (i == 0 || !('0' <= r && r <= '9')) {
+			return fmt.Sprintf("%q", base) + name[len(base):]
+		}
+	}
+	return name
+}
+
+// tsType returns the TypeScript type for the Go type expression expr and
+// whether the value may also be absent (undefined), as for Omittable
+// fields.
+func tsType(pkg *pkgInfo, expr ast.Expr, alias, indent string) (string, bool) {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return identTS(pkg, e.Name, alias, indent), false
+	case *ast.StarExpr:
+		inner, _ := tsType(pkg, e.X, alias, indent)
+		return orNull(inner), false
+	case *ast.ArrayType:
+		if id, ok := e.Elt.(*ast.Ident); ok && (id.Name == "byte" || id.Name == "uint8") {
+			return "string", false
+		}
+		inner, _ := tsType(pkg, e.Elt, alias, indent)
+		if strings.Contains(inner, "|") {
+			inner = "(" + inner + ")"
+		}
+		return inner + "[]", false
+	case *ast.MapType:
+		inner, _ := tsType(pkg, e.Value, alias, indent)
+		return "Record<string, " + inner + ">", false
+	case *ast.StructType:
+		var fields []fieldInfo
+		for _, field := range e.Fields.List {
+			for _, n := range field.Names {
+				if n.IsExported() {
+					fields = append(fields, fieldInfo{name: n.Name, tag: fieldTag(field), expr: field.Type})
+				}
+			}
+		}
+		var buf bytes.Buffer
+		buf.WriteString("{\n")
+		writeProperties(&buf, pkg, fields, alias, indent+"  ")
+		buf.WriteString(indent + "}")
+		return buf.String(), false
+	case *ast.SelectorExpr:
+		pkgName := types.ExprString(e.X)
+		switch {
+		case alias != "" && pkgName == alias:
+			if ts, ok := nullableTS[e.Sel.Name]; ok {
+				return ts, false
+			}
+		case pkgName == "time" && e.Sel.Name == "Time":
+			return "string", false
+		case pkgName == "time" && e.Sel.Name == "Duration":
+			return "number", false
+		case pkgName == "json" && e.Sel.Name == "Number":
+			return "number", false
+		}
+		return "unknown", false
+	case *ast.IndexExpr:
+		sel, ok := e.X.(*ast.SelectorExpr)
+		if !ok || alias == "" || types.ExprString(sel.X) != alias {
+			return "unknown", false
+		}
+		inner, _ := tsType(pkg, e.Index, alias, indent)
+		switch sel.Sel.Name {
+		case "Nullable", "ZeroAsNull", "EmptyAsNull":
+			return orNull(inner), false
+		case "Omittable":
+			return orNull(inner), true
+		case "Bytes":
+			return "string | null", false
+		case "Bitmask":
+			return "number | string[] | null", false
+		}
+		return "unknown", false
+	}
+	return "unknown", false
+}
+
+func identTS(pkg *pkgInfo, name, alias, indent string) string {
+	switch name {
+	case "string":
+		return "string"
+	case "bool":
+		return "boolean"
+	case "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64", "uintptr",
+		"float32", "float64", "byte", "rune":
+		return "number"
+	case "any", "error":
+		return "unknown"
+	}
+	if _, ok := pkg.structs[name]; ok {
+		return name
+	}
+	if underlying, ok := pkg.named[name]; ok {
+		ts, _ := tsType(pkg, underlying, alias, indent)
+		return ts
+	}
+	return "unknown"
+}
+
+func orNull(ts string) string {
+	if strings.HasSuffix(ts, "| null") {
+		return ts
+	}
+	return ts + " | null"
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const typescriptFixture = `package api
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/manattan/nullable"
+)
+
+type Status string
+
+type Base struct {
+	ID int64 ` + "`json:\"id\"`" + `
+}
+
+type User struct {
+	Base
+	Name      nullable.Nullable[string]     ` + "`json:\"name\"`" + `
+	Age       nullable.Nullable[int]        ` + "`json:\"age,omitempty\"`" + `
+	Nick      nullable.Omittable[string]    ` + "`json:\"nick\"`" + `
+	Tags      []nullable.Nullable[string]   ` + "`json:\"tags\"`" + `
+	Manager   *User                         ` + "`json:\"manager\"`" + `
+	Status    Status                        ` + "`json:\"status\"`" + `
+	CreatedAt time.Time                     ` + "`json:\"created_at\"`" + `
+	Balance   nullable.Nullable[nullable.Decimal] ` + "`json:\"balance\"`" + `
+	Meta      map[string]any                ` + "`json:\"meta\"`" + `
+	Raw       json.RawMessage               ` + "`json:\"raw\"`" + `
+	Count     int64                         ` + "`json:\"count,string\"`" + `
+	Avatar    []byte                        ` + "`json:\"avatar\"`" + `
+	Address   struct {
+		City nullable.Nullable[string] ` + "`json:\"city\"`" + `
+	} ` + "`json:\"address\"`" + `
+	Secret   string ` + "`json:\"-\"`" + `
+	Dashed   bool   ` + "`json:\"is-admin\"`" + `
+	internal string
+}
+`
+
+func TestGenerateTypeScript(t *testing.T) {
+	pkg, err := loadPackage(writeFixture(t, typescriptFixture))
+	if err != nil {
+		t.Fatalf("loadPackage: %v", err)
+	}
+	out, err := generateTypeScript(pkg, nil)
+	if err != nil {
+		t.Fatalf("generateTypeScript: %v", err)
+	}
+
+	want := `// Code generated by nullgen; DO NOT EDIT.
+
+export interface Base {
+  id: number;
+}
+
+export interface User extends Base {
+  name: string | null;
+  age?: number | null;
+  nick?: string | null;
+  tags: (string | null)[];
+  manager: User | null;
+  status: string;
+  created_at: string;
+  balance: number | null;
+  meta: Record<string, unknown>;
+  raw: unknown;
+  count: string;
+  avatar: string;
+  address: {
+    city: string | null;
+  };
+  "is-admin": boolean;
+}
+`
+	if string(out) != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, out)
+	}
+}
+
+func TestGenerateTypeScriptSelected(t *testing.T) {
+	pkg, err := loadPackage(writeFixture(t, typescriptFixture))
+	if err != nil {
+		t.Fatalf("loadPackage: %v", err)
+	}
+	out, err := generateTypeScript(pkg, []string{"Base"})
+	if err != nil {
+		t.Fatalf("generateTypeScript: %v", err)
+	}
+	if strings.Contains(string(out), "User") {
+		t.Errorf("Expected only Base, got:\n%s", out)
+	}
+	if _, err := generateTypeScript(pkg, []string{"Nope"}); err == nil {
+		t.Error("Expected error for unknown struct")
+	}
+}
+
+func TestRunTypeScript(t *testing.T) {
+	dir := writeFixture(t, typescriptFixture)
+	if err := run(options{mode: "typescript", dir: dir, types: "Base"}); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "api.ts"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "export interface Base {") {
+		t.Errorf("Unexpected output:\n%s", data)
+	}
+}
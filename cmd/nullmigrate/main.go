@@ -0,0 +1,95 @@
+// Command nullmigrate rewrites selected struct fields from *T to
+// nullable.Nullable[T] and updates their uses throughout the loaded
+// packages.
+//
+// Usage:
+//
+//	nullmigrate -fields=User.Email,User.Age [-w] [-l] [packages]
+//
+// Uses of a migrated field x.F are rewritten mechanically:
+//
+//	x.F == nil, x.F != nil  ->  !x.F.Valid, x.F.Valid
+//	*x.F                    ->  x.F.V
+//	x.F.Name                ->  x.F.V.Name
+//	x.F = nil               ->  x.F = nullable.NewNull[T]()
+//	x.F = &v                ->  x.F = nullable.NewNullable(v)
+//	x.F = p                 ->  x.F = nullable.FromPtr(p)
+//	f(x.F)                  ->  f(x.F.Ptr())
+//
+// Composite literals setting the field are converted the same way as
+// assignments. Taking the address of a migrated field cannot be rewritten
+// and is reported for manual review.
+//
+// Without -w the rewritten files are printed to standard output; -l lists
+// them instead.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+
+	"golang.org/x/tools/go/packages"
+)
+
+const loadMode = packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps | packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo
+
+func main() {
+	var (
+		fields = flag.String("fields", "", "comma-separated Type.Field names to migrate")
+		write  = flag.Bool("w", false, "write results to the source files")
+		list   = flag.Bool("l", false, "list the files that would change")
+	)
+	flag.Parse()
+
+	patterns := flag.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+	if err := run(*fields, patterns, *write, *list); err != nil {
+		fmt.Fprintln(os.Stderr, "nullmigrate:", err)
+		os.Exit(1)
+	}
+}
+
+func run(fields string, patterns []string, write, list bool) error {
+	targets, err := parseTargets(fields)
+	if err != nil {
+		return err
+	}
+	pkgs, err := packages.Load(&packages.Config{Mode: loadMode}, patterns...)
+	if err != nil {
+		return err
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		return fmt.Errorf("packages contain errors")
+	}
+
+	res, err := migrate(pkgs, targets)
+	if err != nil {
+		return err
+	}
+	for _, w := range res.warnings {
+		fmt.Fprintln(os.Stderr, w)
+	}
+
+	paths := make([]string, 0, len(res.files))
+	for path := range res.files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		switch {
+		case list:
+			fmt.Println(path)
+		case write:
+			if err := os.WriteFile(path, res.files[path], 0o644); err != nil {
+				return err
+			}
+		default:
+			fmt.Printf("// %s\n%s", path, res.files[path])
+		}
+	}
+	return nil
+}
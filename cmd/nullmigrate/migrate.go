@@ -0,0 +1,467 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/imports"
+)
+
+const nullablePath = "github.com/manattan/nullable"
+
+// target names a struct field to migrate.
+type target struct {
+	typ, field string
+}
+
+func (t target) String() string { return t.typ + "." + t.field }
+
+// parseTargets parses a comma-separated list of Type.Field names.
+func parseTargets(s string) ([]target, error) {
+	var targets []target
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		typ, field, ok := strings.Cut(item, ".")
+		if !ok || typ == "" || field == "" {
+			return nil, fmt.Errorf("invalid field %q: want Type.Field", item)
+		}
+		targets = append(targets, target{typ, field})
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("no fields given; use -fields=Type.Field")
+	}
+	return targets, nil
+}
+
+// result holds the rewritten files, keyed by path, and diagnostics for
+// uses that need manual review.
+type result struct {
+	files    map[string][]byte
+	warnings []string
+}
+
+// fieldKey identifies a struct field independently of which package's type
+// information it was loaded from.
+type fieldKey struct {
+	pkgPath, typ, field string
+}
+
+type migrator struct {
+	fields   map[fieldKey]bool
+	warnings []string
+}
+
+// migrate rewrites the target fields declared in pkgs and their uses in all
+// of pkgs.
+func migrate(pkgs []*packages.Package, targets []target) (*result, error) {
+	m := &migrator{fields: make(map[fieldKey]bool)}
+	found := make(map[target]bool)
+	for _, pkg := range pkgs {
+		for _, t := range targets {
+			obj, ok := pkg.Types.Scope().Lookup(t.typ).(*types.TypeName)
+			if !ok {
+				continue
+			}
+			st, ok := obj.Type().Underlying().(*types.Struct)
+			if !ok {
+				return nil, fmt.Errorf("%s is not a struct type", t.typ)
+			}
+			var field *types.Var
+			for i := 0; i < st.NumFields(); i++ {
+				if st.Field(i).Name() == t.field {
+					field = st.Field(i)
+				}
+			}
+			if field == nil {
+				return nil, fmt.Errorf("%s has no field %s", t.typ, t.field)
+			}
+			if _, ok := field.Type().(*types.Pointer); !ok {
+				return nil, fmt.Errorf("%s is %s, not a pointer", t, field.Type())
+			}
+			m.fields[fieldKey{pkg.PkgPath, t.typ, t.field}] = true
+			found[t] = true
+		}
+	}
+	for _, t := range targets {
+		if !found[t] {
+			return nil, fmt.Errorf("type %s not found", t.typ)
+		}
+	}
+
+	res := &result{files: make(map[string][]byte)}
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Syntax {
+			path := pkg.Fset.File(file.Pos()).Name()
+			var orig bytes.Buffer
+			if err := format.Node(&orig, pkg.Fset, file); err != nil {
+				return nil, err
+			}
+			if !m.rewriteFile(pkg, file) {
+				continue
+			}
+			var buf bytes.Buffer
+			if err := format.Node(&buf, pkg.Fset, file); err != nil {
+				return nil, err
+			}
+			// Regroup the imports now that nullable has been added.
+			out, err := imports.Process(path, buf.Bytes(), &imports.Options{Comments: true, TabIndent: true, TabWidth: 8, FormatOnly: true})
+			if err != nil {
+				return nil, err
+			}
+			if !bytes.Equal(orig.Bytes(), out) {
+				res.files[path] = out
+			}
+		}
+	}
+	res.warnings = m.warnings
+	return res, nil
+}
+
+// fileState carries per-file rewriting context.
+type fileState struct {
+	pkg   *packages.Package
+	file  *ast.File
+	alias string
+	edits map[ast.Node]ast.Expr
+	// litTargets holds the composite literal elements setting target
+	// fields.
+	litTargets map[*ast.KeyValueExpr]bool
+}
+
+// rewriteFile rewrites declarations and uses of the target fields in file,
+// reporting whether anything changed.
+func (m *migrator) rewriteFile(pkg *packages.Package, file *ast.File) bool {
+	fs := &fileState{pkg: pkg, file: file, alias: nullableAlias(file), edits: make(map[ast.Node]ast.Expr), litTargets: make(map[*ast.KeyValueExpr]bool)}
+	declChanged := m.rewriteDecls(fs)
+
+	var stack []ast.Node
+	ast.Inspect(file, func(n ast.Node) bool {
+		if n == nil {
+			stack = stack[:len(stack)-1]
+			return true
+		}
+		stack = append(stack, n)
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			if elem, ok := m.targetSel(pkg.TypesInfo, n); ok {
+				m.rewriteUse(fs, n, elem, stack[len(stack)-2])
+			}
+		case *ast.CompositeLit:
+			m.rewriteLit(fs, n)
+		}
+		return true
+	})
+
+	if len(fs.edits) == 0 && !declChanged {
+		return false
+	}
+	astutil.Apply(file, func(c *astutil.Cursor) bool {
+		if repl, ok := fs.edits[c.Node()]; ok {
+			c.Replace(repl)
+			return false
+		}
+		return true
+	}, nil)
+	if !hasImport(file, nullablePath) {
+		if fs.alias == "nullable" {
+			astutil.AddImport(pkg.Fset, file, nullablePath)
+		} else {
+			astutil.AddNamedImport(pkg.Fset, file, fs.alias, nullablePath)
+		}
+	}
+	return true
+}
+
+// rewriteDecls changes the types of target fields declared in the file.
+func (m *migrator) rewriteDecls(fs *fileState) bool {
+	changed := false
+	for _, decl := range fs.file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			st, ok := ts.Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
+			var list []*ast.Field
+			for _, f := range st.Fields.List {
+				star, isPtr := f.Type.(*ast.StarExpr)
+				var keep, migrate []*ast.Ident
+				for _, name := range f.Names {
+					if isPtr && m.fields[fieldKey{fs.pkg.PkgPath, ts.Name.Name, name.Name}] {
+						migrate = append(migrate, name)
+					} else {
+						keep = append(keep, name)
+					}
+				}
+				if len(migrate) == 0 {
+					list = append(list, f)
+					continue
+				}
+				changed = true
+				mf := *f
+				mf.Names = migrate
+				mf.Type = &ast.IndexExpr{X: fs.nullableRef("Nullable"), Index: star.X}
+				if len(keep) == 0 {
+					list = append(list, &mf)
+					continue
+				}
+				// Split the field, keeping the comments on the first part.
+				kf := *f
+				kf.Names = keep
+				if keep[0] == f.Names[0] {
+					mf.Doc, mf.Comment = nil, nil
+					list = append(list, &kf, &mf)
+				} else {
+					kf.Doc, kf.Comment = nil, nil
+					list = append(list, &mf, &kf)
+				}
+			}
+			st.Fields.List = list
+		}
+	}
+	return changed
+}
+
+// targetSel reports whether sel selects a target field, returning the
+// pointer's element type.
+func (m *migrator) targetSel(info *types.Info, sel *ast.SelectorExpr) (types.Type, bool) {
+	s, ok := info.Selections[sel]
+	if !ok || s.Kind() != types.FieldVal {
+		return nil, false
+	}
+	owner := fieldOwner(s)
+	if owner == nil || owner.Obj().Pkg() == nil {
+		return nil, false
+	}
+	if !m.fields[fieldKey{owner.Obj().Pkg().Path(), owner.Obj().Name(), s.Obj().Name()}] {
+		return nil, false
+	}
+	ptr, ok := s.Obj().Type().(*types.Pointer)
+	if !ok {
+		return nil, false
+	}
+	return ptr.Elem(), true
+}
+
+// fieldOwner returns the named struct type declaring the field selected by
+// s, following embedded fields for promoted selections.
+func fieldOwner(s *types.Selection) *types.Named {
+	t := s.Recv()
+	idx := s.Index()
+	for i, x := range idx {
+		if p, ok := t.(*types.Pointer); ok {
+			t = p.Elem()
+		}
+		named, _ := t.(*types.Named)
+		st, ok := t.Underlying().(*types.Struct)
+		if !ok {
+			return nil
+		}
+		if i == len(idx)-1 {
+			return named
+		}
+		t = st.Field(x).Type()
+	}
+	return nil
+}
+
+// rewriteUse records the rewrite of the target selector sel given its
+// parent node.
+func (m *migrator) rewriteUse(fs *fileState, sel *ast.SelectorExpr, elem types.Type, parent ast.Node) {
+	info := fs.pkg.TypesInfo
+	switch p := parent.(type) {
+	case *ast.BinaryExpr:
+		if (p.Op == token.EQL || p.Op == token.NEQ) && (isNil(info, p.X) || isNil(info, p.Y)) {
+			var valid ast.Expr = &ast.SelectorExpr{X: sel, Sel: ast.NewIdent("Valid")}
+			if p.Op == token.EQL {
+				valid = &ast.UnaryExpr{Op: token.NOT, X: valid}
+			}
+			fs.edits[p] = valid
+			return
+		}
+	case *ast.StarExpr:
+		fs.edits[p] = &ast.SelectorExpr{X: sel, Sel: ast.NewIdent("V")}
+		return
+	case *ast.SelectorExpr:
+		if p.X == sel {
+			fs.edits[sel] = &ast.SelectorExpr{X: sel, Sel: ast.NewIdent("V")}
+			return
+		}
+	case *ast.UnaryExpr:
+		if p.Op == token.AND {
+			m.warn(fs, sel, "address of %s taken; rewrite manually", types.ExprString(sel))
+			return
+		}
+	case *ast.AssignStmt:
+		for i, lhs := range p.Lhs {
+			if lhs == sel {
+				if len(p.Lhs) == len(p.Rhs) {
+					m.convert(fs, p.Rhs[i], elem)
+				} else {
+					m.warn(fs, sel, "multi-value assignment to %s; rewrite manually", types.ExprString(sel))
+				}
+				return
+			}
+		}
+		for i, rhs := range p.Rhs {
+			if rhs == sel && len(p.Lhs) == len(p.Rhs) {
+				if other, ok := p.Lhs[i].(*ast.SelectorExpr); ok {
+					if _, ok := m.targetSel(info, other); ok {
+						return // Nullable to Nullable
+					}
+				}
+			}
+		}
+	case *ast.KeyValueExpr:
+		if p.Value == sel && fs.litTargets[p] {
+			return // Nullable to Nullable
+		}
+	}
+	fs.edits[sel] = &ast.CallExpr{Fun: &ast.SelectorExpr{X: sel, Sel: ast.NewIdent("Ptr")}}
+}
+
+// rewriteLit converts the values of target fields set in a composite
+// literal of the owning struct type.
+func (m *migrator) rewriteLit(fs *fileState, lit *ast.CompositeLit) {
+	t := fs.pkg.TypesInfo.TypeOf(lit)
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return
+	}
+	st, ok := named.Underlying().(*types.Struct)
+	if !ok {
+		return
+	}
+	key := fieldKey{pkgPath: named.Obj().Pkg().Path(), typ: named.Obj().Name()}
+	for i, elt := range lit.Elts {
+		var name string
+		value := elt
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			id, ok := kv.Key.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			name, value = id.Name, kv.Value
+			key.field = name
+			if m.fields[key] {
+				fs.litTargets[kv] = true
+			}
+		} else if i < st.NumFields() {
+			name = st.Field(i).Name()
+		}
+		key.field = name
+		if !m.fields[key] {
+			continue
+		}
+		ptr, ok := fieldType(st, name).(*types.Pointer)
+		if !ok {
+			continue
+		}
+		m.convert(fs, value, ptr.Elem())
+	}
+}
+
+func fieldType(st *types.Struct, name string) types.Type {
+	for i := 0; i < st.NumFields(); i++ {
+		if st.Field(i).Name() == name {
+			return st.Field(i).Type()
+		}
+	}
+	return nil
+}
+
+// convert records the rewrite of a *T-valued expression assigned to a
+// target field into a Nullable[T] expression.
+func (m *migrator) convert(fs *fileState, expr ast.Expr, elem types.Type) {
+	info := fs.pkg.TypesInfo
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.SelectorExpr:
+		if _, ok := m.targetSel(info, e); ok {
+			return // already a Nullable after migration
+		}
+	case *ast.UnaryExpr:
+		if e.Op == token.AND {
+			if _, isLit := e.X.(*ast.CompositeLit); !isLit {
+				fs.edits[expr] = &ast.CallExpr{Fun: fs.nullableRef("NewNullable"), Args: []ast.Expr{e.X}}
+				return
+			}
+		}
+	}
+	if isNil(info, expr) {
+		fs.edits[expr] = &ast.CallExpr{Fun: &ast.IndexExpr{X: fs.nullableRef("NewNull"), Index: fs.typeExpr(elem)}}
+		return
+	}
+	fs.edits[expr] = &ast.CallExpr{Fun: fs.nullableRef("FromPtr"), Args: []ast.Expr{expr}}
+}
+
+func (m *migrator) warn(fs *fileState, n ast.Node, format string, args ...any) {
+	m.warnings = append(m.warnings, fs.pkg.Fset.Position(n.Pos()).String()+": "+fmt.Sprintf(format, args...))
+}
+
+// nullableRef returns a reference to name in the nullable package.
+func (fs *fileState) nullableRef(name string) ast.Expr {
+	return &ast.SelectorExpr{X: ast.NewIdent(fs.alias), Sel: ast.NewIdent(name)}
+}
+
+// typeExpr returns an expression for t qualified as in the file.
+func (fs *fileState) typeExpr(t types.Type) ast.Expr {
+	s := types.TypeString(t, func(p *types.Package) string {
+		if p == fs.pkg.Types {
+			return ""
+		}
+		for _, imp := range fs.file.Imports {
+			if path, _ := strconv.Unquote(imp.Path.Value); path == p.Path() && imp.Name != nil {
+				return imp.Name.Name
+			}
+		}
+		return p.Name()
+	})
+	return ast.NewIdent(s)
+}
+
+func isNil(info *types.Info, e ast.Expr) bool {
+	id, ok := ast.Unparen(e).(*ast.Ident)
+	if !ok {
+		return false
+	}
+	_, isNil := info.Uses[id].(*types.Nil)
+	return isNil
+}
+
+// nullableAlias returns the local name of the nullable package in file,
+// or "nullable" if it is not imported.
+func nullableAlias(file *ast.File) string {
+	for _, imp := range file.Imports {
+		if path, _ := strconv.Unquote(imp.Path.Value); path == nullablePath && imp.Name != nil {
+			return imp.Name.Name
+		}
+	}
+	return "nullable"
+}
+
+func hasImport(file *ast.File, path string) bool {
+	for _, imp := range file.Imports {
+		if p, _ := strconv.Unquote(imp.Path.Value); p == path {
+			return true
+		}
+	}
+	return false
+}
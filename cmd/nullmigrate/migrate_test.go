@@ -0,0 +1,152 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/packages"
+)
+
+const fixture = `package app
+
+import "time"
+
+type User struct {
+	ID          int
+	Email, Name *string
+	Age         *int
+	Seen        *time.Time
+}
+
+func update(s string, u *User, other User, age int, p *int) {
+	if u.Email == nil {
+		u.Email = &s
+	}
+	if nil != u.Age && *u.Age > 18 {
+		u.Age = nil
+	}
+	u.Age = &age
+	u.Age = p
+	u.Age = other.Age
+	_ = u.Seen.Year()
+	use(u.Email)
+	_ = &u.Age
+}
+
+func use(*string) {}
+
+func other() User { return User{} }
+
+func build(s string) User {
+	return User{Email: &s, Age: nil, Seen: other().Seen}
+}
+`
+
+func loadFixture(t *testing.T, src string) []*packages.Package {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module example.com/app\n\ngo 1.24\n",
+		"app.go": src,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	pkgs, err := packages.Load(&packages.Config{Dir: dir, Mode: loadMode}, "./...")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		t.Fatal("fixture does not type-check")
+	}
+	return pkgs
+}
+
+func TestMigrate(t *testing.T) {
+	src := strings.Replace(fixture, "u.ID2", "s", 1)
+	src = strings.Replace(src, "func update(s string, u *User,", "func update(s string, u *User,", 1)
+	pkgs := loadFixture(t, src)
+	targets, err := parseTargets("User.Email,User.Age,User.Seen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := migrate(pkgs, targets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.files) != 1 {
+		t.Fatalf("Expected 1 rewritten file, got %d", len(res.files))
+	}
+	var out string
+	for _, b := range res.files {
+		out = string(b)
+	}
+
+	for _, want := range []string{
+		`import (
+	"time"
+
+	"github.com/manattan/nullable"
+)`,
+		"Email nullable.Nullable[string]\n\tName  *string\n",
+		"Age   nullable.Nullable[int]",
+		"Seen  nullable.Nullable[time.Time]",
+		"if !u.Email.Valid {",
+		"u.Email = nullable.NewNullable(s)",
+		"if u.Age.Valid && u.Age.V > 18 {",
+		"u.Age = nullable.NewNull[int]()",
+		"u.Age = nullable.NewNullable(age)",
+		"u.Age = nullable.FromPtr(p)",
+		"u.Age = other.Age\n",
+		"_ = u.Seen.V.Year()",
+		"use(u.Email.Ptr())",
+		"User{Email: nullable.NewNullable(s), Age: nullable.NewNull[int](), Seen: other().Seen}",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+	if len(res.warnings) != 1 || !strings.Contains(res.warnings[0], "address of u.Age") {
+		t.Errorf("Expected a warning for &u.Age, got %v", res.warnings)
+	}
+}
+
+func TestMigrateErrors(t *testing.T) {
+	pkgs := loadFixture(t, "package app\n\ntype User struct{ ID int }\n")
+	tests := []struct {
+		fields string
+		want   string
+	}{
+		{"User.ID", "not a pointer"},
+		{"User.Missing", "has no field"},
+		{"Account.ID", "not found"},
+	}
+	for _, tt := range tests {
+		targets, err := parseTargets(tt.fields)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := migrate(pkgs, targets); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: Expected error containing %q, got %v", tt.fields, tt.want, err)
+		}
+	}
+}
+
+func TestParseTargets(t *testing.T) {
+	got, err := parseTargets("User.Email, Order.Note")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0] != (target{"User", "Email"}) || got[1] != (target{"Order", "Note"}) {
+		t.Errorf("Expected two targets, got %v", got)
+	}
+	for _, bad := range []string{"", "User", ".Email", "User."} {
+		if _, err := parseTargets(bad); err == nil {
+			t.Errorf("Expected an error for %q", bad)
+		}
+	}
+}
@@ -0,0 +1,260 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+const nullablePath = "github.com/manattan/nullable"
+
+// annotate adds oapi-codegen extensions to the nullable properties of the
+// OpenAPI document in data. It returns the annotated document and the paths
+// of nullable properties whose Go type could not be determined.
+func annotate(data []byte) ([]byte, []string, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, nil, fmt.Errorf("parsing spec: %w", err)
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil, nil, fmt.Errorf("empty spec")
+	}
+	root := doc.Content[0]
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		clearStyle(root)
+	}
+
+	var skipped []string
+	walk(root, "", &skipped)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, nil, err
+	}
+	return buf.Bytes(), skipped, nil
+}
+
+// clearStyle switches a document parsed from JSON to block style.
+func clearStyle(n *yaml.Node) {
+	n.Style &^= yaml.FlowStyle | yaml.DoubleQuotedStyle
+	for _, c := range n.Content {
+		clearStyle(c)
+	}
+}
+
+// walk visits every mapping in n, annotating the entries of "properties"
+// mappings.
+func walk(n *yaml.Node, path string, skipped *[]string) {
+	switch n.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key, value := n.Content[i].Value, n.Content[i+1]
+			childPath := path + "/" + key
+			if key == "properties" && value.Kind == yaml.MappingNode {
+				for j := 0; j+1 < len(value.Content); j += 2 {
+					propPath := childPath + "/" + value.Content[j].Value
+					if !annotateProperty(value.Content[j+1]) && isNullable(value.Content[j+1]) {
+						*skipped = append(*skipped, propPath)
+					}
+				}
+			}
+			walk(value, childPath, skipped)
+		}
+	case yaml.SequenceNode:
+		for i, c := range n.Content {
+			walk(c, fmt.Sprintf("%s/%d", path, i), skipped)
+		}
+	}
+}
+
+// annotateProperty adds the extensions to the property schema s if it is
+// nullable and its Go type is known, reporting whether s is annotated.
+func annotateProperty(s *yaml.Node) bool {
+	if s.Kind != yaml.MappingNode || !isNullable(s) {
+		return false
+	}
+	if get(s, "x-go-type") != nil {
+		return true
+	}
+	goType, ok := goTypeOf(nonNullSchema(s))
+	if !ok {
+		return false
+	}
+	set(s, "x-go-type", scalar("nullable.Nullable["+goType+"]"))
+	set(s, "x-go-type-import", &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{
+		scalar("path"), scalar(nullablePath),
+	}})
+	set(s, "x-go-type-skip-optional-pointer", &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: "true"})
+	return true
+}
+
+// isNullable reports whether the schema s admits null.
+func isNullable(s *yaml.Node) bool {
+	if s.Kind != yaml.MappingNode {
+		return false
+	}
+	if n := get(s, "nullable"); n != nil && n.Value == "true" {
+		return true
+	}
+	if t := get(s, "type"); t != nil && t.Kind == yaml.SequenceNode {
+		for _, c := range t.Content {
+			if c.Value == "null" {
+				return true
+			}
+		}
+	}
+	for _, key := range []string{"oneOf", "anyOf"} {
+		if alts := get(s, key); alts != nil && len(alts.Content) == 2 {
+			for _, a := range alts.Content {
+				if t := get(a, "type"); t != nil && t.Value == "null" {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// nonNullSchema returns the schema describing the non-null values of the
+// nullable schema s.
+func nonNullSchema(s *yaml.Node) *yaml.Node {
+	for _, key := range []string{"oneOf", "anyOf"} {
+		if alts := get(s, key); alts != nil && len(alts.Content) == 2 {
+			for _, a := range alts.Content {
+				if t := get(a, "type"); t == nil || t.Value != "null" {
+					return a
+				}
+			}
+		}
+	}
+	if all := get(s, "allOf"); all != nil && len(all.Content) == 1 {
+		return all.Content[0]
+	}
+	return s
+}
+
+// goTypeOf returns the Go type oapi-codegen generates for the non-null
+// values of schema s.
+func goTypeOf(s *yaml.Node) (string, bool) {
+	if s.Kind != yaml.MappingNode {
+		return "", false
+	}
+	if ref := get(s, "$ref"); ref != nil {
+		i := strings.LastIndex(ref.Value, "/")
+		if i < 0 || !strings.HasPrefix(ref.Value, "#/components/schemas/") {
+			return "", false
+		}
+		return typeName(ref.Value[i+1:]), true
+	}
+
+	typ := ""
+	if t := get(s, "type"); t != nil {
+		switch t.Kind {
+		case yaml.ScalarNode:
+			typ = t.Value
+		case yaml.SequenceNode:
+			for _, c := range t.Content {
+				if c.Value != "null" {
+					if typ != "" {
+						return "", false
+					}
+					typ = c.Value
+				}
+			}
+		}
+	}
+	format := ""
+	if f := get(s, "format"); f != nil {
+		format = f.Value
+	}
+
+	switch typ {
+	case "string":
+		switch format {
+		case "date-time":
+			return "time.Time", true
+		case "byte", "binary":
+			return "[]byte", true
+		}
+		return "string", true
+	case "integer":
+		switch format {
+		case "int32":
+			return "int32", true
+		case "int64":
+			return "int64", true
+		}
+		return "int", true
+	case "number":
+		if format == "float" {
+			return "float32", true
+		}
+		return "float64", true
+	case "boolean":
+		return "bool", true
+	case "array":
+		items := get(s, "items")
+		if items == nil || isNullable(items) {
+			return "", false
+		}
+		elem, ok := goTypeOf(items)
+		if !ok {
+			return "", false
+		}
+		return "[]" + elem, true
+	}
+	return "", false
+}
+
+// typeName converts a schema name to the Go type name oapi-codegen uses,
+// e.g. "pet-owner" to "PetOwner".
+func typeName(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func get(m *yaml.Node, key string) *yaml.Node {
+	if m == nil || m.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func set(m *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			m.Content[i+1] = value
+			return
+		}
+	}
+	m.Content = append(m.Content, scalar(key), value)
+}
+
+func scalar(s string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: s}
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+const specFixture = `openapi: 3.0.3
+info:
+  title: Pets
+  version: "1"
+paths: {}
+components:
+  schemas:
+    pet-owner:
+      type: object
+      properties:
+        id:
+          type: integer
+          format: int64
+        name:
+          type: string
+          nullable: true
+        born:
+          type: string
+          format: date-time
+          nullable: true
+        weight:
+          type: number
+          nullable: true
+        tags:
+          type: array
+          items:
+            type: string
+          nullable: true
+        best_friend:
+          allOf:
+            - $ref: '#/components/schemas/pet-owner'
+          nullable: true
+        custom:
+          type: string
+          nullable: true
+          x-go-type: MyString
+        blob:
+          type: object
+          nullable: true
+`
+
+func annotatedProperty(t *testing.T, out []byte, schema, name string) *yaml.Node {
+	t.Helper()
+	var doc yaml.Node
+	if err := yaml.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("output is not YAML: %v\n%s", err, out)
+	}
+	return get(get(get(get(get(doc.Content[0], "components"), "schemas"), schema), "properties"), name)
+}
+
+func TestAnnotate(t *testing.T) {
+	out, skipped, err := annotate([]byte(specFixture))
+	if err != nil {
+		t.Fatalf("annotate: %v", err)
+	}
+
+	for name, want := range map[string]string{
+		"name":        "nullable.Nullable[string]",
+		"born":        "nullable.Nullable[time.Time]",
+		"weight":      "nullable.Nullable[float64]",
+		"tags":        "nullable.Nullable[[]string]",
+		"best_friend": "nullable.Nullable[PetOwner]",
+		"custom":      "MyString",
+	} {
+		prop := annotatedProperty(t, out, "pet-owner", name)
+		if got := get(prop, "x-go-type"); got == nil || got.Value != want {
+			t.Errorf("%s: Expected x-go-type %s, got %v", name, want, got)
+		}
+	}
+
+	name := annotatedProperty(t, out, "pet-owner", "name")
+	if imp := get(get(name, "x-go-type-import"), "path"); imp == nil || imp.Value != nullablePath {
+		t.Errorf("Expected x-go-type-import path %s, got %v", nullablePath, imp)
+	}
+	if skip := get(name, "x-go-type-skip-optional-pointer"); skip == nil || skip.Value != "true" {
+		t.Errorf("Expected x-go-type-skip-optional-pointer, got %v", skip)
+	}
+	if get(annotatedProperty(t, out, "pet-owner", "custom"), "x-go-type-import") != nil {
+		t.Error("Expected existing x-go-type to be left alone")
+	}
+	if get(annotatedProperty(t, out, "pet-owner", "id"), "x-go-type") != nil {
+		t.Error("Expected non-nullable property to be left alone")
+	}
+	if want := []string{"/components/schemas/pet-owner/properties/blob"}; len(skipped) != 1 || skipped[0] != want[0] {
+		t.Errorf("Expected skipped %v, got %v", want, skipped)
+	}
+}
+
+func TestAnnotateOpenAPI31JSON(t *testing.T) {
+	spec := `{"openapi":"3.1.0","components":{"schemas":{
+		"Pet":{"type":"object","properties":{
+			"age":{"type":["integer","null"],"format":"int32"},
+			"owner":{"oneOf":[{"$ref":"#/components/schemas/Owner"},{"type":"null"}]},
+			"kind":{"type":"string"}
+		}},
+		"Owner":{"type":"object"}
+	}}}`
+	out, skipped, err := annotate([]byte(spec))
+	if err != nil {
+		t.Fatalf("annotate: %v", err)
+	}
+	if len(skipped) != 0 {
+		t.Errorf("Expected nothing skipped, got %v", skipped)
+	}
+	if bytes.Contains(out, []byte("{")) {
+		t.Errorf("Expected block-style YAML output, got:\n%s", out)
+	}
+	for name, want := range map[string]string{
+		"age":   "nullable.Nullable[int32]",
+		"owner": "nullable.Nullable[Owner]",
+	} {
+		if got := get(annotatedProperty(t, out, "Pet", name), "x-go-type"); got == nil || got.Value != want {
+			t.Errorf("%s: Expected x-go-type %s, got %v", name, want, got)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	in := filepath.Join(dir, "openapi.yaml")
+	if err := os.WriteFile(in, []byte(specFixture), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var stdout, stderr bytes.Buffer
+	if err := run(in, "", &stdout, &stderr); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "x-go-type: nullable.Nullable[string]") {
+		t.Errorf("Unexpected output:\n%s", stdout.String())
+	}
+	if !strings.Contains(stderr.String(), "properties/blob") {
+		t.Errorf("Expected warning for blob, got %q", stderr.String())
+	}
+
+	out := filepath.Join(dir, "api.gen.yaml")
+	if err := run(in, out, &stdout, &stderr); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if _, err := os.Stat(out); err != nil {
+		t.Errorf("Expected output file: %v", err)
+	}
+	if _, _, err := annotate([]byte("")); err == nil {
+		t.Error("Expected error for empty spec")
+	}
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/manattan/nullable"
+)
+
+// goType is the Go type of a column. Types from the nullable package are
+// written with the nullable qualifier.
+type goType struct {
+	name string
+	imp  string // import path, if any
+	// wrapper reports whether the type already represents null, so nullable
+	// columns use it directly instead of wrapping it in Nullable.
+	wrapper bool
+}
+
+var (
+	stringType  = goType{name: "string"}
+	bytesType   = goType{name: "[]byte"}
+	timeType    = goType{name: "time.Time", imp: "time"}
+	jsonType    = goType{name: "json.RawMessage", imp: "encoding/json"}
+	decimalType = goType{name: "nullable.Decimal", imp: nullablePath}
+)
+
+// postgresTypes maps Postgres udt names to Go types.
+var postgresTypes = map[string]goType{
+	"bool":        {name: "bool"},
+	"int2":        {name: "int16"},
+	"int4":        {name: "int32"},
+	"int8":        {name: "int64"},
+	"float4":      {name: "float32"},
+	"float8":      {name: "float64"},
+	"numeric":     decimalType,
+	"text":        stringType,
+	"varchar":     stringType,
+	"bpchar":      stringType,
+	"citext":      stringType,
+	"name":        stringType,
+	"uuid":        stringType,
+	"inet":        stringType,
+	"cidr":        stringType,
+	"bytea":       bytesType,
+	"date":        timeType,
+	"timestamp":   timeType,
+	"timestamptz": timeType,
+	"json":        jsonType,
+	"jsonb":       jsonType,
+	"point":       {name: "nullable.Point", imp: nullablePath},
+	"macaddr":     {name: "nullable.HardwareAddr", imp: nullablePath, wrapper: true},
+	"macaddr8":    {name: "nullable.HardwareAddr", imp: nullablePath, wrapper: true},
+	"int4range":   {name: "nullable.Int4Range", imp: nullablePath},
+	"int8range":   {name: "nullable.Int8Range", imp: nullablePath},
+	"numrange":    {name: "nullable.NumRange", imp: nullablePath},
+	"tstzrange":   {name: "nullable.TstzRange", imp: nullablePath},
+}
+
+// mysqlTypes maps MySQL data types to Go types. Integer types are handled
+// separately to honour the unsigned attribute.
+var mysqlTypes = map[string]goType{
+	"decimal":    decimalType,
+	"float":      {name: "float32"},
+	"double":     {name: "float64"},
+	"year":       {name: "int16"},
+	"char":       stringType,
+	"varchar":    stringType,
+	"tinytext":   stringType,
+	"text":       stringType,
+	"mediumtext": stringType,
+	"longtext":   stringType,
+	"enum":       stringType,
+	"set":        stringType,
+	"time":       stringType, // TIME may exceed 24 hours
+	"binary":     bytesType,
+	"varbinary":  bytesType,
+	"tinyblob":   bytesType,
+	"blob":       bytesType,
+	"mediumblob": bytesType,
+	"longblob":   bytesType,
+	"bit":        bytesType,
+	"date":       timeType,
+	"datetime":   timeType,
+	"timestamp":  timeType,
+	"json":       jsonType,
+}
+
+var mysqlInts = map[string]string{
+	"tinyint":   "int8",
+	"smallint":  "int16",
+	"mediumint": "int32",
+	"int":       "int32",
+	"bigint":    "int64",
+}
+
+// columnType returns the Go type of c. Types without a mapping, such as
+// Postgres arrays and enums, are read as their text form.
+func columnType(d nullable.Dialect, c column) goType {
+	if d == nullable.Postgres {
+		if t, ok := postgresTypes[c.udt]; ok {
+			return t
+		}
+		return stringType
+	}
+
+	dataType := strings.ToLower(c.dataType)
+	columnType := strings.ToLower(c.udt)
+	switch {
+	case columnType == "tinyint(1)" || columnType == "bit(1)":
+		return goType{name: "bool"}
+	case mysqlInts[dataType] != "":
+		name := mysqlInts[dataType]
+		if strings.Contains(columnType, "unsigned") {
+			name = "u" + name
+		}
+		return goType{name: name}
+	}
+	if t, ok := mysqlTypes[dataType]; ok {
+		return t
+	}
+	return stringType
+}
+
+// generate returns the Go source of package pkg declaring a struct for each
+// table.
+func generate(pkg string, d nullable.Dialect, tables []table) ([]byte, error) {
+	imports := make(map[string]bool)
+	var body bytes.Buffer
+	for _, t := range tables {
+		fmt.Fprintf(&body, "\n// %s is a row of the %s table.\ntype %s struct {\n", exportedName(t.name), t.name, exportedName(t.name))
+		for _, c := range t.columns {
+			typ := columnType(d, c)
+			name := typ.name
+			if c.nullable && !typ.wrapper {
+				name = "nullable.Nullable[" + name + "]"
+				imports[nullablePath] = true
+			}
+			if typ.imp != "" {
+				imports[typ.imp] = true
+			}
+			fmt.Fprintf(&body, "%s %s `db:%q json:%q`\n", exportedName(c.name), name, c.name, c.name)
+		}
+		body.WriteString("}\n")
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by nullschema; DO NOT EDIT.\n\npackage %s\n", pkg)
+	if len(imports) > 0 {
+		paths := make([]string, 0, len(imports))
+		for p := range imports {
+			paths = append(paths, p)
+		}
+		// The standard library sorts first, ahead of the nullable package.
+		sort.Slice(paths, func(i, j int) bool {
+			if (paths[i] == nullablePath) != (paths[j] == nullablePath) {
+				return paths[j] == nullablePath
+			}
+			return paths[i] < paths[j]
+		})
+		buf.WriteString("\nimport (\n")
+		for i, p := range paths {
+			if p == nullablePath && i > 0 {
+				buf.WriteString("\n")
+			}
+			fmt.Fprintf(&buf, "%q\n", p)
+		}
+		buf.WriteString(")\n")
+	}
+	buf.Write(body.Bytes())
+
+	out, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+	return out, nil
+}
+
+// initialisms are name parts written in upper case, following Go naming
+// conventions.
+var initialisms = map[string]bool{
+	"api": true, "html": true, "http": true, "id": true, "ip": true,
+	"json": true, "sql": true, "uri": true, "url": true, "utc": true,
+	"uuid": true,
+}
+
+// exportedName converts a snake_case database name to an exported Go
+// identifier, such as "user_id" to "UserID".
+func exportedName(s string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if initialisms[strings.ToLower(part)] {
+			b.WriteString(strings.ToUpper(part))
+			continue
+		}
+		r := []rune(part)
+		b.WriteString(strings.ToUpper(string(r[0])) + string(r[1:]))
+	}
+	name := b.String()
+	if name == "" || !unicode.IsLetter([]rune(name)[0]) {
+		name = "X" + name
+	}
+	return name
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/manattan/nullable"
+)
+
+func TestGeneratePostgres(t *testing.T) {
+	tables := []table{{
+		name: "user_accounts",
+		columns: []column{
+			{name: "id", dataType: "bigint", udt: "int8"},
+			{name: "email", dataType: "text", udt: "text"},
+			{name: "nickname", dataType: "character varying", udt: "varchar", nullable: true},
+			{name: "balance", dataType: "numeric", udt: "numeric", nullable: true},
+			{name: "last_login_at", dataType: "timestamp with time zone", udt: "timestamptz", nullable: true},
+			{name: "tags", dataType: "ARRAY", udt: "_text", nullable: true},
+			{name: "mac", dataType: "macaddr", udt: "macaddr", nullable: true},
+		},
+	}}
+	got, err := generate("models", nullable.Postgres, tables)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "// Code generated by nullschema; DO NOT EDIT.\n\n" +
+		"package models\n\n" +
+		"import (\n" +
+		"\t\"time\"\n\n" +
+		"\t\"github.com/manattan/nullable\"\n" +
+		")\n\n" +
+		"// UserAccounts is a row of the user_accounts table.\n" +
+		"type UserAccounts struct {\n" +
+		"\tID          int64                               `db:\"id\" json:\"id\"`\n" +
+		"\tEmail       string                              `db:\"email\" json:\"email\"`\n" +
+		"\tNickname    nullable.Nullable[string]           `db:\"nickname\" json:\"nickname\"`\n" +
+		"\tBalance     nullable.Nullable[nullable.Decimal] `db:\"balance\" json:\"balance\"`\n" +
+		"\tLastLoginAt nullable.Nullable[time.Time]        `db:\"last_login_at\" json:\"last_login_at\"`\n" +
+		"\tTags        nullable.Nullable[string]           `db:\"tags\" json:\"tags\"`\n" +
+		"\tMac         nullable.HardwareAddr               `db:\"mac\" json:\"mac\"`\n" +
+		"}\n"
+	if string(got) != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestGenerateNoImports(t *testing.T) {
+	tables := []table{{name: "t", columns: []column{{name: "n", dataType: "int", udt: "int"}}}}
+	got, err := generate("models", nullable.MySQL, tables)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "// Code generated by nullschema; DO NOT EDIT.\n\npackage models\n\n// T is a row of the t table.\ntype T struct {\n\tN int32 `db:\"n\" json:\"n\"`\n}\n"
+	if string(got) != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestColumnTypeMySQL(t *testing.T) {
+	tests := []struct {
+		dataType, columnType string
+		want                 string
+	}{
+		{"tinyint", "tinyint(1)", "bool"},
+		{"tinyint", "tinyint(4)", "int8"},
+		{"int", "int(10) unsigned", "uint32"},
+		{"bigint", "bigint unsigned", "uint64"},
+		{"bit", "bit(1)", "bool"},
+		{"bit", "bit(8)", "[]byte"},
+		{"decimal", "decimal(10,2)", "nullable.Decimal"},
+		{"datetime", "datetime(6)", "time.Time"},
+		{"enum", "enum('a','b')", "string"},
+		{"json", "json", "json.RawMessage"},
+		{"geometry", "geometry", "string"},
+	}
+	for _, tt := range tests {
+		if got := columnType(nullable.MySQL, column{dataType: tt.dataType, udt: tt.columnType}); got.name != tt.want {
+			t.Errorf("%s: Expected %s, got %s", tt.columnType, tt.want, got.name)
+		}
+	}
+}
+
+func TestExportedName(t *testing.T) {
+	tests := map[string]string{
+		"user_id":       "UserID",
+		"avatar_url":    "AvatarURL",
+		"createdAt":     "CreatedAt",
+		"order-items":   "OrderItems",
+		"2fa_enabled":   "X2faEnabled",
+		"api_key_uuid":  "APIKeyUUID",
+		"ip_address_v4": "IPAddressV4",
+	}
+	for in, want := range tests {
+		if got := exportedName(in); got != want {
+			t.Errorf("%s: Expected %s, got %s", in, want, got)
+		}
+	}
+}
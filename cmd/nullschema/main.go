@@ -0,0 +1,95 @@
+// Command nullschema generates Go structs from a Postgres or MySQL schema.
+// Nullable columns become nullable.Nullable[T] fields, so models start out
+// with the nullability the database actually enforces.
+//
+// Usage:
+//
+//	nullschema -driver=postgres -dsn=postgres://localhost/app [-schema=public] [-tables=users,orders] [-pkg=models] [-o=models.go]
+//	nullschema -driver=mysql -dsn='user:pass@/app' [-tables=users] [-pkg=models] [-o=models.go]
+//
+// Each table becomes a struct named after it in CamelCase, with one field per
+// column carrying db and json tags of the column name. The schema defaults
+// to public on Postgres and the connection's database on MySQL.
+//
+// MySQL DATE, DATETIME and TIMESTAMP columns map to time.Time, so scanning
+// the generated structs requires parseTime=true in the application's DSN.
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"os"
+
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/jackc/pgx/v5/stdlib"
+
+	"github.com/manattan/nullable"
+)
+
+const nullablePath = "github.com/manattan/nullable"
+
+// options holds the command-line flags.
+type options struct {
+	driver string
+	dsn    string
+	schema string
+	tables string
+	pkg    string
+	output string
+}
+
+func main() {
+	var o options
+	flag.StringVar(&o.driver, "driver", "postgres", "database driver: postgres or mysql")
+	flag.StringVar(&o.dsn, "dsn", "", "data source name")
+	flag.StringVar(&o.schema, "schema", "", "schema to introspect (default public on postgres, the current database on mysql)")
+	flag.StringVar(&o.tables, "tables", "", "comma-separated table names (default all tables)")
+	flag.StringVar(&o.pkg, "pkg", "models", "package name of the generated file")
+	flag.StringVar(&o.output, "o", "", "output file (default standard output)")
+	flag.Parse()
+
+	if err := run(context.Background(), o); err != nil {
+		fmt.Fprintln(os.Stderr, "nullschema:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, o options) error {
+	var (
+		dialect    nullable.Dialect
+		driverName string
+	)
+	switch o.driver {
+	case "postgres":
+		dialect, driverName = nullable.Postgres, "pgx"
+	case "mysql":
+		dialect, driverName = nullable.MySQL, "mysql"
+	default:
+		return fmt.Errorf("unknown driver %q", o.driver)
+	}
+	if o.dsn == "" {
+		return fmt.Errorf("-dsn is required")
+	}
+
+	db, err := sql.Open(driverName, o.dsn)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	tables, err := loadTables(ctx, db, dialect, o.schema, splitList(o.tables))
+	if err != nil {
+		return err
+	}
+	src, err := generate(o.pkg, dialect, tables)
+	if err != nil {
+		return err
+	}
+	if o.output == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	return os.WriteFile(o.output, src, 0o644)
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/manattan/nullable"
+)
+
+// table describes a database table and its columns in ordinal order.
+type table struct {
+	name    string
+	columns []column
+}
+
+// column describes a table column as reported by information_schema.
+type column struct {
+	name string
+	// dataType is the information_schema data_type, such as "integer".
+	dataType string
+	// udt is the Postgres udt_name (such as "int4" or "_text") or the MySQL
+	// column_type (such as "tinyint(1) unsigned").
+	udt      string
+	nullable bool
+}
+
+const (
+	postgresColumns = `SELECT table_name, column_name, data_type, udt_name, is_nullable = 'YES'
+FROM information_schema.columns
+WHERE table_schema = $1
+ORDER BY table_name, ordinal_position`
+
+	mysqlColumns = `SELECT table_name, column_name, data_type, column_type, is_nullable = 'YES'
+FROM information_schema.columns
+WHERE table_schema = COALESCE(NULLIF(?, ''), DATABASE())
+ORDER BY table_name, ordinal_position`
+)
+
+// loadTables reads the columns of the tables in schema, restricted to names
+// if it is not empty.
+func loadTables(ctx context.Context, db *sql.DB, d nullable.Dialect, schema string, names []string) ([]table, error) {
+	query := mysqlColumns
+	if d == nullable.Postgres {
+		query = postgresColumns
+		if schema == "" {
+			schema = "public"
+		}
+	}
+	rows, err := db.QueryContext(ctx, query, schema)
+	if err != nil {
+		return nil, fmt.Errorf("reading columns: %w", err)
+	}
+	defer rows.Close()
+
+	var tables []table
+	for rows.Next() {
+		var name string
+		var c column
+		if err := rows.Scan(&name, &c.name, &c.dataType, &c.udt, &c.nullable); err != nil {
+			return nil, fmt.Errorf("reading columns: %w", err)
+		}
+		if len(names) > 0 && !slices.Contains(names, name) {
+			continue
+		}
+		if len(tables) == 0 || tables[len(tables)-1].name != name {
+			tables = append(tables, table{name: name})
+		}
+		t := &tables[len(tables)-1]
+		t.columns = append(t.columns, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("reading columns: %w", err)
+	}
+
+	for _, name := range names {
+		if !slices.ContainsFunc(tables, func(t table) bool { return t.name == name }) {
+			return nil, fmt.Errorf("table %q not found", name)
+		}
+	}
+	if len(tables) == 0 {
+		return nil, fmt.Errorf("no tables found in schema %q", schema)
+	}
+	return tables, nil
+}
+
+// splitList splits a comma-separated flag value, dropping empty entries.
+func splitList(s string) []string {
+	var list []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
@@ -0,0 +1,278 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	nullablePackage    = protogen.GoImportPath("github.com/manattan/nullable")
+	timePackage        = protogen.GoImportPath("time")
+	wrapperspbPackage  = protogen.GoImportPath("google.golang.org/protobuf/types/known/wrapperspb")
+	timestamppbPackage = protogen.GoImportPath("google.golang.org/protobuf/types/known/timestamppb")
+	durationpbPackage  = protogen.GoImportPath("google.golang.org/protobuf/types/known/durationpb")
+)
+
+// wellKnown describes a well-known message type mapped to a Nullable.
+type wellKnown struct {
+	goType   string
+	newIdent protogen.GoIdent // constructs the message from a Go value
+	get      string           // expression suffix reading the Go value
+	pkg      protogen.GoImportPath
+}
+
+var wellKnownTypes = map[protoreflect.FullName]wellKnown{
+	"google.protobuf.StringValue": {goType: "string", newIdent: wrapperspbPackage.Ident("String"), get: ".GetValue()"},
+	"google.protobuf.BytesValue":  {goType: "[]byte", newIdent: wrapperspbPackage.Ident("Bytes"), get: ".GetValue()"},
+	"google.protobuf.BoolValue":   {goType: "bool", newIdent: wrapperspbPackage.Ident("Bool"), get: ".GetValue()"},
+	"google.protobuf.Int32Value":  {goType: "int32", newIdent: wrapperspbPackage.Ident("Int32"), get: ".GetValue()"},
+	"google.protobuf.Int64Value":  {goType: "int64", newIdent: wrapperspbPackage.Ident("Int64"), get: ".GetValue()"},
+	"google.protobuf.UInt32Value": {goType: "uint32", newIdent: wrapperspbPackage.Ident("UInt32"), get: ".GetValue()"},
+	"google.protobuf.UInt64Value": {goType: "uint64", newIdent: wrapperspbPackage.Ident("UInt64"), get: ".GetValue()"},
+	"google.protobuf.FloatValue":  {goType: "float32", newIdent: wrapperspbPackage.Ident("Float"), get: ".GetValue()"},
+	"google.protobuf.DoubleValue": {goType: "float64", newIdent: wrapperspbPackage.Ident("Double"), get: ".GetValue()"},
+	"google.protobuf.Timestamp":   {goType: "Time", newIdent: timestamppbPackage.Ident("New"), get: ".AsTime()", pkg: timePackage},
+	"google.protobuf.Duration":    {goType: "Duration", newIdent: durationpbPackage.Ident("New"), get: ".AsDuration()", pkg: timePackage},
+}
+
+// fieldKind classifies how a field is converted.
+type fieldKind int
+
+const (
+	plainField     fieldKind = iota // copied as is
+	optionalField                   // pointer to a scalar or enum
+	bytesField                      // optional bytes, present when non-nil
+	wellKnownField                  // wrapper, Timestamp or Duration message
+)
+
+func classifyField(f *protogen.Field) fieldKind {
+	d := f.Desc
+	if d.IsList() || d.IsMap() || f.Oneof != nil && !f.Oneof.Desc.IsSynthetic() {
+		return plainField
+	}
+	switch d.Kind() {
+	case protoreflect.MessageKind:
+		if _, ok := wellKnownTypes[f.Message.Desc.FullName()]; ok {
+			return wellKnownField
+		}
+		return plainField
+	case protoreflect.GroupKind:
+		return plainField
+	case protoreflect.BytesKind:
+		if d.HasPresence() {
+			return bytesField
+		}
+		return plainField
+	}
+	if d.HasPresence() {
+		return optionalField
+	}
+	return plainField
+}
+
+// hasNullable reports whether m has a field mapped to a Nullable.
+func hasNullable(m *protogen.Message) bool {
+	if m.Desc.IsMapEntry() {
+		return false
+	}
+	for _, f := range m.Fields {
+		if classifyField(f) != plainField {
+			return true
+		}
+	}
+	return false
+}
+
+// messages returns the messages of f and their nested messages in
+// declaration order.
+func messages(list []*protogen.Message) []*protogen.Message {
+	var all []*protogen.Message
+	for _, m := range list {
+		all = append(all, m)
+		all = append(all, messages(m.Messages)...)
+	}
+	return all
+}
+
+// generateFile emits the conversions for f, or nothing if no message has a
+// field mapped to a Nullable.
+func generateFile(gen *protogen.Plugin, f *protogen.File) *protogen.GeneratedFile {
+	var targets []*protogen.Message
+	for _, m := range messages(f.Messages) {
+		if hasNullable(m) {
+			targets = append(targets, m)
+		}
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+
+	g := gen.NewGeneratedFile(f.GeneratedFilenamePrefix+"_nullable.pb.go", f.GoImportPath)
+	g.P("// Code generated by protoc-gen-go-nullable. DO NOT EDIT.")
+	g.P("// source: ", f.Desc.Path())
+	g.P()
+	g.P("package ", f.GoPackageName)
+	for _, m := range targets {
+		generateMessage(g, m)
+	}
+	return g
+}
+
+func generateMessage(g *protogen.GeneratedFile, m *protogen.Message) {
+	name := m.GoIdent.GoName + "Nullable"
+	nullableIdent := g.QualifiedGoIdent(nullablePackage.Ident("Nullable"))
+
+	g.P()
+	g.P("// ", name, " is ", m.GoIdent.GoName, " with optional and wrapper fields as Nullable values.")
+	g.P("type ", name, " struct {")
+	seenOneofs := make(map[*protogen.Oneof]bool)
+	for _, f := range m.Fields {
+		if o := f.Oneof; o != nil && !o.Desc.IsSynthetic() {
+			if !seenOneofs[o] {
+				seenOneofs[o] = true
+				g.P(o.GoName, " is", o.GoIdent.GoName)
+			}
+			continue
+		}
+		g.P(f.Comments.Leading, f.GoName, " ", nullableFieldType(g, f, nullableIdent))
+	}
+	g.P("}")
+
+	g.P()
+	g.P("// ", name, "FromProto converts m to a ", name, ". A nil m converts to the zero value.")
+	g.P("func ", name, "FromProto(m *", m.GoIdent, ") ", name, " {")
+	g.P("if m == nil {")
+	g.P("return ", name, "{}")
+	g.P("}")
+	g.P("return ", name, "{")
+	clear(seenOneofs)
+	for _, f := range m.Fields {
+		if o := f.Oneof; o != nil && !o.Desc.IsSynthetic() {
+			if !seenOneofs[o] {
+				seenOneofs[o] = true
+				g.P(o.GoName, ": m.", o.GoName, ",")
+			}
+			continue
+		}
+		switch classifyField(f) {
+		case optionalField:
+			g.P(f.GoName, ": ", nullablePackage.Ident("FromPtr"), "(m.", f.GoName, "),")
+		case bytesField:
+			g.P(f.GoName, ": ", nullablePackage.Ident("Of"), "(m.", f.GoName, ", m.", f.GoName, " != nil),")
+		case wellKnownField:
+			wk := wellKnownTypes[f.Message.Desc.FullName()]
+			g.P(f.GoName, ": ", nullablePackage.Ident("Of"), "(m.Get", f.GoName, "()", wk.get, ", m.", f.GoName, " != nil),")
+		default:
+			g.P(f.GoName, ": m.", f.GoName, ",")
+		}
+	}
+	g.P("}")
+	g.P("}")
+
+	g.P()
+	g.P("// Proto converts n to a ", m.GoIdent.GoName, ", leaving null fields unset.")
+	g.P("func (n ", name, ") Proto() *", m.GoIdent, " {")
+	g.P("m := &", m.GoIdent, "{")
+	clear(seenOneofs)
+	var deferred []*protogen.Field
+	for _, f := range m.Fields {
+		if o := f.Oneof; o != nil && !o.Desc.IsSynthetic() {
+			if !seenOneofs[o] {
+				seenOneofs[o] = true
+				g.P(o.GoName, ": n.", o.GoName, ",")
+			}
+			continue
+		}
+		switch classifyField(f) {
+		case optionalField:
+			g.P(f.GoName, ": n.", f.GoName, ".Ptr(),")
+		case bytesField, wellKnownField:
+			deferred = append(deferred, f)
+		default:
+			g.P(f.GoName, ": n.", f.GoName, ",")
+		}
+	}
+	g.P("}")
+	for _, f := range deferred {
+		g.P("if n.", f.GoName, ".Valid {")
+		if classifyField(f) == bytesField {
+			// A non-nil slice keeps the field present even when empty.
+			g.P("m.", f.GoName, " = append([]byte{}, n.", f.GoName, ".V...)")
+		} else {
+			wk := wellKnownTypes[f.Message.Desc.FullName()]
+			g.P("m.", f.GoName, " = ", wk.newIdent, "(n.", f.GoName, ".V)")
+		}
+		g.P("}")
+	}
+	g.P("return m")
+	g.P("}")
+}
+
+// nullableFieldType returns the Go type of f in the Nullable struct.
+func nullableFieldType(g *protogen.GeneratedFile, f *protogen.Field, nullableIdent string) string {
+	switch classifyField(f) {
+	case optionalField:
+		return nullableIdent + "[" + scalarType(g, f) + "]"
+	case bytesField:
+		return nullableIdent + "[[]byte]"
+	case wellKnownField:
+		wk := wellKnownTypes[f.Message.Desc.FullName()]
+		typ := wk.goType
+		if wk.pkg != "" {
+			typ = g.QualifiedGoIdent(wk.pkg.Ident(typ))
+		}
+		return nullableIdent + "[" + typ + "]"
+	}
+	return protoGoType(g, f)
+}
+
+// scalarType returns the Go type of a singular scalar or enum field,
+// matching protoc-gen-go.
+func scalarType(g *protogen.GeneratedFile, f *protogen.Field) string {
+	switch f.Desc.Kind() {
+	case protoreflect.BoolKind:
+		return "bool"
+	case protoreflect.EnumKind:
+		return g.QualifiedGoIdent(f.Enum.GoIdent)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return "int32"
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return "uint32"
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return "int64"
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return "uint64"
+	case protoreflect.FloatKind:
+		return "float32"
+	case protoreflect.DoubleKind:
+		return "float64"
+	case protoreflect.StringKind:
+		return "string"
+	case protoreflect.BytesKind:
+		return "[]byte"
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return "*" + g.QualifiedGoIdent(f.Message.GoIdent)
+	}
+	panic(fmt.Sprintf("unknown field kind %v", f.Desc.Kind()))
+}
+
+// protoGoType returns the Go type protoc-gen-go uses for f in the message
+// struct.
+func protoGoType(g *protogen.GeneratedFile, f *protogen.Field) string {
+	switch {
+	case f.Desc.IsMap():
+		key := scalarType(g, f.Message.Fields[0])
+		val := scalarType(g, f.Message.Fields[1])
+		return "map[" + key + "]" + val
+	case f.Desc.IsList():
+		return "[]" + scalarType(g, f)
+	}
+	typ := scalarType(g, f)
+	if f.Desc.HasPresence() && !strings.HasPrefix(typ, "*") && typ != "[]byte" {
+		typ = "*" + typ
+	}
+	return typ
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// userProto describes:
+//
+//	syntax = "proto3";
+//	package app;
+//	option go_package = "example.com/app/pb";
+//	message User {
+//	  string id = 1;
+//	  optional string email = 2;
+//	  google.protobuf.Int64Value age = 3;
+//	  google.protobuf.Timestamp seen_at = 4;
+//	  optional bytes avatar = 5;
+//	  repeated string tags = 6;
+//	  oneof contact { string phone = 7; string fax = 8; }
+//	  optional Role role = 9;
+//	}
+//	message Plain { string name = 1; }
+//	enum Role { ROLE_UNSPECIFIED = 0; }
+func userProto() *descriptorpb.FileDescriptorProto {
+	field := func(name string, num int32, typ descriptorpb.FieldDescriptorProto_Type, label descriptorpb.FieldDescriptorProto_Label) *descriptorpb.FieldDescriptorProto {
+		return &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(num),
+			Type:     typ.Enum(),
+			Label:    label.Enum(),
+		}
+	}
+	optional := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL
+	repeated := descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+	inOneof := func(f *descriptorpb.FieldDescriptorProto, index int32, proto3Optional bool) *descriptorpb.FieldDescriptorProto {
+		f.OneofIndex = proto.Int32(index)
+		if proto3Optional {
+			f.Proto3Optional = proto.Bool(true)
+		}
+		return f
+	}
+	message := func(f *descriptorpb.FieldDescriptorProto, typeName string) *descriptorpb.FieldDescriptorProto {
+		f.TypeName = proto.String(typeName)
+		return f
+	}
+
+	return &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("app/user.proto"),
+		Package:    proto.String("app"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"google/protobuf/wrappers.proto", "google/protobuf/timestamp.proto"},
+		Options:    &descriptorpb.FileOptions{GoPackage: proto.String("example.com/app/pb")},
+		EnumType: []*descriptorpb.EnumDescriptorProto{{
+			Name:  proto.String("Role"),
+			Value: []*descriptorpb.EnumValueDescriptorProto{{Name: proto.String("ROLE_UNSPECIFIED"), Number: proto.Int32(0)}},
+		}},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("User"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("id", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, optional),
+					inOneof(field("email", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING, optional), 1, true),
+					message(field("age", 3, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, optional), ".google.protobuf.Int64Value"),
+					message(field("seen_at", 4, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, optional), ".google.protobuf.Timestamp"),
+					inOneof(field("avatar", 5, descriptorpb.FieldDescriptorProto_TYPE_BYTES, optional), 2, true),
+					field("tags", 6, descriptorpb.FieldDescriptorProto_TYPE_STRING, repeated),
+					inOneof(field("phone", 7, descriptorpb.FieldDescriptorProto_TYPE_STRING, optional), 0, false),
+					inOneof(field("fax", 8, descriptorpb.FieldDescriptorProto_TYPE_STRING, optional), 0, false),
+					message(inOneof(field("role", 9, descriptorpb.FieldDescriptorProto_TYPE_ENUM, optional), 3, true), ".app.Role"),
+				},
+				OneofDecl: []*descriptorpb.OneofDescriptorProto{
+					{Name: proto.String("contact")},
+					{Name: proto.String("_email")},
+					{Name: proto.String("_avatar")},
+					{Name: proto.String("_role")},
+				},
+			},
+			{
+				Name:  proto.String("Plain"),
+				Field: []*descriptorpb.FieldDescriptorProto{field("name", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, optional)},
+			},
+		},
+	}
+}
+
+// newPlugin returns a plugin for a request generating userProto.
+func newPlugin(t *testing.T) *protogen.Plugin {
+	t.Helper()
+	req := &pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{"app/user.proto"},
+		ProtoFile: []*descriptorpb.FileDescriptorProto{
+			protodesc.ToFileDescriptorProto(wrapperspb.File_google_protobuf_wrappers_proto),
+			protodesc.ToFileDescriptorProto(timestamppb.File_google_protobuf_timestamp_proto),
+			userProto(),
+		},
+	}
+	gen, err := protogen.Options{}.New(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return gen
+}
+
+func TestGenerateFile(t *testing.T) {
+	gen := newPlugin(t)
+	g := generateFile(gen, gen.FilesByPath["app/user.proto"])
+	if g == nil {
+		t.Fatal("Expected a generated file")
+	}
+	content, err := g.Content()
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(content)
+
+	for _, want := range []string{
+		"// Code generated by protoc-gen-go-nullable. DO NOT EDIT.\n// source: app/user.proto\n\npackage pb\n",
+		`nullable "github.com/manattan/nullable"`,
+		"type UserNullable struct {",
+		"\tId      string\n",
+		"\tEmail   nullable.Nullable[string]\n",
+		"\tAge     nullable.Nullable[int64]\n",
+		"\tSeenAt  nullable.Nullable[time.Time]\n",
+		"\tAvatar  nullable.Nullable[[]byte]\n",
+		"\tTags    []string\n",
+		"\tContact isUser_Contact\n",
+		"\tRole    nullable.Nullable[Role]\n",
+		"func UserNullableFromProto(m *User) UserNullable {",
+		"Email:   nullable.FromPtr(m.Email),",
+		"Age:     nullable.Of(m.GetAge().GetValue(), m.Age != nil),",
+		"SeenAt:  nullable.Of(m.GetSeenAt().AsTime(), m.SeenAt != nil),",
+		"Avatar:  nullable.Of(m.Avatar, m.Avatar != nil),",
+		"func (n UserNullable) Proto() *User {",
+		"Email:   n.Email.Ptr(),",
+		"Contact: n.Contact,",
+		"m.Age = wrapperspb.Int64(n.Age.V)",
+		"m.SeenAt = timestamppb.New(n.SeenAt.V)",
+		"m.Avatar = append([]byte{}, n.Avatar.V...)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "PlainNullable") {
+		t.Error("Expected no struct for a message without nullable fields")
+	}
+	if strings.Count(out, "Contact: ") != 2 {
+		t.Errorf("Expected the contact oneof once per section, got:\n%s", out)
+	}
+}
+
+func TestGenerateFileSkipsPlainFiles(t *testing.T) {
+	gen := newPlugin(t)
+	for _, path := range []string{"google/protobuf/wrappers.proto", "google/protobuf/timestamp.proto"} {
+		f := gen.FilesByPath[path]
+		f.Messages = f.Messages[:0]
+		if g := generateFile(gen, f); g != nil {
+			t.Errorf("%s: Expected no generated file", path)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+// Command protoc-gen-go-nullable is a protoc plugin generating Go structs
+// with nullable.Nullable fields, and conversions to and from them, for
+// messages with optional fields or well-known wrapper types.
+//
+// Usage:
+//
+//	protoc --go_out=. --go-nullable_out=. user.proto
+//
+// For every such message Foo it emits, next to the protoc-gen-go output:
+//
+//	type FooNullable struct { ... }
+//	func FooNullableFromProto(m *Foo) FooNullable
+//	func (n FooNullable) Proto() *Foo
+//
+// Optional scalar and enum fields, google.protobuf wrapper types such as
+// StringValue, Timestamp and Duration become Nullable fields; all other
+// fields are copied as they are.
+package main
+
+import (
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+func main() {
+	protogen.Options{}.Run(func(gen *protogen.Plugin) error {
+		gen.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
+		for _, f := range gen.Files {
+			if f.Generate {
+				generateFile(gen, f)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,92 @@
+package nullable
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"strings"
+	"sync"
+)
+
+// Codec encodes and decodes values for a single content type.
+type Codec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+// CodecFuncs adapts a pair of functions to the Codec interface.
+type CodecFuncs struct {
+	MarshalFunc   func(v any) ([]byte, error)
+	UnmarshalFunc func(data []byte, v any) error
+}
+
+// Marshal implements the Codec interface.
+func (c CodecFuncs) Marshal(v any) ([]byte, error) {
+	return c.MarshalFunc(v)
+}
+
+// Unmarshal implements the Codec interface.
+func (c CodecFuncs) Unmarshal(data []byte, v any) error {
+	return c.UnmarshalFunc(data, v)
+}
+
+var (
+	codecsMu sync.RWMutex
+	codecs   = map[string]Codec{
+		"application/json": CodecFuncs{MarshalFunc: json.Marshal, UnmarshalFunc: json.Unmarshal},
+	}
+)
+
+// RegisterCodec registers c for contentType, replacing any codec previously
+// registered for it. Parameters such as charset are ignored. A JSON codec is
+// registered for "application/json" by default.
+func RegisterCodec(contentType string, c Codec) {
+	if c == nil {
+		panic("nullable: RegisterCodec called with nil codec")
+	}
+	codecsMu.Lock()
+	defer codecsMu.Unlock()
+	codecs[mediaType(contentType)] = c
+}
+
+// LookupCodec returns the codec registered for contentType. Content types
+// with a "+json" suffix fall back to the "application/json" codec.
+func LookupCodec(contentType string) (Codec, bool) {
+	mt := mediaType(contentType)
+	codecsMu.RLock()
+	defer codecsMu.RUnlock()
+	if c, ok := codecs[mt]; ok {
+		return c, true
+	}
+	if strings.HasSuffix(mt, "+json") {
+		c, ok := codecs["application/json"]
+		return c, ok
+	}
+	return nil, false
+}
+
+// MarshalContent encodes v with the codec registered for contentType.
+func MarshalContent(contentType string, v any) ([]byte, error) {
+	c, ok := LookupCodec(contentType)
+	if !ok {
+		return nil, fmt.Errorf("nullable: no codec registered for %q", contentType)
+	}
+	return c.Marshal(v)
+}
+
+// UnmarshalContent decodes data into v with the codec registered for
+// contentType.
+func UnmarshalContent(contentType string, data []byte, v any) error {
+	c, ok := LookupCodec(contentType)
+	if !ok {
+		return fmt.Errorf("nullable: no codec registered for %q", contentType)
+	}
+	return c.Unmarshal(data, v)
+}
+
+func mediaType(contentType string) string {
+	if mt, _, err := mime.ParseMediaType(contentType); err == nil {
+		return mt
+	}
+	return strings.ToLower(strings.TrimSpace(contentType))
+}
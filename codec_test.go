@@ -0,0 +1,58 @@
+package nullable
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestCodecJSONDefault(t *testing.T) {
+	type user struct {
+		Name Nullable[string] `json:"name"`
+	}
+
+	data, err := MarshalContent("application/json; charset=utf-8", user{Name: NewNull[string]()})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(data) != `{"name":null}` {
+		t.Errorf("Expected null name, got %s", data)
+	}
+
+	var decoded user
+	if err := UnmarshalContent("application/problem+json", []byte(`{"name":"x"}`), &decoded); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !decoded.Name.Valid || decoded.Name.V != "x" {
+		t.Errorf("Expected name 'x', got %+v", decoded.Name)
+	}
+}
+
+func TestRegisterCodec(t *testing.T) {
+	errDecode := errors.New("decode")
+	RegisterCodec("Application/X-Test", CodecFuncs{
+		MarshalFunc: func(v any) ([]byte, error) {
+			return []byte("test"), nil
+		},
+		UnmarshalFunc: func(data []byte, v any) error {
+			return errDecode
+		},
+	})
+
+	data, err := MarshalContent("application/x-test", NewNullable(1))
+	if err != nil || !bytes.Equal(data, []byte("test")) {
+		t.Errorf("Expected registered codec to be used, got %q, %v", data, err)
+	}
+	if err := UnmarshalContent("application/x-test; v=1", nil, nil); !errors.Is(err, errDecode) {
+		t.Errorf("Expected decode error, got %v", err)
+	}
+}
+
+func TestLookupCodecMissing(t *testing.T) {
+	if _, ok := LookupCodec("application/unknown"); ok {
+		t.Error("Expected no codec for unknown content type")
+	}
+	if _, err := MarshalContent("application/unknown", 1); err == nil {
+		t.Error("Expected error for unknown content type")
+	}
+}
@@ -0,0 +1,48 @@
+package nullable
+
+import "cmp"
+
+// Equal reports whether a and b are both null, or both valid with equal
+// values. Unlike a == b, it ignores the V of null values:
+//
+//	if !nullable.Equal(stored.Email, req.Email) {
+//		updates["email"] = req.Email
+//	}
+func Equal[T comparable](a, b Nullable[T]) bool {
+	return EqualFunc(a, b, func(x, y T) bool { return x == y })
+}
+
+// EqualFunc is like Equal for values that are not comparable with ==, such
+// as slices or structs holding a time.Time, using eq to compare valid
+// values.
+func EqualFunc[T any](a, b Nullable[T], eq func(x, y T) bool) bool {
+	if !a.Valid || !b.Valid {
+		return a.Valid == b.Valid
+	}
+	return eq(a.V, b.V)
+}
+
+// Compare returns -1, 0 or +1 depending on whether a sorts before, with or
+// after b, ordering valid values with cmp.Compare. Nulls compare equal to
+// each other and sort before valid values, or after them if nullsLast is
+// set, like NULLS FIRST and NULLS LAST in SQL:
+//
+//	slices.SortFunc(rows, func(x, y Row) int {
+//		return nullable.Compare(x.DueDate, y.DueDate, true)
+//	})
+func Compare[T cmp.Ordered](a, b Nullable[T], nullsLast bool) int {
+	switch {
+	case !a.Valid && !b.Valid:
+		return 0
+	case !a.Valid || !b.Valid:
+		c := -1
+		if !b.Valid {
+			c = 1
+		}
+		if nullsLast {
+			c = -c
+		}
+		return c
+	}
+	return cmp.Compare(a.V, b.V)
+}
@@ -0,0 +1,87 @@
+package nullable
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestEqual(t *testing.T) {
+	staleNull := Nullable[int]{}
+	staleNull.V = 5
+	tests := []struct {
+		a, b Nullable[int]
+		want bool
+	}{
+		{NewNull[int](), NewNull[int](), true},
+		{staleNull, NewNull[int](), true},
+		{NewNullable(0), NewNull[int](), false},
+		{NewNullable(1), NewNullable(1), true},
+		{NewNullable(1), NewNullable(2), false},
+	}
+	for _, tc := range tests {
+		if got := Equal(tc.a, tc.b); got != tc.want {
+			t.Errorf("Equal(%+v, %+v): Expected %v, got %v", tc.a, tc.b, tc.want, got)
+		}
+		if got := Equal(tc.b, tc.a); got != tc.want {
+			t.Errorf("Equal(%+v, %+v): Expected %v, got %v", tc.b, tc.a, tc.want, got)
+		}
+	}
+}
+
+func TestEqualFunc(t *testing.T) {
+	a := NewNullable([]string{"a", "b"})
+	if !EqualFunc(a, NewNullable([]string{"a", "b"}), slices.Equal[[]string]) {
+		t.Error("Expected equal slices")
+	}
+	if EqualFunc(a, NewNullable([]string{"a"}), slices.Equal[[]string]) {
+		t.Error("Expected different slices")
+	}
+	if EqualFunc(a, NewNull[[]string](), slices.Equal[[]string]) {
+		t.Error("Expected valid and null to differ")
+	}
+
+	when := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	local := NewNullable(when.In(time.FixedZone("CET", 3600)))
+	if !EqualFunc(NewNullable(when), local, time.Time.Equal) {
+		t.Error("Expected the same instant to be equal")
+	}
+	called := false
+	EqualFunc(NewNull[time.Time](), NewNull[time.Time](), func(x, y time.Time) bool { called = true; return false })
+	if called {
+		t.Error("Expected eq not to be called for nulls")
+	}
+}
+
+func TestCompare(t *testing.T) {
+	null := NewNull[int]()
+	tests := []struct {
+		a, b      Nullable[int]
+		nullsLast bool
+		want      int
+	}{
+		{null, null, false, 0},
+		{null, null, true, 0},
+		{null, NewNullable(1), false, -1},
+		{null, NewNullable(1), true, 1},
+		{NewNullable(1), null, false, 1},
+		{NewNullable(1), null, true, -1},
+		{NewNullable(1), NewNullable(2), true, -1},
+		{NewNullable(2), NewNullable(2), false, 0},
+	}
+	for _, tc := range tests {
+		if got := Compare(tc.a, tc.b, tc.nullsLast); got != tc.want {
+			t.Errorf("Compare(%v, %v, %v): Expected %d, got %d", tc.a, tc.b, tc.nullsLast, tc.want, got)
+		}
+	}
+
+	names := []Nullable[string]{NewNullable("b"), NewNull[string](), NewNullable("a")}
+	slices.SortFunc(names, func(x, y Nullable[string]) int { return Compare(x, y, true) })
+	if names[0] != NewNullable("a") || names[1] != NewNullable("b") || names[2].Valid {
+		t.Errorf("Expected [a b null], got %v", names)
+	}
+	slices.SortFunc(names, func(x, y Nullable[string]) int { return Compare(x, y, false) })
+	if names[0].Valid || names[1] != NewNullable("a") {
+		t.Errorf("Expected [null a b], got %v", names)
+	}
+}
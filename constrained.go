@@ -0,0 +1,168 @@
+package nullable
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Number is the set of types accepted by the constrained numeric wrappers.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// RangeError reports a number outside the range allowed by a constrained
+// wrapper type such as NonNegative or Percentage.
+type RangeError struct {
+	Kind   string // "non-negative number", "positive number" or "percentage"
+	Value  any
+	Reason string
+}
+
+func (e *RangeError) Error() string {
+	return fmt.Sprintf("nullable: invalid %s %v: %s", e.Kind, e.Value, e.Reason)
+}
+
+// NonNegative is a nullable number that is zero or greater, such as a
+// quantity or a balance. NewNonNegative, Scan and the decoding methods
+// reject negative values and NaN.
+type NonNegative[T Number] struct {
+	Nullable[T]
+}
+
+// NewNonNegative validates v and returns a valid NonNegative, or a
+// *RangeError.
+func NewNonNegative[T Number](v T) (NonNegative[T], error) {
+	if err := validateNonNegative(v); err != nil {
+		return NonNegative[T]{}, err
+	}
+	return NonNegative[T]{NewNullable(v)}, nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (n *NonNegative[T]) UnmarshalJSON(data []byte) error {
+	return unmarshalConstrained(&n.Nullable, data, validateNonNegative[T])
+}
+
+// Scan implements the sql.Scanner interface.
+func (n *NonNegative[T]) Scan(value any) error {
+	return scanConstrained(&n.Nullable, value, validateNonNegative[T])
+}
+
+func (n NonNegative[T]) validate() error {
+	return validateIfValid(n.Nullable, validateNonNegative[T])
+}
+
+func validateNonNegative[T Number](v T) error {
+	if !(v >= 0) {
+		return &RangeError{Kind: "non-negative number", Value: v, Reason: "must be >= 0"}
+	}
+	return nil
+}
+
+// Positive is a nullable number greater than zero, such as a page size or
+// a unit price. NewPositive, Scan and the decoding methods reject zero,
+// negative values and NaN.
+type Positive[T Number] struct {
+	Nullable[T]
+}
+
+// NewPositive validates v and returns a valid Positive, or a *RangeError.
+func NewPositive[T Number](v T) (Positive[T], error) {
+	if err := validatePositive(v); err != nil {
+		return Positive[T]{}, err
+	}
+	return Positive[T]{NewNullable(v)}, nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (n *Positive[T]) UnmarshalJSON(data []byte) error {
+	return unmarshalConstrained(&n.Nullable, data, validatePositive[T])
+}
+
+// Scan implements the sql.Scanner interface.
+func (n *Positive[T]) Scan(value any) error {
+	return scanConstrained(&n.Nullable, value, validatePositive[T])
+}
+
+func (n Positive[T]) validate() error {
+	return validateIfValid(n.Nullable, validatePositive[T])
+}
+
+func validatePositive[T Number](v T) error {
+	if !(v > 0) {
+		return &RangeError{Kind: "positive number", Value: v, Reason: "must be > 0"}
+	}
+	return nil
+}
+
+// Percentage is a nullable number from 0 to 100 inclusive. NewPercentage,
+// Scan and the decoding methods reject values outside that range and NaN.
+type Percentage[T Number] struct {
+	Nullable[T]
+}
+
+// NewPercentage validates v and returns a valid Percentage, or a
+// *RangeError.
+func NewPercentage[T Number](v T) (Percentage[T], error) {
+	if err := validatePercentage(v); err != nil {
+		return Percentage[T]{}, err
+	}
+	return Percentage[T]{NewNullable(v)}, nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (n *Percentage[T]) UnmarshalJSON(data []byte) error {
+	return unmarshalConstrained(&n.Nullable, data, validatePercentage[T])
+}
+
+// Scan implements the sql.Scanner interface.
+func (n *Percentage[T]) Scan(value any) error {
+	return scanConstrained(&n.Nullable, value, validatePercentage[T])
+}
+
+func (n Percentage[T]) validate() error {
+	return validateIfValid(n.Nullable, validatePercentage[T])
+}
+
+func validatePercentage[T Number](v T) error {
+	if !(v >= 0 && v <= 100) {
+		return &RangeError{Kind: "percentage", Value: v, Reason: "must be between 0 and 100"}
+	}
+	return nil
+}
+
+// unmarshalConstrained decodes data into n, leaving n unchanged when the
+// value fails validate.
+func unmarshalConstrained[T Number](n *Nullable[T], data []byte, validate func(T) error) error {
+	if isNull(data) {
+		n.V, n.Valid = *new(T), false
+		return nil
+	}
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if err := validate(v); err != nil {
+		return err
+	}
+	n.V, n.Valid = v, true
+	return nil
+}
+
+// scanConstrained scans value into n, leaving n unchanged when the value
+// fails validate.
+func scanConstrained[T Number](n *Nullable[T], value any, validate func(T) error) error {
+	var scanned Nullable[T]
+	if err := scanned.Scan(value); err != nil {
+		return err
+	}
+	if scanned.Valid {
+		if err := validate(scanned.V); err != nil {
+			return err
+		}
+	}
+	*n = scanned
+	return nil
+}
@@ -0,0 +1,108 @@
+package nullable
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestNewConstrained(t *testing.T) {
+	if n, err := NewNonNegative(0); err != nil || !n.Valid || n.V != 0 {
+		t.Errorf("Expected 0, got %+v (%v)", n, err)
+	}
+	if p, err := NewPositive(2.5); err != nil || p.V != 2.5 {
+		t.Errorf("Expected 2.5, got %+v (%v)", p, err)
+	}
+	if p, err := NewPercentage[uint8](100); err != nil || p.V != 100 {
+		t.Errorf("Expected 100, got %+v (%v)", p, err)
+	}
+
+	tests := []struct {
+		err    error
+		reason string
+	}{
+		{second(NewNonNegative(-1)), "must be >= 0"},
+		{second(NewNonNegative(math.NaN())), "must be >= 0"},
+		{second(NewPositive(0)), "must be > 0"},
+		{second(NewPositive(-0.5)), "must be > 0"},
+		{second(NewPercentage(100.5)), "must be between 0 and 100"},
+		{second(NewPercentage(-1)), "must be between 0 and 100"},
+	}
+	for _, tc := range tests {
+		var re *RangeError
+		if !errors.As(tc.err, &re) || re.Reason != tc.reason {
+			t.Errorf("Expected reason %q, got %v", tc.reason, tc.err)
+		}
+	}
+
+	_, err := NewPercentage(120)
+	if want := "nullable: invalid percentage 120: must be between 0 and 100"; err == nil || err.Error() != want {
+		t.Errorf("Expected %s, got %v", want, err)
+	}
+}
+
+func second[T any](_ T, err error) error {
+	return err
+}
+
+func TestConstrainedJSON(t *testing.T) {
+	type order struct {
+		Quantity NonNegative[int]    `json:"quantity"`
+		Price    Positive[float64]   `json:"price"`
+		Discount Percentage[float64] `json:"discount"`
+	}
+	var o order
+	if err := json.Unmarshal([]byte(`{"quantity":3,"price":9.99,"discount":null}`), &o); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if o.Quantity.V != 3 || o.Price.V != 9.99 || o.Discount.Valid {
+		t.Errorf("Expected 3, 9.99 and null, got %+v", o)
+	}
+	data, _ := json.Marshal(o)
+	if want := `{"quantity":3,"price":9.99,"discount":null}`; string(data) != want {
+		t.Errorf("Expected %s, got %s", want, data)
+	}
+
+	for _, in := range []string{
+		`{"quantity":-1}`,
+		`{"price":0}`,
+		`{"discount":150}`,
+	} {
+		var re *RangeError
+		if err := json.Unmarshal([]byte(in), &o); !errors.As(err, &re) {
+			t.Errorf("%s: Expected *RangeError, got %v", in, err)
+		}
+	}
+	if o.Quantity.V != 3 || o.Price.V != 9.99 {
+		t.Errorf("Expected rejected values to leave fields unchanged, got %+v", o)
+	}
+	if err := json.Unmarshal([]byte(`{"quantity":"3"}`), &o); err == nil {
+		t.Error("Expected error for string quantity")
+	}
+}
+
+func TestConstrainedScan(t *testing.T) {
+	var q NonNegative[int64]
+	if err := q.Scan(int64(5)); err != nil || q.V != 5 {
+		t.Errorf("Expected 5, got %+v (%v)", q, err)
+	}
+	var re *RangeError
+	if err := q.Scan(int64(-5)); !errors.As(err, &re) {
+		t.Errorf("Expected *RangeError, got %v", err)
+	}
+	if !q.Valid || q.V != 5 {
+		t.Errorf("Expected rejected scan to leave 5, got %+v", q)
+	}
+	if err := q.Scan(nil); err != nil || q.Valid {
+		t.Errorf("Expected null, got %+v (%v)", q, err)
+	}
+
+	var p Percentage[float64]
+	if err := p.Scan(float64(101)); !errors.As(err, &re) {
+		t.Errorf("Expected *RangeError, got %v", err)
+	}
+	if err := p.Scan(float64(12.5)); err != nil || p.V != 12.5 {
+		t.Errorf("Expected 12.5, got %+v (%v)", p, err)
+	}
+}
@@ -0,0 +1,26 @@
+package nullable
+
+import "context"
+
+// FromContext returns the value stored in ctx under key as a Nullable. It is
+// valid if ctx holds a T under key, or a valid Nullable[T] stored by
+// WithValue; it is null if the key is missing, holds another type, or holds
+// a null stored by WithValue.
+//
+//	tenant := nullable.FromContext[string](r.Context(), tenantKey{})
+func FromContext[T any](ctx context.Context, key any) Nullable[T] {
+	switch v := ctx.Value(key).(type) {
+	case T:
+		return NewNullable(v)
+	case Nullable[T]:
+		return v
+	}
+	return NewNull[T]()
+}
+
+// WithValue returns a copy of ctx in which key is associated with n. A null
+// n hides any value for key in the parent context, so FromContext reports
+// null for it.
+func WithValue[T any](ctx context.Context, key any, n Nullable[T]) context.Context {
+	return context.WithValue(ctx, key, n)
+}
@@ -0,0 +1,35 @@
+package nullable
+
+import (
+	"context"
+	"testing"
+)
+
+type tenantKey struct{}
+
+func TestFromContext(t *testing.T) {
+	ctx := context.Background()
+	if n := FromContext[string](ctx, tenantKey{}); n.Valid {
+		t.Errorf("Expected null for missing key, got %+v", n)
+	}
+
+	ctx = context.WithValue(ctx, tenantKey{}, "acme")
+	if n := FromContext[string](ctx, tenantKey{}); !n.Valid || n.V != "acme" {
+		t.Errorf("Expected acme, got %+v", n)
+	}
+	if n := FromContext[int](ctx, tenantKey{}); n.Valid {
+		t.Errorf("Expected null for mismatched type, got %+v", n)
+	}
+}
+
+func TestWithValue(t *testing.T) {
+	ctx := WithValue(context.Background(), tenantKey{}, NewNullable("acme"))
+	if n := FromContext[string](ctx, tenantKey{}); !n.Valid || n.V != "acme" {
+		t.Errorf("Expected acme, got %+v", n)
+	}
+
+	ctx = WithValue(ctx, tenantKey{}, NewNull[string]())
+	if n := FromContext[string](ctx, tenantKey{}); n.Valid {
+		t.Errorf("Expected null to hide parent value, got %+v", n)
+	}
+}
@@ -0,0 +1,158 @@
+package nullable
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Decimal is an exact decimal number stored as its canonical text form. It
+// scans NUMERIC and DECIMAL columns from their text representation without
+// routing through float64, preserving precision for money and measurement
+// data. Use Nullable[Decimal] for nullable columns:
+//
+//	var price nullable.Nullable[nullable.Decimal]
+//	err := row.Scan(&price)
+//
+// The zero value is 0.
+type Decimal struct {
+	s string
+}
+
+var decimalRe = regexp.MustCompile(`^[+-]?(\d+(\.\d*)?|\.\d+)([eE][+-]?\d+)?$`)
+
+// ParseDecimal parses s as a decimal number such as "-123.4500" or "1e-3".
+// Trailing zeros are kept, but the text is brought into JSON number form:
+// a leading "+" and leading zeros are dropped and a bare decimal point gets
+// its digit, so ".5" becomes "0.5", "1." becomes "1" and "007" becomes "7".
+func ParseDecimal(s string) (Decimal, error) {
+	s = strings.TrimSpace(s)
+	if !decimalRe.MatchString(s) {
+		return Decimal{}, fmt.Errorf("nullable: invalid decimal %q", s)
+	}
+	return Decimal{s: canonicalDecimal(s)}, nil
+}
+
+// canonicalDecimal rewrites s, which matches decimalRe, in JSON number
+// form.
+func canonicalDecimal(s string) string {
+	sign := ""
+	switch s[0] {
+	case '-':
+		sign, s = "-", s[1:]
+	case '+':
+		s = s[1:]
+	}
+	mantissa, exp := s, ""
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		mantissa, exp = s[:i], s[i:]
+	}
+	integer, fraction, _ := strings.Cut(mantissa, ".")
+	integer = strings.TrimLeft(integer, "0")
+	if integer == "" {
+		integer = "0"
+	}
+	if fraction != "" {
+		integer += "." + fraction
+	}
+	return sign + integer + exp
+}
+
+// MustParseDecimal is like ParseDecimal but panics on error.
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// String returns the decimal in the form it was parsed or scanned.
+func (d Decimal) String() string {
+	if d.s == "" {
+		return "0"
+	}
+	return d.s
+}
+
+// Rat returns the exact value of d as a big.Rat.
+func (d Decimal) Rat() *big.Rat {
+	r, _ := new(big.Rat).SetString(d.String())
+	return r
+}
+
+// Float64 returns the nearest float64 to d and whether it is exact.
+func (d Decimal) Float64() (float64, bool) {
+	return d.Rat().Float64()
+}
+
+// Cmp compares d and other, returning -1, 0 or +1.
+func (d Decimal) Cmp(other Decimal) int {
+	return d.Rat().Cmp(other.Rat())
+}
+
+// Scan implements the sql.Scanner interface. Strings and byte slices are
+// parsed exactly; integers are formatted exactly; float64 values, which
+// have already lost precision in the driver, use their shortest
+// representation.
+func (d *Decimal) Scan(value any) error {
+	switch v := value.(type) {
+	case string:
+		return d.parse(v)
+	case []byte:
+		return d.parse(string(v))
+	case int64:
+		d.s = strconv.FormatInt(v, 10)
+		return nil
+	case float64:
+		d.s = strconv.FormatFloat(v, 'f', -1, 64)
+		return nil
+	case nil:
+		return fmt.Errorf("nullable: cannot scan NULL into Decimal; use Nullable[Decimal]")
+	default:
+		return fmt.Errorf("nullable: cannot scan %T into Decimal", value)
+	}
+}
+
+func (d *Decimal) parse(s string) error {
+	parsed, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// Value implements the driver.Valuer interface, returning the decimal text.
+func (d Decimal) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (d *Decimal) UnmarshalText(text []byte) error {
+	return d.parse(string(text))
+}
+
+// MarshalJSON implements the json.Marshaler interface, encoding d as a JSON
+// number with all of its digits.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, accepting either
+// a JSON number or a string holding a decimal.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+	return d.parse(s)
+}
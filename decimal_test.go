@@ -0,0 +1,123 @@
+package nullable
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	for in, want := range map[string]string{
+		"123.4500":                   "123.4500",
+		"+1":                         "1",
+		"-0.000000000000000000001":   "-0.000000000000000000001",
+		"12345678901234567890.12345": "12345678901234567890.12345",
+		"1e-3":                       "1e-3",
+		" 7 ":                        "7",
+		".5":                         "0.5",
+		"-.5":                        "-0.5",
+		"1.":                         "1",
+		"007":                        "7",
+		"-00.50":                     "-0.50",
+		"1.E5":                       "1E5",
+		"0":                          "0",
+	} {
+		d, err := ParseDecimal(in)
+		if err != nil {
+			t.Errorf("%q: Unexpected error: %v", in, err)
+			continue
+		}
+		if d.String() != want {
+			t.Errorf("%q: Expected %s, got %s", in, want, d)
+		}
+		data, err := json.Marshal(d)
+		if err != nil || string(data) != want {
+			t.Errorf("%q: Expected JSON %s, got %s (%v)", in, want, data, err)
+		}
+	}
+
+	for _, in := range []string{"", "NaN", "1.2.3", "abc", "1e"} {
+		if _, err := ParseDecimal(in); err == nil {
+			t.Errorf("%q: Expected error", in)
+		}
+	}
+}
+
+func TestDecimalScan(t *testing.T) {
+	var n Nullable[Decimal]
+	if err := n.Scan([]byte("98765432109876543210.0001")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !n.Valid || n.V.String() != "98765432109876543210.0001" {
+		t.Errorf("Expected exact decimal, got %+v", n)
+	}
+
+	if err := n.Scan(nil); err != nil || n.Valid {
+		t.Errorf("Expected null, got %+v (%v)", n, err)
+	}
+
+	var d Decimal
+	if err := d.Scan(int64(-42)); err != nil || d.String() != "-42" {
+		t.Errorf("Expected -42, got %s (%v)", d, err)
+	}
+	if err := d.Scan(0.1); err != nil || d.String() != "0.1" {
+		t.Errorf("Expected 0.1, got %s (%v)", d, err)
+	}
+	if err := d.Scan([]byte(".50")); err != nil || d.String() != "0.50" {
+		t.Errorf("Expected 0.50, got %s (%v)", d, err)
+	}
+	if data, err := json.Marshal(NewNullable(d)); err != nil || string(data) != "0.50" {
+		t.Errorf("Expected JSON 0.50, got %s (%v)", data, err)
+	}
+	if err := d.Scan(true); err == nil {
+		t.Error("Expected error for bool")
+	}
+	if err := d.Scan(nil); err == nil {
+		t.Error("Expected error for nil")
+	}
+
+	v, err := MustParseDecimal("1.50").Value()
+	if err != nil || v != "1.50" {
+		t.Errorf("Expected '1.50', got %v (%v)", v, err)
+	}
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	a, b := MustParseDecimal("0.1"), MustParseDecimal("0.10")
+	if a.Cmp(b) != 0 {
+		t.Error("Expected 0.1 and 0.10 to compare equal")
+	}
+	if f, exact := MustParseDecimal("0.5").Float64(); f != 0.5 || !exact {
+		t.Errorf("Expected exact 0.5, got %v %v", f, exact)
+	}
+	if (Decimal{}).String() != "0" {
+		t.Error("Expected zero value to be 0")
+	}
+}
+
+func TestDecimalJSON(t *testing.T) {
+	type price struct {
+		Amount Nullable[Decimal] `json:"amount"`
+	}
+
+	data, err := json.Marshal(price{Amount: NewNullable(MustParseDecimal("12345678901234567890.01"))})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(data) != `{"amount":12345678901234567890.01}` {
+		t.Errorf("Unexpected JSON %s", data)
+	}
+
+	var p price
+	if err := json.Unmarshal([]byte(`{"amount":"0.30"}`), &p); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !p.Amount.Valid || p.Amount.V.String() != "0.30" {
+		t.Errorf("Expected 0.30, got %+v", p.Amount)
+	}
+	if err := json.Unmarshal([]byte(`{"amount":null}`), &p); err != nil || p.Amount.Valid {
+		t.Errorf("Expected null, got %+v (%v)", p.Amount, err)
+	}
+	if err := json.Unmarshal([]byte(`{"amount":true}`), &p); err == nil {
+		t.Error("Expected error for bool")
+	}
+}
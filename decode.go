@@ -0,0 +1,287 @@
+package nullable
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/manattan/nullable/internal/nullreflect"
+)
+
+// DecodeOption configures the behavior of Unmarshal.
+type DecodeOption func(*decodeOptions)
+
+type decodeOptions struct {
+	nullAsZero     bool
+	numericStrings bool
+	useNumber      bool
+}
+
+// NullAsZero makes Unmarshal decode JSON null into a valid Nullable holding
+// the zero value, for interop with legacy services that do not distinguish
+// null from zero. A nested Nullable is made valid at every level.
+func NullAsZero() DecodeOption {
+	return func(o *decodeOptions) {
+		o.nullAsZero = true
+	}
+}
+
+// CoerceNumericStrings makes Unmarshal accept quoted numbers such as "42" for
+// Nullables of integer and float types, as sent by many third-party APIs.
+func CoerceNumericStrings() DecodeOption {
+	return func(o *decodeOptions) {
+		o.numericStrings = true
+	}
+}
+
+// UseNumber makes Unmarshal decode numbers into interface values, including
+// Nullable[any], as json.Number instead of float64, so integers beyond 2^53
+// keep their exact digits.
+func UseNumber() DecodeOption {
+	return func(o *decodeOptions) {
+		o.useNumber = true
+	}
+}
+
+// optionsUnmarshaler is implemented by *Nullable[T] to decode with options.
+type optionsUnmarshaler interface {
+	unmarshalJSONOptions(data []byte, o *decodeOptions) error
+}
+
+// Unmarshal parses the JSON-encoded data and stores the result in the value
+// pointed to by v, like json.Unmarshal, applying opts to every Nullable
+// reached through structs, pointers, slices, arrays and maps. Types with
+// their own UnmarshalJSON method, including the wrapper types of this
+// package, are decoded with that method and ignore opts, and so are JSON
+// strings decoded into types with an UnmarshalText method.
+//
+// Struct fields of type time.Time, *time.Time or Nullable[time.Time] may
+// select their encoding with a nullable struct tag, either a Go layout or a
+// named format (date, datetime, time, rfc3339, rfc3339nano, unix, unixmilli):
+//
+//	Birthday nullable.Nullable[time.Time] `json:"birthday" nullable:"layout=2006-01-02"`
+//	Created  nullable.Nullable[time.Time] `json:"created" nullable:"format=unixmilli"`
+//
+// Bool and numeric fields with the ",string" option, including Nullables,
+// accept both quoted and bare values.
+func Unmarshal(data []byte, v any, opts ...DecodeOption) error {
+	var o decodeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return &json.InvalidUnmarshalError{Type: reflect.TypeOf(v)}
+	}
+	if !json.Valid(data) {
+		// Let encoding/json produce its usual syntax error.
+		return json.Unmarshal(data, v)
+	}
+	return decodeValue(bytes.TrimSpace(data), rv.Elem(), &o)
+}
+
+func (n *Nullable[T]) unmarshalJSONOptions(data []byte, o *decodeOptions) error {
+	if isNull(data) {
+		var zero T
+		n.V, n.Valid = zero, o.nullAsZero
+		if inner, ok := any(&n.V).(optionsUnmarshaler); ok && o.nullAsZero {
+			// A nested Nullable gets the zero value too, rather than a
+			// valid outer Nullable holding null.
+			return inner.unmarshalJSONOptions(data, o)
+		}
+		return nil
+	}
+	rv := reflect.ValueOf(&n.V).Elem()
+	if o.numericStrings && data[0] == '"' && isNumericKind(rv.Kind()) {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		if err := setNumericString(rv, s); err != nil {
+			return err
+		}
+		n.Valid = true
+		return nil
+	}
+	n.Valid = true
+	return decodeValue(data, rv, o)
+}
+
+func isNull(data []byte) bool {
+	return string(data) == "null"
+}
+
+var jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+
+func decodeValue(data []byte, v reflect.Value, o *decodeOptions) error {
+	t := v.Type()
+	if nullreflect.IsNullable(t) {
+		return v.Addr().Interface().(optionsUnmarshaler).unmarshalJSONOptions(data, o)
+	}
+	if reflect.PointerTo(t).Implements(jsonUnmarshalerType) {
+		return json.Unmarshal(data, v.Addr().Interface())
+	}
+	if data[0] == '"' && reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		// Strings decode through UnmarshalText, as in encoding/json, rather
+		// than by kind: netip.Addr is a struct and UUIDs are often arrays.
+		return json.Unmarshal(data, v.Addr().Interface())
+	}
+
+	switch t.Kind() {
+	case reflect.Interface:
+		if o.useNumber && t.NumMethod() == 0 {
+			dec := json.NewDecoder(bytes.NewReader(data))
+			dec.UseNumber()
+			return dec.Decode(v.Addr().Interface())
+		}
+	case reflect.Pointer:
+		if isNull(data) {
+			v.SetZero()
+			return nil
+		}
+		if v.IsNil() {
+			v.Set(reflect.New(t.Elem()))
+		}
+		return decodeValue(data, v.Elem(), o)
+	case reflect.Struct:
+		if isNull(data) {
+			return nil
+		}
+		return decodeStruct(data, v, o)
+	case reflect.Slice:
+		if isNull(data) {
+			v.SetZero()
+			return nil
+		}
+		if t.Elem().Kind() == reflect.Uint8 {
+			break // []byte is base64 encoded
+		}
+		var elems []json.RawMessage
+		if err := json.Unmarshal(data, &elems); err != nil {
+			return err
+		}
+		s := reflect.MakeSlice(t, len(elems), len(elems))
+		for i, e := range elems {
+			if err := decodeValue(e, s.Index(i), o); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+		return nil
+	case reflect.Array:
+		if isNull(data) {
+			return nil
+		}
+		var elems []json.RawMessage
+		if err := json.Unmarshal(data, &elems); err != nil {
+			return err
+		}
+		v.SetZero()
+		for i := 0; i < v.Len() && i < len(elems); i++ {
+			if err := decodeValue(elems[i], v.Index(i), o); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			break
+		}
+		if isNull(data) {
+			v.SetZero()
+			return nil
+		}
+		var elems map[string]json.RawMessage
+		if err := json.Unmarshal(data, &elems); err != nil {
+			return err
+		}
+		if v.IsNil() {
+			v.Set(reflect.MakeMapWithSize(t, len(elems)))
+		}
+		for k, e := range elems {
+			ev := reflect.New(t.Elem()).Elem()
+			if err := decodeValue(e, ev, o); err != nil {
+				return err
+			}
+			v.SetMapIndex(reflect.ValueOf(k).Convert(t.Key()), ev)
+		}
+		return nil
+	}
+	return json.Unmarshal(data, v.Addr().Interface())
+}
+
+func decodeStruct(data []byte, v reflect.Value, o *decodeOptions) error {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return &json.UnmarshalTypeError{Value: jsonKind(data), Type: v.Type()}
+	}
+
+	fields, err := structFields(v.Type())
+	if err != nil {
+		return err
+	}
+	for _, f := range fields {
+		raw, ok := obj[f.name]
+		if !ok {
+			for k, r := range obj {
+				if strings.EqualFold(k, f.name) {
+					raw, ok = r, true
+					break
+				}
+			}
+		}
+		if !ok {
+			continue
+		}
+		fv := fieldByIndexAlloc(v, f.index)
+		var err error
+		if f.timeLayout != "" {
+			err = decodeTimeField(raw, fv, f.timeLayout, o)
+		} else if f.quoted && len(raw) > 0 && raw[0] == '"' {
+			err = decodeQuoted(raw, fv, o)
+		} else {
+			err = decodeValue(raw, fv, o)
+		}
+		if err != nil {
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &typeErr) && typeErr.Field == "" {
+				typeErr.Struct, typeErr.Field = v.Type().Name(), f.name
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeQuoted decodes a bool or number quoted by the ",string" option.
+func decodeQuoted(raw []byte, v reflect.Value, o *decodeOptions) error {
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return err
+	}
+	lit := []byte(s)
+	if !json.Valid(lit) || isNull(lit) || lit[0] == '"' || lit[0] == '[' || lit[0] == '{' {
+		return fmt.Errorf("nullable: invalid use of ,string struct tag, trying to unmarshal %s into %v", raw, v.Type())
+	}
+	return decodeValue(lit, v, o)
+}
+
+// jsonKind names the kind of JSON value in data for error messages.
+func jsonKind(data []byte) string {
+	switch data[0] {
+	case '"':
+		return "string"
+	case '[':
+		return "array"
+	case 't', 'f':
+		return "bool"
+	case 'n':
+		return "null"
+	default:
+		return "number"
+	}
+}
@@ -0,0 +1,297 @@
+package nullable
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type decodeAddress struct {
+	City Nullable[string] `json:"city"`
+}
+
+type decodeBase struct {
+	ID Nullable[int64] `json:"id"`
+}
+
+type decodeUser struct {
+	decodeBase
+	Name      Nullable[string]         `json:"name"`
+	Age       Nullable[int]            `json:"age"`
+	Nick      Nullable[string]         `json:"nick"`
+	Address   *decodeAddress           `json:"address"`
+	Tags      []Nullable[string]       `json:"tags"`
+	Scores    map[string]Nullable[int] `json:"scores"`
+	Nested    Nullable[decodeAddress]  `json:"nested"`
+	Lenient   LenientString            `json:"lenient"`
+	Skipped   Nullable[string]         `json:"-"`
+	Plain     int                      `json:"plain"`
+	Arr       [2]Nullable[int]         `json:"arr"`
+	Raw       json.RawMessage          `json:"raw"`
+	Any       any                      `json:"any"`
+	Untouched Nullable[string]         `json:"untouched"`
+}
+
+func TestUnmarshalNullAsZero(t *testing.T) {
+	data := []byte(`{
+		"id": null,
+		"name": null,
+		"AGE": 4,
+		"address": {"city": null},
+		"tags": ["a", null],
+		"scores": {"x": null, "y": 2},
+		"nested": {"city": null},
+		"lenient": "",
+		"skipped": null,
+		"plain": 1,
+		"arr": [null, 3],
+		"raw": {"k": null},
+		"any": null
+	}`)
+
+	var u decodeUser
+	if err := Unmarshal(data, &u, NullAsZero()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !u.ID.Valid || u.ID.V != 0 {
+		t.Errorf("Expected embedded id to be valid zero, got %+v", u.ID)
+	}
+	if !u.Name.Valid || u.Name.V != "" {
+		t.Errorf("Expected name to be valid zero, got %+v", u.Name)
+	}
+	if !u.Age.Valid || u.Age.V != 4 {
+		t.Errorf("Expected case-insensitive match for age, got %+v", u.Age)
+	}
+	if u.Nick.Valid || u.Untouched.Valid {
+		t.Error("Expected absent fields to stay null")
+	}
+	if u.Address == nil || !u.Address.City.Valid {
+		t.Errorf("Expected address.city to be valid zero, got %+v", u.Address)
+	}
+	if len(u.Tags) != 2 || !u.Tags[1].Valid || u.Tags[0].V != "a" {
+		t.Errorf("Expected tags to be valid, got %+v", u.Tags)
+	}
+	if !u.Scores["x"].Valid || u.Scores["y"].V != 2 {
+		t.Errorf("Expected scores to be valid, got %+v", u.Scores)
+	}
+	if !u.Nested.Valid || !u.Nested.V.City.Valid {
+		t.Errorf("Expected nested city to be valid zero, got %+v", u.Nested)
+	}
+	if u.Lenient.Valid {
+		t.Error("Expected wrapper types to keep their own decoding")
+	}
+	if u.Skipped.Valid {
+		t.Error("Expected skipped field to be ignored")
+	}
+	if !u.Arr[0].Valid || u.Arr[1].V != 3 {
+		t.Errorf("Expected arr to be valid, got %+v", u.Arr)
+	}
+	if string(u.Raw) != `{"k": null}` {
+		t.Errorf("Expected raw message to be kept, got %s", u.Raw)
+	}
+	if u.Any != nil {
+		t.Errorf("Expected any to be nil, got %v", u.Any)
+	}
+}
+
+func TestUnmarshalWithoutOptions(t *testing.T) {
+	var u decodeUser
+	if err := Unmarshal([]byte(`{"name":null}`), &u); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if u.Name.Valid {
+		t.Error("Expected name to be null")
+	}
+}
+
+// decodeUUID is a UUID array type that only implements
+// encoding.TextUnmarshaler.
+type decodeUUID [16]byte
+
+func (u *decodeUUID) UnmarshalText(text []byte) error {
+	b, err := hex.DecodeString(strings.ReplaceAll(string(text), "-", ""))
+	if err != nil || len(b) != len(u) {
+		return fmt.Errorf("invalid UUID %q", text)
+	}
+	copy(u[:], b)
+	return nil
+}
+
+func TestUnmarshalTextUnmarshalers(t *testing.T) {
+	type host struct {
+		Addr   netip.Addr           `json:"addr"`
+		ID     decodeUUID           `json:"id"`
+		Backup Nullable[netip.Addr] `json:"backup"`
+		Keys   []decodeUUID         `json:"keys"`
+	}
+	data := []byte(`{
+		"addr": "10.0.0.1",
+		"id": "00112233-4455-6677-8899-aabbccddeeff",
+		"backup": "::1",
+		"keys": ["00000000-0000-0000-0000-000000000001"]
+	}`)
+	var got host
+	if err := Unmarshal(data, &got, NullAsZero()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var want host
+	if err := json.Unmarshal(data, &want); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) || got.Addr != netip.MustParseAddr("10.0.0.1") || got.ID[15] != 0xff {
+		t.Errorf("Expected %+v, got %+v", want, got)
+	}
+
+	if err := Unmarshal([]byte(`{"id":"nope"}`), &got); err == nil {
+		t.Error("Expected error for invalid UUID")
+	}
+}
+
+func TestUnmarshalTopLevelNullable(t *testing.T) {
+	var n Nullable[int]
+	if err := Unmarshal([]byte("null"), &n, NullAsZero()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !n.Valid {
+		t.Error("Expected valid zero")
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	var u decodeUser
+	if err := Unmarshal([]byte(`{"name":`), &u, NullAsZero()); err == nil {
+		t.Error("Expected syntax error")
+	}
+	if err := Unmarshal([]byte(`{}`), u, NullAsZero()); err == nil {
+		t.Error("Expected error for non-pointer")
+	}
+	err := Unmarshal([]byte(`{"age":"x"}`), &u, NullAsZero())
+	typeErr, ok := err.(*json.UnmarshalTypeError)
+	if !ok {
+		t.Fatalf("Expected *json.UnmarshalTypeError, got %T %v", err, err)
+	}
+	if typeErr.Field != "age" {
+		t.Errorf("Expected field 'age', got %q", typeErr.Field)
+	}
+	if err := Unmarshal([]byte(`[1]`), &u, NullAsZero()); err == nil {
+		t.Error("Expected error decoding array into struct")
+	}
+}
+
+func TestUnmarshalCoerceNumericStrings(t *testing.T) {
+	var s struct {
+		Count Nullable[int]     `json:"count"`
+		Price Nullable[float64] `json:"price"`
+		Name  Nullable[string]  `json:"name"`
+		Null  Nullable[int]     `json:"null"`
+	}
+	data := []byte(`{"count":"42","price":"1.25","name":"7","null":null}`)
+	if err := Unmarshal(data, &s, CoerceNumericStrings()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !s.Count.Valid || s.Count.V != 42 {
+		t.Errorf("Expected count 42, got %+v", s.Count)
+	}
+	if !s.Price.Valid || s.Price.V != 1.25 {
+		t.Errorf("Expected price 1.25, got %+v", s.Price)
+	}
+	if s.Name.V != "7" {
+		t.Errorf("Expected name '7', got %+v", s.Name)
+	}
+	if s.Null.Valid {
+		t.Error("Expected null to stay null")
+	}
+
+	if err := Unmarshal([]byte(`{"count":"x"}`), &s, CoerceNumericStrings()); err == nil {
+		t.Error("Expected error for non-numeric string")
+	}
+	if err := json.Unmarshal([]byte(`{"count":"42"}`), &s); err == nil {
+		t.Error("Expected encoding/json to reject quoted numbers")
+	}
+}
+
+func TestJSONNumberRoundTrip(t *testing.T) {
+	for _, in := range []string{"12345678901234567890123", "1.10", "-0.0000001", "1e400"} {
+		var n Nullable[json.Number]
+		if err := json.Unmarshal([]byte(in), &n); err != nil {
+			t.Errorf("%s: Unexpected error: %v", in, err)
+			continue
+		}
+		if !n.Valid || n.V.String() != in {
+			t.Errorf("%s: Expected exact digits, got %+v", in, n)
+		}
+		data, err := json.Marshal(n)
+		if err != nil || string(data) != in {
+			t.Errorf("%s: Expected round trip, got %s (%v)", in, data, err)
+		}
+	}
+
+	var n Nullable[json.Number]
+	if err := json.Unmarshal([]byte("null"), &n); err != nil || n.Valid {
+		t.Errorf("Expected null, got %+v (%v)", n, err)
+	}
+}
+
+func TestUnmarshalUseNumber(t *testing.T) {
+	var s struct {
+		Any   Nullable[any]  `json:"any"`
+		Plain any            `json:"plain"`
+		Map   map[string]any `json:"map"`
+		Float Nullable[any]  `json:"float"`
+	}
+	data := []byte(`{"any":9007199254740993,"plain":9007199254740995,"map":{"k":18446744073709551615},"float":1.5}`)
+	if err := Unmarshal(data, &s, UseNumber()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if n, ok := s.Any.V.(json.Number); !ok || n.String() != "9007199254740993" {
+		t.Errorf("Expected exact json.Number, got %#v", s.Any.V)
+	}
+	if n, ok := s.Plain.(json.Number); !ok || n.String() != "9007199254740995" {
+		t.Errorf("Expected exact json.Number, got %#v", s.Plain)
+	}
+	if n, ok := s.Map["k"].(json.Number); !ok || n.String() != "18446744073709551615" {
+		t.Errorf("Expected exact json.Number, got %#v", s.Map["k"])
+	}
+
+	if err := Unmarshal(data, &s); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, ok := s.Any.V.(float64); !ok {
+		t.Errorf("Expected float64 without UseNumber, got %T", s.Any.V)
+	}
+}
+
+func TestUnmarshalStringOption(t *testing.T) {
+	var v quotedFields
+	data := `{"id":"9007199254740993","price":"9.5","active":"true","count":"3","plain":"7","name":"Ada","version":"1.2.3"}`
+	if err := Unmarshal([]byte(data), &v); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if v.ID.V != 9007199254740993 || v.Price.V != 9.5 || !v.Active.V || *v.Count != 3 || v.Plain != 7 {
+		t.Errorf("Unexpected values %+v", v)
+	}
+	if v.Name.V != "Ada" || v.Version.V.String() != "1.2.3" {
+		t.Errorf("Expected non-numeric fields to decode as usual, got %+v", v)
+	}
+
+	if err := Unmarshal([]byte(`{"id":null,"active":null,"count":null}`), &v); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if v.ID.Valid || v.Active.Valid || v.Count != nil {
+		t.Errorf("Expected nulls, got %+v", v)
+	}
+	if err := Unmarshal([]byte(`{"id":42,"active":true}`), &v); err != nil || v.ID.V != 42 || !v.Active.V {
+		t.Errorf("Expected bare literals to be accepted, got %+v (%v)", v, err)
+	}
+
+	for _, in := range []string{`{"id":"abc"}`, `{"id":"null"}`, `{"active":"\"true\""}`, `{"id":"1.5"}`} {
+		if err := Unmarshal([]byte(in), &v); err == nil {
+			t.Errorf("%s: Expected error", in)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package nullable
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// DecodeArray reads a JSON array from dec one element at a time, calling fn
+// with each element as a Nullable[T], so large request bodies are never held
+// in memory at once. JSON null elements are passed as null, and a null array
+// calls fn zero times. Decoding stops at the first error, including one
+// returned by fn, which is returned unchanged; decoding errors name the
+// element index.
+//
+// Settings on dec such as UseNumber and DisallowUnknownFields apply to each
+// element. When opts are given, elements are decoded with Unmarshal instead.
+func DecodeArray[T any](dec *json.Decoder, fn func(Nullable[T]) error, opts ...DecodeOption) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	switch tok {
+	case nil:
+		return nil
+	case json.Delim('['):
+	default:
+		return fmt.Errorf("nullable: expected JSON array, got %v", tok)
+	}
+
+	for i := 0; dec.More(); i++ {
+		var n Nullable[T]
+		if len(opts) == 0 {
+			err = dec.Decode(&n)
+		} else {
+			var raw json.RawMessage
+			if err = dec.Decode(&raw); err == nil {
+				err = Unmarshal(raw, &n, opts...)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("nullable: array element %d: %w", i, err)
+		}
+		if err := fn(n); err != nil {
+			return err
+		}
+	}
+
+	_, err = dec.Token() // closing bracket
+	return err
+}
@@ -0,0 +1,90 @@
+package nullable
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDecodeArray(t *testing.T) {
+	type row struct {
+		Name Nullable[string] `json:"name"`
+	}
+	dec := json.NewDecoder(strings.NewReader(`[{"name":"a"}, null, {"name":null}]`))
+	var got []Nullable[row]
+	err := DecodeArray(dec, func(n Nullable[row]) error {
+		got = append(got, n)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("Expected 3 elements, got %d", len(got))
+	}
+	if !got[0].Valid || got[0].V.Name.V != "a" {
+		t.Errorf("Expected name a, got %+v", got[0])
+	}
+	if got[1].Valid {
+		t.Errorf("Expected null element, got %+v", got[1])
+	}
+	if !got[2].Valid || got[2].V.Name.Valid {
+		t.Errorf("Expected row with null name, got %+v", got[2])
+	}
+	if _, err := dec.Token(); err == nil {
+		t.Error("Expected array to be fully consumed")
+	}
+}
+
+func TestDecodeArrayNullAndEmpty(t *testing.T) {
+	for _, input := range []string{`null`, `[]`, ` [ ] `} {
+		calls := 0
+		err := DecodeArray(json.NewDecoder(strings.NewReader(input)), func(Nullable[int]) error {
+			calls++
+			return nil
+		})
+		if err != nil || calls != 0 {
+			t.Errorf("%s: expected no calls, got %d (%v)", input, calls, err)
+		}
+	}
+}
+
+func TestDecodeArrayErrors(t *testing.T) {
+	noop := func(Nullable[int]) error { return nil }
+
+	if err := DecodeArray(json.NewDecoder(strings.NewReader(`{"a":1}`)), noop); err == nil {
+		t.Error("Expected error for object")
+	}
+	err := DecodeArray(json.NewDecoder(strings.NewReader(`[1, "two", 3]`)), noop)
+	if err == nil || !strings.Contains(err.Error(), "array element 1") {
+		t.Errorf("Expected error naming element 1, got %v", err)
+	}
+	if err := DecodeArray(json.NewDecoder(strings.NewReader(`[1, 2`)), noop); err == nil {
+		t.Error("Expected error for truncated array")
+	}
+
+	stop := errors.New("stop")
+	calls := 0
+	err = DecodeArray(json.NewDecoder(strings.NewReader(`[1, 2, 3]`)), func(Nullable[int]) error {
+		calls++
+		return stop
+	})
+	if err != stop || calls != 1 {
+		t.Errorf("Expected callback error after one call, got %v (%d calls)", err, calls)
+	}
+}
+
+func TestDecodeArrayOptions(t *testing.T) {
+	var got []Nullable[int]
+	err := DecodeArray(json.NewDecoder(strings.NewReader(`["1", null, 3]`)), func(n Nullable[int]) error {
+		got = append(got, n)
+		return nil
+	}, CoerceNumericStrings(), NullAsZero())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(got) != 3 || got[0].V != 1 || !got[1].Valid || got[2].V != 3 {
+		t.Errorf("Expected [1 0 3] all valid, got %+v", got)
+	}
+}
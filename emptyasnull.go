@@ -0,0 +1,92 @@
+package nullable
+
+import (
+	"encoding/xml"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// EmptyAsNull is a Nullable for string-like types that decodes the empty
+// string "" as null, for upstream APIs that send empty strings where they
+// mean "no value". The rule applies to every decoding Nullable supports, not
+// only JSON. A valid empty string is still encoded as "".
+type EmptyAsNull[T ~string] struct {
+	Nullable[T]
+}
+
+// LenientString is an EmptyAsNull string.
+type LenientString = EmptyAsNull[string]
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (e *EmptyAsNull[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == `""` {
+		e.V, e.Valid = "", false
+		return nil
+	}
+	return e.Nullable.UnmarshalJSON(data)
+}
+
+// setWire stores a decoded value, treating the empty string as null.
+func (e *EmptyAsNull[T]) setWire(n Nullable[T]) error {
+	if n.V == "" {
+		n.Valid = false
+	}
+	e.Nullable = n
+	return nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (e *EmptyAsNull[T]) UnmarshalText(text []byte) error {
+	return decodeWire(e.setWire, func(n *Nullable[T]) error {
+		return n.UnmarshalText(text)
+	})
+}
+
+// UnmarshalTOML implements the BurntSushi/toml Unmarshaler interface.
+func (e *EmptyAsNull[T]) UnmarshalTOML(value any) error {
+	return decodeWire(e.setWire, func(n *Nullable[T]) error {
+		return n.UnmarshalTOML(value)
+	})
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface.
+func (e *EmptyAsNull[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return decodeWire(e.setWire, func(n *Nullable[T]) error {
+		return n.UnmarshalXML(d, start)
+	})
+}
+
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface.
+func (e *EmptyAsNull[T]) UnmarshalXMLAttr(attr xml.Attr) error {
+	return decodeWire(e.setWire, func(n *Nullable[T]) error {
+		return n.UnmarshalXMLAttr(attr)
+	})
+}
+
+// UnmarshalCBOR implements the cbor.Unmarshaler interface.
+func (e *EmptyAsNull[T]) UnmarshalCBOR(data []byte) error {
+	return decodeWire(e.setWire, func(n *Nullable[T]) error {
+		return n.UnmarshalCBOR(data)
+	})
+}
+
+// DecodeMsgpack implements the msgpack.CustomDecoder interface.
+func (e *EmptyAsNull[T]) DecodeMsgpack(dec *msgpack.Decoder) error {
+	return decodeWire(e.setWire, func(n *Nullable[T]) error {
+		return n.DecodeMsgpack(dec)
+	})
+}
+
+// UnmarshalBSONValue implements the bson.ValueUnmarshaler interface.
+func (e *EmptyAsNull[T]) UnmarshalBSONValue(typ byte, data []byte) error {
+	return decodeWire(e.setWire, func(n *Nullable[T]) error {
+		return n.UnmarshalBSONValue(typ, data)
+	})
+}
+
+// GobDecode implements the gob.GobDecoder interface.
+func (e *EmptyAsNull[T]) GobDecode(data []byte) error {
+	return decodeWire(e.setWire, func(n *Nullable[T]) error {
+		return n.GobDecode(data)
+	})
+}
@@ -0,0 +1,62 @@
+package nullable
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEmptyAsNullUnmarshalJSON(t *testing.T) {
+	type status string
+	var s struct {
+		Name   LenientString       `json:"name"`
+		Nick   LenientString       `json:"nick"`
+		Status EmptyAsNull[status] `json:"status"`
+		Other  LenientString       `json:"other"`
+	}
+	if err := json.Unmarshal([]byte(`{"name":"","nick":null,"status":"active","other":" "}`), &s); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if s.Name.Valid {
+		t.Error("Expected empty name to be null")
+	}
+	if s.Nick.Valid {
+		t.Error("Expected nick to be null")
+	}
+	if !s.Status.Valid || s.Status.V != "active" {
+		t.Errorf("Expected status 'active', got %+v", s.Status)
+	}
+	if !s.Other.Valid || s.Other.V != " " {
+		t.Errorf("Expected other ' ', got %+v", s.Other)
+	}
+}
+
+func TestEmptyAsNullMarshalJSON(t *testing.T) {
+	data, err := json.Marshal(LenientString{NewNullable("")})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(data) != `""` {
+		t.Errorf("Expected \"\", got %s", data)
+	}
+}
+
+func TestEmptyAsNullCodecs(t *testing.T) {
+	type plainDoc struct {
+		A Nullable[string] `xml:"a" cbor:"a" msgpack:"a" bson:"a"`
+	}
+	type lenientDoc struct {
+		A LenientString `xml:"a" cbor:"a" msgpack:"a" bson:"a"`
+	}
+	for _, c := range wireCodecs {
+		for _, v := range []string{"", "x"} {
+			data, _ := c.marshal(plainDoc{NewNullable(v)})
+			var out lenientDoc
+			if err := c.unmarshal(data, &out); err != nil {
+				t.Fatalf("%s: Unexpected error: %v", c.name, err)
+			}
+			if out.A.Valid != (v != "") || out.A.V != v {
+				t.Errorf("%s: Expected %q to decode as valid=%v, got %q valid=%v", c.name, v, v != "", out.A.V, out.A.Valid)
+			}
+		}
+	}
+}
@@ -0,0 +1,310 @@
+package nullable
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/manattan/nullable/internal/nullreflect"
+)
+
+// EncodeOption configures the behavior of Marshal.
+type EncodeOption func(*encodeOptions)
+
+type encodeOptions struct {
+	nonFinite      NonFinitePolicy
+	zeroTimeAsNull bool
+	nulls          NullPolicy
+}
+
+// NonFinitePolicy selects how Marshal encodes NaN and infinite floats, which
+// encoding/json rejects.
+type NonFinitePolicy int
+
+const (
+	// NonFiniteError fails encoding, like encoding/json.
+	NonFiniteError NonFinitePolicy = iota
+	// NonFiniteNull encodes non-finite floats as null.
+	NonFiniteNull
+	// NonFiniteString encodes non-finite floats as the strings "NaN",
+	// "+Inf" and "-Inf", which CoerceNumericStrings decodes again.
+	NonFiniteString
+)
+
+// NonFiniteFloats sets the policy for NaN and infinite float values,
+// including those held by Nullables.
+func NonFiniteFloats(p NonFinitePolicy) EncodeOption {
+	return func(o *encodeOptions) {
+		o.nonFinite = p
+	}
+}
+
+// ZeroTimeAsNull encodes the zero time.Time as null, whether it is held
+// directly or by a valid Nullable, rather than as "0001-01-01T00:00:00Z".
+func ZeroTimeAsNull() EncodeOption {
+	return func(o *encodeOptions) {
+		o.zeroTimeAsNull = true
+	}
+}
+
+// Marshal returns the JSON encoding of v like json.Marshal, applying opts
+// and honoring nullable struct tags on time-valued fields as described for
+// Unmarshal. Unlike json.Marshal, it also honors the ",string" option on
+// Nullable bool and numeric fields, quoting valid values and leaving null
+// bare.
+func Marshal(v any, opts ...EncodeOption) ([]byte, error) {
+	var o encodeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if v == nil {
+		return []byte("null"), nil
+	}
+	return encodeValue(nil, reflect.ValueOf(v), &o)
+}
+
+var (
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	zeroerType        = reflect.TypeFor[interface{ IsZero() bool }]()
+)
+
+func encodeValue(buf []byte, v reflect.Value, o *encodeOptions) ([]byte, error) {
+	if !v.IsValid() {
+		return append(buf, "null"...), nil
+	}
+	t := v.Type()
+	if nullreflect.IsNullable(t) {
+		if !nullreflect.Valid(v) {
+			return append(buf, "null"...), nil
+		}
+		return encodeValue(buf, nullreflect.Inner(v), o)
+	}
+	if t.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return append(buf, "null"...), nil
+		}
+		return encodeValue(buf, v.Elem(), o)
+	}
+	if t == timeType && o.zeroTimeAsNull && v.Interface().(time.Time).IsZero() {
+		return append(buf, "null"...), nil
+	}
+	if t.Implements(jsonMarshalerType) || (v.CanAddr() && reflect.PointerTo(t).Implements(jsonMarshalerType)) {
+		return appendJSON(buf, v)
+	}
+
+	if t.Implements(textMarshalerType) || (v.CanAddr() && reflect.PointerTo(t).Implements(textMarshalerType)) {
+		return appendJSON(buf, v)
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return strconv.AppendBool(buf, v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.AppendInt(buf, v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.AppendUint(buf, v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return appendNonFinite(buf, f, o.nonFinite)
+		}
+		return appendFloat(buf, f, t.Bits()), nil
+	case reflect.Interface:
+		if v.IsNil() {
+			return append(buf, "null"...), nil
+		}
+		return encodeValue(buf, v.Elem(), o)
+	case reflect.Struct:
+		return encodeStruct(buf, v, o)
+	case reflect.Slice:
+		if v.IsNil() {
+			return append(buf, "null"...), nil
+		}
+		if t.Elem().Kind() == reflect.Uint8 {
+			break // []byte is base64 encoded
+		}
+		fallthrough
+	case reflect.Array:
+		buf = append(buf, '[')
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				buf = append(buf, ',')
+			}
+			var err error
+			if buf, err = encodeValue(buf, v.Index(i), o); err != nil {
+				return nil, err
+			}
+		}
+		return append(buf, ']'), nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			break
+		}
+		if v.IsNil() {
+			return append(buf, "null"...), nil
+		}
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+		buf = append(buf, '{')
+		for i, k := range keys {
+			if i > 0 {
+				buf = append(buf, ',')
+			}
+			var err error
+			if buf, err = appendJSON(buf, reflect.ValueOf(k.String())); err != nil {
+				return nil, err
+			}
+			buf = append(buf, ':')
+			if buf, err = encodeValue(buf, v.MapIndex(k), o); err != nil {
+				return nil, err
+			}
+		}
+		return append(buf, '}'), nil
+	}
+	return appendJSON(buf, v)
+}
+
+func encodeStruct(buf []byte, v reflect.Value, o *encodeOptions) ([]byte, error) {
+	fields, err := structFields(v.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	buf = append(buf, '{')
+	first := true
+	for _, f := range fields {
+		fv, ok := fieldByIndex(v, f.index)
+		if !ok || (f.omitEmpty && isEmptyValue(fv)) || (f.omitZero && isZeroValue(fv)) {
+			continue
+		}
+		if !first {
+			buf = append(buf, ',')
+		}
+		first = false
+		buf = append(buf, f.key...)
+		if f.timeLayout != "" {
+			buf = encodeTimeField(buf, fv, f.timeLayout, o)
+			continue
+		}
+		start := len(buf)
+		if buf, err = encodeValue(buf, fv, o); err != nil {
+			return nil, err
+		}
+		if f.quoted {
+			buf = quoteLiteral(buf, start)
+		}
+	}
+	return append(buf, '}'), nil
+}
+
+// quoteLiteral wraps the bool or number literal at buf[start:] in quotes
+// for the ",string" option. Null and strings, such as quoted non-finite
+// floats, are left as they are.
+func quoteLiteral(buf []byte, start int) []byte {
+	lit := buf[start:]
+	if isNull(lit) || len(lit) == 0 || lit[0] == '"' {
+		return buf
+	}
+	buf = append(buf, 0, 0)
+	copy(buf[start+1:], buf[start:len(buf)-2])
+	buf[start] = '"'
+	buf[len(buf)-1] = '"'
+	return buf
+}
+
+func appendNonFinite(buf []byte, f float64, p NonFinitePolicy) ([]byte, error) {
+	switch p {
+	case NonFiniteNull:
+		return append(buf, "null"...), nil
+	case NonFiniteString:
+		s := "NaN"
+		if math.IsInf(f, 1) {
+			s = "+Inf"
+		} else if math.IsInf(f, -1) {
+			s = "-Inf"
+		}
+		return append(append(append(buf, '"'), s...), '"'), nil
+	default:
+		return nil, &json.UnsupportedValueError{Value: reflect.ValueOf(f), Str: strconv.FormatFloat(f, 'g', -1, 64)}
+	}
+}
+
+// appendFloat formats f like encoding/json: the shortest representation,
+// in exponent form only for very small or large magnitudes.
+func appendFloat(buf []byte, f float64, bits int) []byte {
+	abs := math.Abs(f)
+	format := byte('f')
+	if abs != 0 && (bits == 64 && (abs < 1e-6 || abs >= 1e21) || bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21)) {
+		format = 'e'
+	}
+	buf = strconv.AppendFloat(buf, f, format, -1, bits)
+	if format == 'e' {
+		// Clean up e-09 to e-9.
+		n := len(buf)
+		if n >= 4 && buf[n-4] == 'e' && buf[n-3] == '-' && buf[n-2] == '0' {
+			buf[n-2] = buf[n-1]
+			buf = buf[:n-1]
+		}
+	}
+	return buf
+}
+
+// fieldByIndex returns the field of v at index, reporting false if it is
+// reached through a nil embedded pointer.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// appendJSON appends the encoding/json encoding of v. Addressable values
+// are marshaled through their address, so that pointer-receiver
+// MarshalJSON and MarshalText methods run as they do in encoding/json.
+func appendJSON(buf []byte, v reflect.Value) ([]byte, error) {
+	if v.CanAddr() {
+		v = v.Addr()
+	}
+	data, err := json.Marshal(v.Interface())
+	if err != nil {
+		return nil, err
+	}
+	return append(buf, data...), nil
+}
+
+// isEmptyValue reports whether v is empty according to the omitempty rules
+// of encoding/json.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Interface, reflect.Pointer:
+		return v.IsZero()
+	}
+	return false
+}
+
+// isZeroValue reports whether v is zero according to the omitzero rules of
+// encoding/json.
+func isZeroValue(v reflect.Value) bool {
+	if v.Type().Implements(zeroerType) {
+		if v.Kind() == reflect.Pointer && v.IsNil() {
+			return true
+		}
+		return v.Interface().(interface{ IsZero() bool }).IsZero()
+	}
+	return v.IsZero()
+}
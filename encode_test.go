@@ -0,0 +1,373 @@
+package nullable
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type encodeEvent struct {
+	Name     Nullable[string]    `json:"name"`
+	Date     Nullable[time.Time] `json:"date" nullable:"layout=2006-01-02"`
+	Human    time.Time           `json:"human" nullable:"layout=Jan 2, 2006"`
+	Created  Nullable[time.Time] `json:"created" nullable:"format=unixmilli"`
+	Updated  *time.Time          `json:"updated,omitempty" nullable:"format=unix"`
+	Default  Nullable[time.Time] `json:"default"`
+	Missing  Nullable[string]    `json:"missing,omitzero"`
+	Tags     []Nullable[int]     `json:"tags"`
+	Labels   map[string]string   `json:"labels"`
+	Ignored  string              `json:"-"`
+	internal string
+}
+
+func TestMarshalTimeLayouts(t *testing.T) {
+	ts := time.Date(2024, 3, 5, 10, 30, 0, 0, time.UTC)
+	e := encodeEvent{
+		Name:    NewNullable("launch"),
+		Date:    NewNullable(ts),
+		Human:   ts,
+		Created: NewNullable(ts),
+		Default: NewNullable(ts),
+		Tags:    []Nullable[int]{NewNullable(1), NewNull[int]()},
+		Labels:  map[string]string{"b": "2", "a": "1"},
+	}
+
+	data, err := Marshal(e)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := `{"name":"launch","date":"2024-03-05","human":"Mar 5, 2024","created":1709634600000,` +
+		`"default":"2024-03-05T10:30:00Z","tags":[1,null],"labels":{"a":"1","b":"2"}}`
+	if string(data) != want {
+		t.Errorf("Expected %s, got %s", want, data)
+	}
+
+	var decoded encodeEvent
+	if err := Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !decoded.Date.Valid || !decoded.Date.V.Equal(ts.Truncate(24*time.Hour)) {
+		t.Errorf("Unexpected date %+v", decoded.Date)
+	}
+	if !decoded.Human.Equal(ts.Truncate(24 * time.Hour)) {
+		t.Errorf("Unexpected human date %v", decoded.Human)
+	}
+	if !decoded.Created.Valid || !decoded.Created.V.Equal(ts) {
+		t.Errorf("Unexpected created %+v", decoded.Created)
+	}
+	if decoded.Updated != nil {
+		t.Errorf("Expected updated to be nil, got %v", decoded.Updated)
+	}
+}
+
+func TestMarshalNullTimeField(t *testing.T) {
+	ts := time.Unix(1700000000, 0)
+	data, err := Marshal(encodeEvent{Updated: &ts})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := `{"name":null,"date":null,"human":"Jan 1, 0001","created":null,"updated":1700000000,"default":null,"tags":null,"labels":null}`
+	if string(data) != want {
+		t.Errorf("Expected %s, got %s", want, data)
+	}
+
+	var decoded encodeEvent
+	if err := Unmarshal([]byte(`{"date":null,"updated":1700000000}`), &decoded, NullAsZero()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !decoded.Date.Valid || !decoded.Date.V.IsZero() {
+		t.Errorf("Expected valid zero date, got %+v", decoded.Date)
+	}
+	if decoded.Updated == nil || decoded.Updated.Unix() != 1700000000 {
+		t.Errorf("Unexpected updated %v", decoded.Updated)
+	}
+}
+
+func TestMarshalMatchesEncodingJSON(t *testing.T) {
+	type inner struct {
+		X Nullable[int] `json:"x"`
+	}
+	type embedded struct {
+		E string
+	}
+	type value struct {
+		embedded
+		A   Nullable[string] `json:"a,omitempty"`
+		B   *inner           `json:"b"`
+		C   []byte           `json:"c"`
+		D   any              `json:"d"`
+		Arr [2]int           `json:"arr"`
+		Z   int              `json:"z,omitempty"`
+	}
+	v := value{embedded: embedded{E: "e"}, B: &inner{X: NewNullable(1)}, C: []byte("hi"), D: 1.5}
+
+	got, err := Marshal(v)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want, _ := json.Marshal(v)
+	if string(got) != string(want) {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
+
+type ptrJSONMarshaler struct{ n int }
+
+func (m *ptrJSONMarshaler) MarshalJSON() ([]byte, error) {
+	return json.Marshal(fmt.Sprintf("json-%d", m.n))
+}
+
+type ptrTextMarshaler struct{ n int }
+
+func (m *ptrTextMarshaler) MarshalText() ([]byte, error) {
+	return fmt.Appendf(nil, "text-%d", m.n), nil
+}
+
+func TestMarshalPointerReceiverMarshalers(t *testing.T) {
+	type value struct {
+		J ptrJSONMarshaler `json:"j"`
+		T ptrTextMarshaler `json:"t"`
+	}
+	v := &value{J: ptrJSONMarshaler{1}, T: ptrTextMarshaler{2}}
+
+	got, err := Marshal(v)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want, _ := json.Marshal(v)
+	if string(got) != string(want) || string(got) != `{"j":"json-1","t":"text-2"}` {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+
+	// Without a pointer the fields are not addressable, and encoding/json
+	// ignores the methods.
+	got, _ = Marshal(*v)
+	want, _ = json.Marshal(*v)
+	if string(got) != string(want) {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
+
+func TestTimeLayoutErrors(t *testing.T) {
+	var bad struct {
+		N Nullable[int] `nullable:"layout=2006"`
+	}
+	if _, err := Marshal(bad); err == nil {
+		t.Error("Expected error for layout on non-time field")
+	}
+	var unknown struct {
+		T time.Time `nullable:"format=nope"`
+	}
+	if err := Unmarshal([]byte(`{}`), &unknown); err == nil {
+		t.Error("Expected error for unknown format")
+	}
+	var e encodeEvent
+	if err := Unmarshal([]byte(`{"date":"03/05/2024"}`), &e); err == nil {
+		t.Error("Expected parse error for mismatched layout")
+	}
+}
+
+func TestMarshalNonFiniteFloats(t *testing.T) {
+	type metrics struct {
+		Rate  Nullable[float64] `json:"rate"`
+		Ratio float32           `json:"ratio"`
+		Max   Nullable[float64] `json:"max"`
+		Min   Nullable[float64] `json:"min"`
+		Avg   Nullable[float64] `json:"avg"`
+	}
+	m := metrics{
+		Rate:  NewNullable(math.NaN()),
+		Ratio: float32(math.Inf(1)),
+		Max:   NewNullable(math.Inf(1)),
+		Min:   NewNullable(math.Inf(-1)),
+		Avg:   NewNullable(0.5),
+	}
+
+	if _, err := Marshal(m); err == nil {
+		t.Error("Expected error by default")
+	}
+	if _, err := Marshal(m, NonFiniteFloats(NonFiniteError)); err == nil {
+		t.Error("Expected error for NonFiniteError")
+	}
+
+	data, err := Marshal(m, NonFiniteFloats(NonFiniteNull))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := `{"rate":null,"ratio":null,"max":null,"min":null,"avg":0.5}`; string(data) != want {
+		t.Errorf("Expected %s, got %s", want, data)
+	}
+
+	data, err = Marshal(m, NonFiniteFloats(NonFiniteString))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := `{"rate":"NaN","ratio":"+Inf","max":"+Inf","min":"-Inf","avg":0.5}`; string(data) != want {
+		t.Errorf("Expected %s, got %s", want, data)
+	}
+
+	var decoded metrics
+	if err := Unmarshal([]byte(`{"rate":"NaN","max":"+Inf","min":"-Inf"}`), &decoded, CoerceNumericStrings()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !math.IsNaN(decoded.Rate.V) || !math.IsInf(decoded.Max.V, 1) || !math.IsInf(decoded.Min.V, -1) {
+		t.Errorf("Expected non-finite values to round trip, got %+v", decoded)
+	}
+}
+
+func TestMarshalZeroTimeAsNull(t *testing.T) {
+	type event struct {
+		At      Nullable[time.Time] `json:"at"`
+		Created time.Time           `json:"created"`
+		Day     Nullable[time.Time] `json:"day" nullable:"format=date"`
+		Done    *time.Time          `json:"done"`
+		Null    Nullable[time.Time] `json:"null"`
+	}
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	e := event{At: NewNullable(time.Time{}), Day: NewNullable(time.Time{}), Done: &time.Time{}}
+
+	data, err := Marshal(e)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := `{"at":"0001-01-01T00:00:00Z","created":"0001-01-01T00:00:00Z","day":"0001-01-01","done":"0001-01-01T00:00:00Z","null":null}`; string(data) != want {
+		t.Errorf("Expected %s, got %s", want, data)
+	}
+
+	data, err = Marshal(e, ZeroTimeAsNull())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := `{"at":null,"created":null,"day":null,"done":null,"null":null}`; string(data) != want {
+		t.Errorf("Expected %s, got %s", want, data)
+	}
+
+	e = event{At: NewNullable(ts), Created: ts}
+	data, err = Marshal(e, ZeroTimeAsNull())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := `{"at":"2024-01-02T03:04:05Z","created":"2024-01-02T03:04:05Z","day":null,"done":null,"null":null}`; string(data) != want {
+		t.Errorf("Expected %s, got %s", want, data)
+	}
+}
+
+func TestMarshalNumbersMatchEncodingJSON(t *testing.T) {
+	type level uint8
+	type row struct {
+		B   bool
+		I   int8
+		U   level
+		F32 float32
+		F64 []float64
+		N   Nullable[float64]
+		D   time.Duration
+		T   Nullable[Decimal]
+	}
+	r := row{
+		B: true, I: -128, U: 200, F32: 0.1,
+		F64: []float64{0, -0.0, 1e-7, 123456789, 1e21, 2.5e-300, 1.0 / 3},
+		N:   NewNullable(1e20), D: time.Second, T: NewNullable(MustParseDecimal("1.10")),
+	}
+	want, err := json.Marshal(r)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	got, err := Marshal(r)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
+
+func TestStructFieldsCached(t *testing.T) {
+	typ := reflect.TypeFor[encodeEvent]()
+	first, err := structFields(typ)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	second, _ := structFields(typ)
+	if &first[0] != &second[0] {
+		t.Error("Expected cached fields to be reused")
+	}
+	if string(first[0].key) != `"name":` {
+		t.Errorf(`Expected key "name":, got %s`, first[0].key)
+	}
+
+	type bad struct {
+		N int `nullable:"format=date"`
+	}
+	for range 2 {
+		if _, err := Marshal(bad{}); err == nil {
+			t.Error("Expected cached tag error")
+		}
+	}
+}
+
+func BenchmarkMarshal(b *testing.B) {
+	ts := time.Date(2024, 3, 5, 10, 30, 0, 0, time.UTC)
+	e := encodeEvent{
+		Name:    NewNullable("launch"),
+		Date:    NewNullable(ts),
+		Created: NewNullable(ts),
+		Tags:    []Nullable[int]{NewNullable(1), NewNull[int]()},
+	}
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := Marshal(e); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+type quotedFields struct {
+	ID      Nullable[int64]   `json:"id,string"`
+	Price   Nullable[float64] `json:"price,string"`
+	Active  Nullable[bool]    `json:"active,string"`
+	Count   *int              `json:"count,string"`
+	Plain   uint8             `json:"plain,string"`
+	Name    Nullable[string]  `json:"name,string"`
+	Version Nullable[Semver]  `json:"version,string"`
+}
+
+func TestMarshalStringOption(t *testing.T) {
+	count := 3
+	v := quotedFields{
+		ID:      NewNullable[int64](9007199254740993),
+		Price:   NewNullable(9.5),
+		Active:  NewNullable(false),
+		Count:   &count,
+		Plain:   7,
+		Name:    NewNullable("Ada"),
+		Version: NewNullable(MustParseSemver("1.2.3")),
+	}
+	data, err := Marshal(v)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := `{"id":"9007199254740993","price":"9.5","active":"false","count":"3","plain":"7","name":"Ada","version":"1.2.3"}`
+	if string(data) != want {
+		t.Errorf("Expected %s, got %s", want, data)
+	}
+
+	data, err = Marshal(quotedFields{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := `{"id":null,"price":null,"active":null,"count":null,"plain":"0","name":null,"version":null}`; string(data) != want {
+		t.Errorf("Expected %s, got %s", want, data)
+	}
+
+	type nonFinite struct {
+		F Nullable[float64] `json:"f,string"`
+	}
+	data, err = Marshal(nonFinite{NewNullable(math.Inf(1))}, NonFiniteFloats(NonFiniteString))
+	if err != nil || string(data) != `{"f":"+Inf"}` {
+		t.Errorf(`Expected {"f":"+Inf"}, got %s (%v)`, data, err)
+	}
+}
@@ -0,0 +1,33 @@
+package nullable
+
+import (
+	"fmt"
+	"os"
+)
+
+// FromEnv creates a Nullable from the environment variable key, which is
+// null if the variable is unset. A variable set to the empty string is
+// valid.
+func FromEnv(key string) Nullable[string] {
+	v, ok := os.LookupEnv(key)
+	return Of(v, ok)
+}
+
+// FromEnvParse is like FromEnv but converts a set variable with parse,
+// such as strconv.Atoi or time.ParseDuration:
+//
+//	port, err := nullable.FromEnvParse("PORT", strconv.Atoi)
+//
+// An unset variable yields null without calling parse. Parse errors are
+// returned with the variable name.
+func FromEnvParse[T any](key string, parse func(string) (T, error)) (Nullable[T], error) {
+	s, ok := os.LookupEnv(key)
+	if !ok {
+		return NewNull[T](), nil
+	}
+	v, err := parse(s)
+	if err != nil {
+		return NewNull[T](), fmt.Errorf("nullable: parsing $%s: %w", key, err)
+	}
+	return NewNullable(v), nil
+}
@@ -0,0 +1,52 @@
+package nullable
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("NULLABLE_TEST_SET", "value")
+	t.Setenv("NULLABLE_TEST_EMPTY", "")
+
+	if n := FromEnv("NULLABLE_TEST_SET"); !n.Valid || n.V != "value" {
+		t.Errorf("Expected valid 'value', got %+v", n)
+	}
+	if n := FromEnv("NULLABLE_TEST_EMPTY"); !n.Valid || n.V != "" {
+		t.Errorf("Expected valid empty string, got %+v", n)
+	}
+	if n := FromEnv("NULLABLE_TEST_UNSET"); n.Valid {
+		t.Error("Expected Valid to be false")
+	}
+}
+
+func TestFromEnvParse(t *testing.T) {
+	t.Setenv("NULLABLE_TEST_PORT", "8080")
+	t.Setenv("NULLABLE_TEST_TIMEOUT", "1m30s")
+	t.Setenv("NULLABLE_TEST_BAD", "eighty")
+
+	port, err := FromEnvParse("NULLABLE_TEST_PORT", strconv.Atoi)
+	if err != nil || !port.Valid || port.V != 8080 {
+		t.Errorf("Expected valid 8080, got %+v (%v)", port, err)
+	}
+	timeout, err := FromEnvParse("NULLABLE_TEST_TIMEOUT", time.ParseDuration)
+	if err != nil || !timeout.Valid || timeout.V != 90*time.Second {
+		t.Errorf("Expected valid 1m30s, got %+v (%v)", timeout, err)
+	}
+
+	called := false
+	n, err := FromEnvParse("NULLABLE_TEST_UNSET", func(s string) (int, error) { called = true; return 0, nil })
+	if err != nil || n.Valid || called {
+		t.Errorf("Expected null without parsing, got %+v (%v, called %v)", n, err, called)
+	}
+
+	n, err = FromEnvParse("NULLABLE_TEST_BAD", strconv.Atoi)
+	if n.Valid || !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("Expected syntax error, got %+v (%v)", n, err)
+	}
+	if want := `nullable: parsing $NULLABLE_TEST_BAD: strconv.Atoi: parsing "eighty": invalid syntax`; err == nil || err.Error() != want {
+		t.Errorf("Expected %q, got %v", want, err)
+	}
+}
@@ -0,0 +1,204 @@
+package nullable
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/manattan/nullable/internal/nullreflect"
+)
+
+// structField describes a JSON-visible struct field for the reflective
+// encoder and decoder.
+type structField struct {
+	name string
+	// key is the encoded object key including the colon, e.g. `"name":`.
+	key       []byte
+	index     []int
+	typ       reflect.Type
+	omitEmpty bool
+	omitZero  bool
+	tagged    bool
+	// quoted is set by the ",string" option on bool and numeric fields,
+	// including Nullables and pointers of those types.
+	quoted bool
+
+	// timeLayout is the layout from a nullable:"layout=..." or
+	// nullable:"format=..." tag, or "unix"/"unixmilli" for epoch formats.
+	timeLayout string
+}
+
+// fieldPlan is the cached result of typeFields for a struct type.
+type fieldPlan struct {
+	fields []structField
+	err    error
+}
+
+// fieldCache maps struct types to their *fieldPlan, so the reflective
+// encoder and decoder walk each type's fields and tags only once.
+var fieldCache sync.Map
+
+// structFields returns the JSON-visible fields of the struct type t,
+// computing them on first use. The result must not be modified.
+func structFields(t reflect.Type) ([]structField, error) {
+	if p, ok := fieldCache.Load(t); ok {
+		return p.(*fieldPlan).fields, p.(*fieldPlan).err
+	}
+	fields, err := typeFields(t)
+	p, _ := fieldCache.LoadOrStore(t, &fieldPlan{fields: fields, err: err})
+	return p.(*fieldPlan).fields, p.(*fieldPlan).err
+}
+
+// typeFields returns the JSON-visible fields of the struct type t following
+// encoding/json naming rules: json tag names, "-" to skip, and promotion of
+// untagged embedded structs. Of several fields with the same name the
+// shallowest wins, preferring a tagged one; remaining ties hide each other.
+func typeFields(t reflect.Type) ([]structField, error) {
+	var candidates []structField
+	if err := collectFields(t, nil, &candidates); err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string][]structField)
+	for _, f := range candidates {
+		byName[f.name] = append(byName[f.name], f)
+	}
+	fields := make([]structField, 0, len(byName))
+	for _, group := range byName {
+		if f, ok := dominantField(group); ok {
+			fields = append(fields, f)
+		}
+	}
+	slices.SortFunc(fields, func(a, b structField) int {
+		return slices.Compare(a.index, b.index)
+	})
+	for i := range fields {
+		key, err := json.Marshal(fields[i].name)
+		if err != nil {
+			return nil, err
+		}
+		fields[i].key = append(key, ':')
+	}
+	return fields, nil
+}
+
+func dominantField(group []structField) (structField, bool) {
+	minDepth := len(group[0].index)
+	for _, f := range group[1:] {
+		minDepth = min(minDepth, len(f.index))
+	}
+	var shallow []structField
+	for _, f := range group {
+		if len(f.index) == minDepth {
+			shallow = append(shallow, f)
+		}
+	}
+	if len(shallow) == 1 {
+		return shallow[0], true
+	}
+	var tagged []structField
+	for _, f := range shallow {
+		if f.tagged {
+			tagged = append(tagged, f)
+		}
+	}
+	if len(tagged) == 1 {
+		return tagged[0], true
+	}
+	return structField{}, false
+}
+
+func collectFields(t reflect.Type, index []int, fields *[]structField) error {
+	var embedded []reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if sf.Anonymous && name == "" {
+			ft := sf.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct && !nullreflect.IsNullable(ft) {
+				embedded = append(embedded, sf)
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		f := structField{
+			name:   name,
+			index:  append(append([]int(nil), index...), i),
+			typ:    sf.Type,
+			tagged: name != "",
+		}
+		if f.name == "" {
+			f.name = sf.Name
+		}
+		for _, o := range strings.Split(opts, ",") {
+			switch o {
+			case "omitempty":
+				f.omitEmpty = true
+			case "omitzero":
+				f.omitZero = true
+			case "string":
+				f.quoted = isQuotable(sf.Type)
+			}
+		}
+		if err := parseNullableTag(sf.Tag.Get("nullable"), &f); err != nil {
+			return err
+		}
+		*fields = append(*fields, f)
+	}
+
+	for _, sf := range embedded {
+		ft := sf.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if err := collectFields(ft, append(append([]int(nil), index...), sf.Index[0]), fields); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isQuotable reports whether the ",string" option applies to t: a bool or
+// numeric type without its own JSON or text marshaling, possibly wrapped in
+// Nullables and pointers.
+func isQuotable(t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer || nullreflect.IsNullable(t) {
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		} else {
+			t = nullreflect.Inner(reflect.New(t).Elem()).Type()
+		}
+	}
+	pt := reflect.PointerTo(t)
+	if pt.Implements(jsonMarshalerType) || pt.Implements(textMarshalerType) {
+		return false
+	}
+	return t.Kind() == reflect.Bool || isNumericKind(t.Kind())
+}
+
+// fieldByIndexAlloc returns the field of v at index, allocating nil embedded
+// struct pointers along the way.
+func fieldByIndexAlloc(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
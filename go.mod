@@ -1,3 +1,63 @@
 module github.com/manattan/nullable
 
 go 1.24.3
+
+require (
+	dario.cat/mergo v1.0.2
+	github.com/99designs/gqlgen v0.17.78
+	github.com/BurntSushi/toml v1.6.0
+	github.com/brianvoe/gofakeit/v7 v7.17.1
+	github.com/fxamacker/cbor/v2 v2.9.4
+	github.com/go-sql-driver/mysql v1.9.3
+	github.com/golang-jwt/jwt/v5 v5.2.2
+	github.com/gorilla/securecookie v1.1.2
+	github.com/grpc-ecosystem/grpc-gateway/v2 v2.22.0
+	github.com/hamba/avro/v2 v2.29.0
+	github.com/jackc/pgx/v5 v5.7.5
+	github.com/neo4j/neo4j-go-driver/v5 v5.28.5
+	github.com/oklog/ulid/v2 v2.1.1
+	github.com/pelletier/go-toml/v2 v2.4.3
+	github.com/rs/zerolog v1.33.0
+	github.com/sirupsen/logrus v1.9.3
+	github.com/vmihailenco/msgpack/v5 v5.4.1
+	github.com/xuri/excelize/v2 v2.9.1
+	go.mongodb.org/mongo-driver/v2 v2.8.2
+	go.temporal.io/api v1.53.0
+	go.temporal.io/sdk v1.37.0
+	golang.org/x/mod v0.26.0
+	golang.org/x/text v0.27.0
+	golang.org/x/tools v0.35.0
+	google.golang.org/protobuf v1.36.10
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	filippo.io/edwards25519 v1.1.0 // indirect
+	github.com/go-viper/mapstructure/v2 v2.4.0 // indirect
+	github.com/gogo/protobuf v1.3.2 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/jackc/pgpassfile v1.0.0 // indirect
+	github.com/jackc/pgservicefile v0.0.0-20240606120523-5a60cdf6a761 // indirect
+	github.com/jackc/puddle/v2 v2.2.2 // indirect
+	github.com/json-iterator/go v1.1.12 // indirect
+	github.com/mattn/go-colorable v0.1.14 // indirect
+	github.com/mattn/go-isatty v0.0.20 // indirect
+	github.com/modern-go/concurrent v0.0.0-20180306012644-bacd9c7ef1dd // indirect
+	github.com/modern-go/reflect2 v1.0.2 // indirect
+	github.com/richardlehane/mscfb v1.0.4 // indirect
+	github.com/richardlehane/msoleps v1.0.4 // indirect
+	github.com/sosodev/duration v1.3.1 // indirect
+	github.com/tiendc/go-deepcopy v1.6.0 // indirect
+	github.com/vektah/gqlparser/v2 v2.5.30 // indirect
+	github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
+	github.com/x448/float16 v0.8.4 // indirect
+	github.com/xuri/efp v0.0.1 // indirect
+	github.com/xuri/nfp v0.0.1 // indirect
+	golang.org/x/crypto v0.40.0 // indirect
+	golang.org/x/net v0.42.0 // indirect
+	golang.org/x/sync v0.16.0 // indirect
+	golang.org/x/sys v0.34.0 // indirect
+	google.golang.org/genproto/googleapis/api v0.0.0-20240827150818-7e3bb234dfed // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240827150818-7e3bb234dfed // indirect
+	google.golang.org/grpc v1.67.1 // indirect
+)
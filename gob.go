@@ -0,0 +1,112 @@
+package nullable
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+)
+
+// GobEncode implements the gob.GobEncoder interface: a validity byte
+// followed, for valid values, by the gob encoding of the value. It keeps
+// the wire format independent of the embedded sql.Null, so structs with
+// Nullable fields survive gob round trips.
+func (n Nullable[T]) GobEncode() ([]byte, error) {
+	if !n.Valid {
+		return []byte{0}, nil
+	}
+	buf := bytes.NewBuffer([]byte{1})
+	if err := gob.NewEncoder(buf).Encode(&n.V); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements the gob.GobDecoder interface, decoding the output
+// of GobEncode. On error n is left unchanged.
+func (n *Nullable[T]) GobDecode(data []byte) error {
+	if len(data) == 0 {
+		return errors.New("nullable: empty gob data")
+	}
+	switch data[0] {
+	case 0:
+		if len(data) > 1 {
+			return errors.New("nullable: trailing data after gob null")
+		}
+		n.Reset()
+		return nil
+	case 1:
+	default:
+		return fmt.Errorf("nullable: invalid gob validity byte %d", data[0])
+	}
+
+	var v T
+	if err := gob.NewDecoder(bytes.NewReader(data[1:])).Decode(&v); err != nil {
+		return err
+	}
+	n.V, n.Valid = v, true
+	return nil
+}
+
+// GobEncode implements the gob.GobEncoder interface. An absent value
+// encodes as no data, and a present one like Nullable.GobEncode.
+func (o Omittable[T]) GobEncode() ([]byte, error) {
+	if !o.present {
+		return []byte{}, nil
+	}
+	return o.Nullable.GobEncode()
+}
+
+// GobDecode implements the gob.GobDecoder interface, decoding the output
+// of GobEncode.
+func (o *Omittable[T]) GobDecode(data []byte) error {
+	if len(data) == 0 {
+		o.Reset()
+		return nil
+	}
+	if err := o.Nullable.GobDecode(data); err != nil {
+		return err
+	}
+	o.present = true
+	return nil
+}
+
+// GobDecode implements the gob.GobDecoder interface like
+// Nullable.GobDecode, rejecting invalid addresses.
+func (e *Email) GobDecode(data []byte) error {
+	return decodeChecked(&e.Nullable, validateEmail, func(dst *Nullable[string]) error {
+		return dst.GobDecode(data)
+	})
+}
+
+// GobDecode implements the gob.GobDecoder interface like
+// Nullable.GobDecode, rejecting numbers that are not E.164.
+func (p *Phone) GobDecode(data []byte) error {
+	return decodeChecked(&p.Nullable, validatePhone, func(dst *Nullable[string]) error {
+		return dst.GobDecode(data)
+	})
+}
+
+// GobDecode implements the gob.GobDecoder interface like
+// Nullable.GobDecode, rejecting negative values.
+func (n *NonNegative[T]) GobDecode(data []byte) error {
+	return decodeChecked(&n.Nullable, validateNonNegative[T], func(dst *Nullable[T]) error {
+		return dst.GobDecode(data)
+	})
+}
+
+// GobDecode implements the gob.GobDecoder interface like
+// Nullable.GobDecode, rejecting values that are not positive.
+func (n *Positive[T]) GobDecode(data []byte) error {
+	return decodeChecked(&n.Nullable, validatePositive[T], func(dst *Nullable[T]) error {
+		return dst.GobDecode(data)
+	})
+}
+
+// GobDecode implements the gob.GobDecoder interface like
+// Nullable.GobDecode, rejecting values outside 0 to 100.
+func (n *Percentage[T]) GobDecode(data []byte) error {
+	return decodeChecked(&n.Nullable, validatePercentage[T], func(dst *Nullable[T]) error {
+		return dst.GobDecode(data)
+	})
+}
@@ -0,0 +1,132 @@
+package nullable
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var (
+	_ gob.GobEncoder = Nullable[int]{}
+	_ gob.GobDecoder = (*Nullable[int])(nil)
+)
+
+type gobSession struct {
+	User    string
+	Age     Nullable[int]
+	Email   Nullable[string]
+	Seen    Nullable[time.Time]
+	Attrs   Nullable[map[string]int]
+	Profile Nullable[gobProfile]
+	Patch   Omittable[string]
+	Cleared Omittable[string]
+}
+
+type gobProfile struct {
+	Name string
+	Tags []string
+}
+
+func gobRoundTrip(t *testing.T, in, out any) {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := gob.NewDecoder(&buf).Decode(out); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestGobRoundTrip(t *testing.T) {
+	seen := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	in := gobSession{
+		User:    "ada",
+		Age:     NewNullable(0),
+		Email:   NewNull[string](),
+		Seen:    NewNullable(seen),
+		Attrs:   NewNullable(map[string]int{"a": 1}),
+		Profile: NewNullable(gobProfile{Name: "Ada", Tags: []string{"x"}}),
+		Patch:   NewOmittable("new"),
+		Cleared: NewOmittableNull[string](),
+	}
+	var out gobSession
+	gobRoundTrip(t, in, &out)
+	if !out.Seen.V.Equal(seen) {
+		t.Errorf("Expected %v, got %v", seen, out.Seen.V)
+	}
+	out.Seen = in.Seen
+	if !reflect.DeepEqual(in, out) {
+		t.Errorf("Expected %+v, got %+v", in, out)
+	}
+	if !out.Cleared.IsNull() {
+		t.Error("Expected explicit null to stay present")
+	}
+}
+
+func TestGobNested(t *testing.T) {
+	in := NewNullable(NewNullable(int8(-3)))
+	var out Nullable[Nullable[int8]]
+	gobRoundTrip(t, in, &out)
+	if out != in {
+		t.Errorf("Expected %v, got %v", in, out)
+	}
+}
+
+func TestGobDecodeErrors(t *testing.T) {
+	for _, data := range [][]byte{nil, {2}, {0, 1}, {1, 0xff}} {
+		n := NewNullable(7)
+		if err := n.GobDecode(data); err == nil {
+			t.Errorf("%v: Expected error", data)
+		}
+		if n != NewNullable(7) {
+			t.Errorf("%v: Expected value to be left unchanged, got %v", data, n)
+		}
+	}
+
+	o := NewOmittable(7)
+	if err := o.GobDecode(nil); err != nil || o.IsPresent() {
+		t.Errorf("Expected absent, got %v (%v)", o, err)
+	}
+}
+
+func TestValidatedGob(t *testing.T) {
+	type contact struct {
+		Email Email
+		Phone Phone
+		Seats Positive[int]
+	}
+	var out contact
+	gobRoundTrip(t, contact{Email: Email{NewNullable("ada@example.com")}, Seats: Positive[int]{NewNullable(2)}}, &out)
+	if out.Email.V != "ada@example.com" || out.Phone.Valid || out.Seats.V != 2 {
+		t.Errorf("Unexpected decode: %+v", out)
+	}
+
+	bad, _ := NewNullable("nope").GobEncode()
+	var fe *FormatError
+	if err := out.Email.GobDecode(bad); !errors.As(err, &fe) || out.Email.V != "ada@example.com" {
+		t.Errorf("Expected *FormatError and unchanged value, got %v (%v)", out.Email, err)
+	}
+	if err := out.Phone.GobDecode(bad); !errors.As(err, &fe) {
+		t.Errorf("Expected *FormatError, got %v", err)
+	}
+
+	negative, _ := NewNullable(-1).GobEncode()
+	tests := []struct {
+		name string
+		dst  gob.GobDecoder
+	}{
+		{"non-negative", new(NonNegative[int])},
+		{"positive", new(Positive[int])},
+		{"percentage", new(Percentage[int])},
+	}
+	for _, tt := range tests {
+		var re *RangeError
+		if err := tt.dst.GobDecode(negative); !errors.As(err, &re) {
+			t.Errorf("%s: Expected *RangeError, got %v", tt.name, err)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package nullable
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// graphQLType is implemented by values usable as graph-gophers/graphql-go
+// scalars.
+type graphQLType interface {
+	ImplementsGraphQLType(name string) bool
+}
+
+// graphQLUnmarshaler is implemented by graph-gophers/graphql-go input
+// scalars.
+type graphQLUnmarshaler interface {
+	UnmarshalGraphQL(input any) error
+}
+
+// ImplementsGraphQLType reports whether n can represent the GraphQL scalar
+// name in graph-gophers/graphql-go schemas: String or ID for strings, Int
+// for integers, Float for floats, Boolean for bools and Time for time.Time.
+// Values implementing ImplementsGraphQLType themselves decide for their
+// Nullable. Outputs are encoded with MarshalJSON, so a null Nullable
+// resolves to null; graphql-go still requires resolvers of nullable fields
+// to return a pointer, such as *Nullable[string].
+func (n Nullable[T]) ImplementsGraphQLType(name string) bool {
+	if g, ok := any(&n.V).(graphQLType); ok {
+		return g.ImplementsGraphQLType(name)
+	}
+	if _, ok := any(n.V).(time.Time); ok {
+		return name == "Time"
+	}
+	switch reflect.TypeFor[T]().Kind() {
+	case reflect.String:
+		return name == "String" || name == "ID"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return name == "Int"
+	case reflect.Float32, reflect.Float64:
+		return name == "Float"
+	case reflect.Bool:
+		return name == "Boolean"
+	}
+	return false
+}
+
+// UnmarshalGraphQL implements the graph-gophers/graphql-go Unmarshaler
+// interface, decoding null inputs as null. Int inputs, which graphql-go
+// passes as int32, are converted to the integer or float type of T.
+func (n *Nullable[T]) UnmarshalGraphQL(input any) error {
+	if input == nil {
+		var zero T
+		n.V, n.Valid = zero, false
+		return nil
+	}
+	if u, ok := any(&n.V).(graphQLUnmarshaler); ok {
+		if err := u.UnmarshalGraphQL(input); err != nil {
+			return err
+		}
+		n.Valid = true
+		return nil
+	}
+
+	dst := reflect.ValueOf(&n.V).Elem()
+	src := reflect.ValueOf(input)
+	switch {
+	case src.Type().AssignableTo(dst.Type()):
+		dst.Set(src)
+	case isNumericKind(src.Kind()) && isNumericKind(dst.Kind()):
+		if err := convertNumber(src, dst); err != nil {
+			return err
+		}
+	default:
+		// Strings for time.Time and other types decode like JSON.
+		data, err := json.Marshal(input)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(data, &n.V); err != nil {
+			return fmt.Errorf("nullable: cannot unmarshal GraphQL %T into %s: %w", input, dst.Type(), err)
+		}
+	}
+	n.Valid = true
+	return nil
+}
+
+// Nullable marks Nullable as a graph-gophers/graphql-go NullUnmarshaller,
+// so it can be used for nullable input arguments without a pointer.
+func (n *Nullable[T]) Nullable() {}
+
+// convertNumber stores the number src in dst, rejecting values that do not
+// fit.
+func convertNumber(src, dst reflect.Value) error {
+	overflow := false
+	switch dst.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if !src.CanInt() {
+			return fmt.Errorf("nullable: cannot unmarshal GraphQL %s into %s", src.Type(), dst.Type())
+		}
+		overflow = dst.OverflowInt(src.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if !src.CanInt() || src.Int() < 0 {
+			return fmt.Errorf("nullable: cannot unmarshal GraphQL %v into %s", src, dst.Type())
+		}
+		overflow = dst.OverflowUint(uint64(src.Int()))
+	}
+	if overflow {
+		return fmt.Errorf("nullable: GraphQL value %v overflows %s", src, dst.Type())
+	}
+	dst.Set(src.Convert(dst.Type()))
+	return nil
+}
+
+// UnmarshalGraphQL implements the graph-gophers/graphql-go Unmarshaler
+// interface like Nullable.UnmarshalGraphQL, rejecting invalid addresses.
+func (e *Email) UnmarshalGraphQL(input any) error {
+	return decodeChecked(&e.Nullable, validateEmail, func(dst *Nullable[string]) error {
+		return dst.UnmarshalGraphQL(input)
+	})
+}
+
+// UnmarshalGraphQL implements the graph-gophers/graphql-go Unmarshaler
+// interface like Nullable.UnmarshalGraphQL, rejecting
+// numbers that are not E.164.
+func (p *Phone) UnmarshalGraphQL(input any) error {
+	return decodeChecked(&p.Nullable, validatePhone, func(dst *Nullable[string]) error {
+		return dst.UnmarshalGraphQL(input)
+	})
+}
+
+// UnmarshalGraphQL implements the graph-gophers/graphql-go Unmarshaler
+// interface like Nullable.UnmarshalGraphQL, rejecting negative values.
+func (n *NonNegative[T]) UnmarshalGraphQL(input any) error {
+	return decodeChecked(&n.Nullable, validateNonNegative[T], func(dst *Nullable[T]) error {
+		return dst.UnmarshalGraphQL(input)
+	})
+}
+
+// UnmarshalGraphQL implements the graph-gophers/graphql-go Unmarshaler
+// interface like Nullable.UnmarshalGraphQL, rejecting
+// values that are not positive.
+func (n *Positive[T]) UnmarshalGraphQL(input any) error {
+	return decodeChecked(&n.Nullable, validatePositive[T], func(dst *Nullable[T]) error {
+		return dst.UnmarshalGraphQL(input)
+	})
+}
+
+// UnmarshalGraphQL implements the graph-gophers/graphql-go Unmarshaler
+// interface like Nullable.UnmarshalGraphQL, rejecting
+// values outside 0 to 100.
+func (n *Percentage[T]) UnmarshalGraphQL(input any) error {
+	return decodeChecked(&n.Nullable, validatePercentage[T], func(dst *Nullable[T]) error {
+		return dst.UnmarshalGraphQL(input)
+	})
+}
+
+// UnmarshalGraphQL implements the graph-gophers/graphql-go Unmarshaler
+// interface like Nullable.UnmarshalGraphQL, marking o present.
+func (o *Omittable[T]) UnmarshalGraphQL(input any) error {
+	if err := o.Nullable.UnmarshalGraphQL(input); err != nil {
+		return err
+	}
+	o.present = true
+	return nil
+}
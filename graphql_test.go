@@ -0,0 +1,131 @@
+package nullable
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type graphQLColor string
+
+func (graphQLColor) ImplementsGraphQLType(name string) bool { return name == "Color" }
+
+func (c *graphQLColor) UnmarshalGraphQL(input any) error {
+	*c = graphQLColor("#" + input.(string))
+	return nil
+}
+
+func TestImplementsGraphQLType(t *testing.T) {
+	tests := []struct {
+		name string
+		got  bool
+		want bool
+	}{
+		{"string String", Nullable[string]{}.ImplementsGraphQLType("String"), true},
+		{"string ID", Nullable[string]{}.ImplementsGraphQLType("ID"), true},
+		{"string Int", Nullable[string]{}.ImplementsGraphQLType("Int"), false},
+		{"int32 Int", Nullable[int32]{}.ImplementsGraphQLType("Int"), true},
+		{"int64 Int", Nullable[int64]{}.ImplementsGraphQLType("Int"), true},
+		{"float64 Float", Nullable[float64]{}.ImplementsGraphQLType("Float"), true},
+		{"bool Boolean", Nullable[bool]{}.ImplementsGraphQLType("Boolean"), true},
+		{"time Time", Nullable[time.Time]{}.ImplementsGraphQLType("Time"), true},
+		{"time String", Nullable[time.Time]{}.ImplementsGraphQLType("String"), false},
+		{"custom", Nullable[graphQLColor]{}.ImplementsGraphQLType("Color"), true},
+		{"custom String", Nullable[graphQLColor]{}.ImplementsGraphQLType("String"), false},
+		{"slice", Nullable[[]string]{}.ImplementsGraphQLType("String"), false},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: Expected %v, got %v", tt.name, tt.want, tt.got)
+		}
+	}
+}
+
+func TestUnmarshalGraphQL(t *testing.T) {
+	var s Nullable[string]
+	if err := s.UnmarshalGraphQL("hello"); err != nil || s != NewNullable("hello") {
+		t.Errorf("Expected hello, got %v (%v)", s, err)
+	}
+	if err := s.UnmarshalGraphQL(nil); err != nil || s.Valid || s.V != "" {
+		t.Errorf("Expected null, got %v (%v)", s, err)
+	}
+
+	var i Nullable[int64]
+	if err := i.UnmarshalGraphQL(int32(42)); err != nil || i != NewNullable(int64(42)) {
+		t.Errorf("Expected 42, got %v (%v)", i, err)
+	}
+	var f Nullable[float64]
+	if err := f.UnmarshalGraphQL(int32(2)); err != nil || f != NewNullable(2.0) {
+		t.Errorf("Expected 2, got %v (%v)", f, err)
+	}
+	var small Nullable[int8]
+	if err := small.UnmarshalGraphQL(int32(300)); err == nil {
+		t.Error("Expected an overflow error")
+	}
+	var u Nullable[uint]
+	if err := u.UnmarshalGraphQL(int32(-1)); err == nil {
+		t.Error("Expected an error for a negative unsigned value")
+	}
+	if err := i.UnmarshalGraphQL(1.5); err == nil {
+		t.Error("Expected an error for a float into an integer")
+	}
+
+	var ts Nullable[time.Time]
+	if err := ts.UnmarshalGraphQL("2024-05-01T10:00:00Z"); err != nil || !ts.V.Equal(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected 2024-05-01T10:00:00Z, got %v (%v)", ts, err)
+	}
+	if err := ts.UnmarshalGraphQL(true); err == nil {
+		t.Error("Expected an error for a bool into a time")
+	}
+
+	var c Nullable[graphQLColor]
+	if err := c.UnmarshalGraphQL("fff"); err != nil || c != NewNullable(graphQLColor("#fff")) {
+		t.Errorf("Expected #fff, got %v (%v)", c, err)
+	}
+}
+
+// graphql-go requires NullUnmarshaller to accept Nullable inputs without a
+// pointer.
+var _ interface {
+	graphQLUnmarshaler
+	Nullable()
+} = (*Nullable[string])(nil)
+
+func TestValidatedUnmarshalGraphQL(t *testing.T) {
+	var e Email
+	if err := e.UnmarshalGraphQL("ada@example.com"); err != nil || e.V != "ada@example.com" {
+		t.Errorf("Expected ada@example.com, got %v (%v)", e, err)
+	}
+	var fe *FormatError
+	if err := e.UnmarshalGraphQL("nope"); !errors.As(err, &fe) || e.V != "ada@example.com" {
+		t.Errorf("Expected *FormatError and unchanged value, got %v (%v)", e, err)
+	}
+	var p Phone
+	if err := p.UnmarshalGraphQL("555"); !errors.As(err, &fe) || p.Valid {
+		t.Errorf("Expected *FormatError, got %v (%v)", p, err)
+	}
+
+	tests := []struct {
+		name string
+		dst  graphQLUnmarshaler
+	}{
+		{"non-negative", new(NonNegative[int])},
+		{"positive", new(Positive[int])},
+		{"percentage", new(Percentage[int])},
+	}
+	for _, tt := range tests {
+		var re *RangeError
+		if err := tt.dst.UnmarshalGraphQL(int32(-1)); !errors.As(err, &re) {
+			t.Errorf("%s: Expected *RangeError, got %v", tt.name, err)
+		}
+	}
+	var limit Positive[int]
+	if err := limit.UnmarshalGraphQL(nil); err != nil || limit.Valid {
+		t.Errorf("Expected null, got %v (%v)", limit, err)
+	}
+
+	var o Omittable[int]
+	if err := o.UnmarshalGraphQL(nil); err != nil || !o.IsNull() {
+		t.Errorf("Expected present null, got %v (%v)", o, err)
+	}
+}
@@ -0,0 +1,198 @@
+package nullable
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net"
+)
+
+// HardwareAddr is a nullable MAC address encoded in JSON, text, TOML, XML
+// and GraphQL as its canonical colon-separated string and stored in SQL as
+// text, which Postgres accepts for MACADDR and MACADDR8 columns.
+type HardwareAddr struct {
+	Nullable[net.HardwareAddr]
+}
+
+// NewHardwareAddr creates a valid HardwareAddr.
+func NewHardwareAddr(addr net.HardwareAddr) HardwareAddr {
+	return HardwareAddr{NewNullable(addr)}
+}
+
+// ParseHardwareAddr parses s in any format accepted by net.ParseMAC. The
+// empty string yields a null HardwareAddr.
+func ParseHardwareAddr(s string) (HardwareAddr, error) {
+	if s == "" {
+		return HardwareAddr{}, nil
+	}
+	addr, err := net.ParseMAC(s)
+	if err != nil {
+		return HardwareAddr{}, err
+	}
+	return NewHardwareAddr(addr), nil
+}
+
+// String returns the canonical form of the address, or "null".
+func (h HardwareAddr) String() string {
+	if !h.Valid {
+		return "null"
+	}
+	return h.V.String()
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (h HardwareAddr) MarshalJSON() ([]byte, error) {
+	if !h.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(h.V.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (h *HardwareAddr) UnmarshalJSON(data []byte) error {
+	if isNull(data) {
+		h.V, h.Valid = nil, false
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	addr, err := net.ParseMAC(s)
+	if err != nil {
+		return err
+	}
+	h.V, h.Valid = addr, true
+	return nil
+}
+
+// Scan implements the sql.Scanner interface. Text values are parsed with
+// net.ParseMAC; byte values that are not text are taken as the raw address
+// when they have a valid MAC length (6, 8 or 20 bytes).
+func (h *HardwareAddr) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		h.V, h.Valid = nil, false
+		return nil
+	case string:
+		return h.scanText(v)
+	case []byte:
+		if err := h.scanText(string(v)); err == nil {
+			return nil
+		}
+		switch len(v) {
+		case 6, 8, 20:
+			h.V, h.Valid = append(net.HardwareAddr(nil), v...), true
+			return nil
+		}
+		return fmt.Errorf("nullable: cannot scan %d bytes into HardwareAddr", len(v))
+	default:
+		return fmt.Errorf("nullable: cannot scan %T into HardwareAddr", value)
+	}
+}
+
+func (h *HardwareAddr) scanText(s string) error {
+	addr, err := net.ParseMAC(s)
+	if err != nil {
+		return err
+	}
+	h.V, h.Valid = addr, true
+	return nil
+}
+
+// Value implements the driver.Valuer interface, returning the canonical
+// address text or nil.
+func (h HardwareAddr) Value() (driver.Value, error) {
+	if !h.Valid {
+		return nil, nil
+	}
+	return h.V.String(), nil
+}
+
+// parseText makes h null for empty text and parses s with net.ParseMAC
+// otherwise. On error h is left unchanged.
+func (h *HardwareAddr) parseText(s string) error {
+	if s == "" {
+		h.Reset()
+		return nil
+	}
+	return h.scanText(s)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface, returning
+// the canonical address text, or empty text for null.
+func (h HardwareAddr) MarshalText() ([]byte, error) {
+	if !h.Valid {
+		return []byte{}, nil
+	}
+	return []byte(h.V.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. Empty
+// text decodes as null; any other text is parsed with net.ParseMAC.
+func (h *HardwareAddr) UnmarshalText(text []byte) error {
+	return h.parseText(string(text))
+}
+
+// UnmarshalTOML implements the BurntSushi/toml Unmarshaler interface,
+// parsing TOML strings with net.ParseMAC.
+func (h *HardwareAddr) UnmarshalTOML(value any) error {
+	s, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("nullable: cannot decode TOML %T into HardwareAddr", value)
+	}
+	return h.scanText(s)
+}
+
+// MarshalXML implements the xml.Marshaler interface, encoding the
+// canonical address text, and null like Nullable.MarshalXML.
+func (h HardwareAddr) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return Convert(h.Nullable, net.HardwareAddr.String).MarshalXML(e, start)
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface, parsing the
+// element text with net.ParseMAC. An element with xsi:nil decodes as null.
+func (h *HardwareAddr) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var s Nullable[string]
+	if err := s.UnmarshalXML(d, start); err != nil {
+		return err
+	}
+	if !s.Valid {
+		h.Reset()
+		return nil
+	}
+	return h.scanText(s.V)
+}
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface, omitting the
+// attribute for null.
+func (h HardwareAddr) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return Convert(h.Nullable, net.HardwareAddr.String).MarshalXMLAttr(name)
+}
+
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface, parsing
+// the attribute with net.ParseMAC.
+func (h *HardwareAddr) UnmarshalXMLAttr(attr xml.Attr) error {
+	return h.scanText(attr.Value)
+}
+
+// ImplementsGraphQLType reports whether h can represent the GraphQL scalar
+// name, which is String, as addresses are exchanged as text.
+func (h HardwareAddr) ImplementsGraphQLType(name string) bool {
+	return name == "String"
+}
+
+// UnmarshalGraphQL implements the graph-gophers/graphql-go Unmarshaler
+// interface, decoding null inputs as null and parsing strings with
+// net.ParseMAC.
+func (h *HardwareAddr) UnmarshalGraphQL(input any) error {
+	switch v := input.(type) {
+	case nil:
+		h.Reset()
+		return nil
+	case string:
+		return h.scanText(v)
+	}
+	return fmt.Errorf("nullable: cannot unmarshal GraphQL %T into HardwareAddr", input)
+}
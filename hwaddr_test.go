@@ -0,0 +1,194 @@
+package nullable
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"net"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	gotoml "github.com/pelletier/go-toml/v2"
+)
+
+func TestHardwareAddrJSON(t *testing.T) {
+	type device struct {
+		MAC HardwareAddr `json:"mac"`
+	}
+	var d device
+	if err := json.Unmarshal([]byte(`{"mac":"00-1A-2B-3C-4D-5E"}`), &d); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !d.MAC.Valid || d.MAC.String() != "00:1a:2b:3c:4d:5e" {
+		t.Errorf("Expected 00:1a:2b:3c:4d:5e, got %v", d.MAC)
+	}
+	data, err := json.Marshal(d)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := `{"mac":"00:1a:2b:3c:4d:5e"}`; string(data) != want {
+		t.Errorf("Expected %s, got %s", want, data)
+	}
+
+	if err := json.Unmarshal([]byte(`{"mac":null}`), &d); err != nil || d.MAC.Valid {
+		t.Errorf("Expected null, got %+v (%v)", d.MAC, err)
+	}
+	data, _ = json.Marshal(d)
+	if want := `{"mac":null}`; string(data) != want {
+		t.Errorf("Expected %s, got %s", want, data)
+	}
+
+	if err := json.Unmarshal([]byte(`{"mac":"not-a-mac"}`), &d); err == nil {
+		t.Error("Expected error for invalid address")
+	}
+}
+
+func TestParseHardwareAddr(t *testing.T) {
+	h, err := ParseHardwareAddr("0000.5e00.5301")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if h.String() != "00:00:5e:00:53:01" {
+		t.Errorf("Expected 00:00:5e:00:53:01, got %s", h)
+	}
+	if h, err := ParseHardwareAddr(""); err != nil || h.Valid {
+		t.Errorf("Expected null for empty string, got %+v (%v)", h, err)
+	}
+	if _, err := ParseHardwareAddr("zz"); err == nil {
+		t.Error("Expected error for invalid address")
+	}
+}
+
+func TestHardwareAddrSQL(t *testing.T) {
+	var h HardwareAddr
+	if err := h.Scan([]byte("08:00:2b:01:02:03:04:05")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if h.String() != "08:00:2b:01:02:03:04:05" {
+		t.Errorf("Expected MACADDR8 text, got %s", h)
+	}
+
+	raw := []byte{0x00, 0x1a, 0x2b, 0x3c, 0x4d, 0x5e}
+	if err := h.Scan(raw); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if h.String() != "00:1a:2b:3c:4d:5e" {
+		t.Errorf("Expected raw bytes to scan, got %s", h)
+	}
+	raw[0] = 0xff
+	if h.V[0] != 0x00 {
+		t.Error("Expected Scan to copy raw bytes")
+	}
+
+	if err := h.Scan(nil); err != nil || h.Valid {
+		t.Errorf("Expected null, got %+v (%v)", h, err)
+	}
+	if err := h.Scan([]byte{1, 2, 3}); err == nil {
+		t.Error("Expected error for short byte value")
+	}
+	if err := h.Scan(int64(1)); err == nil {
+		t.Error("Expected error for int64")
+	}
+
+	v, err := NewHardwareAddr(net.HardwareAddr{0, 0x1a, 0x2b, 0x3c, 0x4d, 0x5e}).Value()
+	if err != nil || v != "00:1a:2b:3c:4d:5e" {
+		t.Errorf("Expected address text, got %v (%v)", v, err)
+	}
+	if v, err := (HardwareAddr{}).Value(); err != nil || v != nil {
+		t.Errorf("Expected nil, got %v (%v)", v, err)
+	}
+}
+
+func TestHardwareAddrText(t *testing.T) {
+	h, _ := ParseHardwareAddr("00-1A-2B-3C-4D-5E")
+	text, err := h.MarshalText()
+	if err != nil || string(text) != "00:1a:2b:3c:4d:5e" {
+		t.Errorf("Expected 00:1a:2b:3c:4d:5e, got %q (%v)", text, err)
+	}
+	var out HardwareAddr
+	if err := out.UnmarshalText(text); err != nil || !bytes.Equal(out.V, h.V) {
+		t.Errorf("Expected %v, got %v (%v)", h, out, err)
+	}
+	if err := out.UnmarshalText([]byte("not-a-mac")); err == nil || !bytes.Equal(out.V, h.V) {
+		t.Errorf("Expected error and unchanged value, got %v (%v)", out, err)
+	}
+	if text, _ := (HardwareAddr{}).MarshalText(); len(text) != 0 {
+		t.Errorf("Expected empty text for null, got %q", text)
+	}
+	if err := out.UnmarshalText(nil); err != nil || out.Valid {
+		t.Errorf("Expected null, got %v (%v)", out, err)
+	}
+}
+
+func TestHardwareAddrTOML(t *testing.T) {
+	type device struct {
+		MAC HardwareAddr `toml:"mac,omitempty"`
+	}
+	in := device{MAC: NewHardwareAddr(net.HardwareAddr{0, 0x1a, 0x2b, 0x3c, 0x4d, 0x5e})}
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := "mac = \"00:1a:2b:3c:4d:5e\"\n"; buf.String() != want {
+		t.Errorf("Expected %q, got %q", want, buf.String())
+	}
+
+	var out device
+	if _, err := toml.Decode(buf.String(), &out); err != nil || out.MAC.String() != in.MAC.String() {
+		t.Errorf("Expected %v, got %v (%v)", in.MAC, out.MAC, err)
+	}
+	out = device{}
+	if err := gotoml.Unmarshal(buf.Bytes(), &out); err != nil || out.MAC.String() != in.MAC.String() {
+		t.Errorf("go-toml: Expected %v, got %v (%v)", in.MAC, out.MAC, err)
+	}
+	if _, err := toml.Decode(`mac = 5`, &out); err == nil {
+		t.Error("Expected error for integer address")
+	}
+}
+
+func TestHardwareAddrXML(t *testing.T) {
+	type device struct {
+		XMLName xml.Name     `xml:"device"`
+		MAC     HardwareAddr `xml:"mac"`
+		Alt     HardwareAddr `xml:"alt,attr"`
+		Spare   HardwareAddr `xml:"spare"`
+	}
+	mac := NewHardwareAddr(net.HardwareAddr{0, 0x1a, 0x2b, 0x3c, 0x4d, 0x5e})
+	alt := NewHardwareAddr(net.HardwareAddr{0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff})
+	data, err := xml.Marshal(device{MAC: mac, Alt: alt})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := `<device alt="aa:bb:cc:dd:ee:ff"><mac>00:1a:2b:3c:4d:5e</mac>` +
+		`<spare xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:nil="true"></spare></device>`
+	if string(data) != want {
+		t.Errorf("Expected %s, got %s", want, data)
+	}
+
+	var out device
+	if err := xml.Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if out.MAC.String() != mac.String() || out.Alt.String() != alt.String() || out.Spare.Valid {
+		t.Errorf("Expected %v, %v and null, got %+v", mac, alt, out)
+	}
+	if err := xml.Unmarshal([]byte(`<device><mac>nope</mac></device>`), &out); err == nil {
+		t.Error("Expected error for invalid address")
+	}
+}
+
+func TestHardwareAddrGraphQL(t *testing.T) {
+	var h HardwareAddr
+	if !h.ImplementsGraphQLType("String") || h.ImplementsGraphQLType("Int") {
+		t.Error("Expected HardwareAddr to implement only String")
+	}
+	if err := h.UnmarshalGraphQL("00-1A-2B-3C-4D-5E"); err != nil || h.String() != "00:1a:2b:3c:4d:5e" {
+		t.Errorf("Expected 00:1a:2b:3c:4d:5e, got %v (%v)", h, err)
+	}
+	if err := h.UnmarshalGraphQL(int32(1)); err == nil || h.String() != "00:1a:2b:3c:4d:5e" {
+		t.Errorf("Expected error and unchanged value, got %v (%v)", h, err)
+	}
+	if err := h.UnmarshalGraphQL(nil); err != nil || h.Valid {
+		t.Errorf("Expected null, got %v (%v)", h, err)
+	}
+}
@@ -0,0 +1,101 @@
+package nullreflect
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// IsWrapper reports whether t is a wrapper type embedding a Nullable as its
+// first field, such as nullable.HardwareAddr.
+func IsWrapper(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t.NumField() > 0 && t.Field(0).Anonymous && IsNullable(t.Field(0).Type)
+}
+
+// Assign stores src in dst, converting between the loosely typed values
+// decoded by database drivers and the Go types declared by callers:
+// integers and floats to any numeric type they fit, struct types with the
+// same underlying type (such as time.Time definitions), and slices and
+// string-keyed maps element by element. A nil src zeroes dst, and Nullable
+// and pointer destinations are set valid and allocated as needed.
+func Assign(dst, src reflect.Value) error {
+	if src.Kind() == reflect.Interface {
+		src = src.Elem()
+	}
+	if !src.IsValid() {
+		dst.SetZero()
+		return nil
+	}
+	st, dt := src.Type(), dst.Type()
+	switch {
+	case st.AssignableTo(dt):
+		dst.Set(src)
+		return nil
+	case IsNullable(dt):
+		SetValid(dst, true)
+		return Assign(Inner(dst), src)
+	case dt.Kind() == reflect.Pointer:
+		p := reflect.New(dt.Elem())
+		if err := Assign(p.Elem(), src); err != nil {
+			return err
+		}
+		dst.Set(p)
+		return nil
+	}
+
+	switch dt.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if src.CanInt() && !dst.OverflowInt(src.Int()) {
+			dst.SetInt(src.Int())
+			return nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if src.CanInt() && src.Int() >= 0 && !dst.OverflowUint(uint64(src.Int())) {
+			dst.SetUint(uint64(src.Int()))
+			return nil
+		}
+	case reflect.Float32, reflect.Float64:
+		switch {
+		case src.CanFloat():
+			dst.SetFloat(src.Float())
+			return nil
+		case src.CanInt():
+			dst.SetFloat(float64(src.Int()))
+			return nil
+		}
+	case reflect.String:
+		if st.Kind() == reflect.String {
+			dst.SetString(src.String())
+			return nil
+		}
+	case reflect.Struct:
+		if st.Kind() == reflect.Struct && st.ConvertibleTo(dt) {
+			dst.Set(src.Convert(dt))
+			return nil
+		}
+	case reflect.Slice:
+		if st.Kind() == reflect.Slice {
+			s := reflect.MakeSlice(dt, src.Len(), src.Len())
+			for i := 0; i < src.Len(); i++ {
+				if err := Assign(s.Index(i), src.Index(i)); err != nil {
+					return fmt.Errorf("element %d: %w", i, err)
+				}
+			}
+			dst.Set(s)
+			return nil
+		}
+	case reflect.Map:
+		if st.Kind() == reflect.Map && st.Key().Kind() == reflect.String && dt.Key().Kind() == reflect.String {
+			m := reflect.MakeMapWithSize(dt, src.Len())
+			for it := src.MapRange(); it.Next(); {
+				e := reflect.New(dt.Elem()).Elem()
+				if err := Assign(e, it.Value()); err != nil {
+					return fmt.Errorf("key %s: %w", it.Key(), err)
+				}
+				m.SetMapIndex(it.Key().Convert(dt.Key()), e)
+			}
+			dst.Set(m)
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot convert %s %v to %s", st, src, dt)
+}
@@ -0,0 +1,146 @@
+// Package nullreflect provides reflection helpers for nullable.Nullable values
+// shared by the packages of this module.
+package nullreflect
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Path is the import path of the nullable package.
+const Path = "github.com/manattan/nullable"
+
+// IsNullable reports whether t is an instantiation of nullable.Nullable.
+func IsNullable(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t.PkgPath() == Path && strings.HasPrefix(t.Name(), "Nullable[")
+}
+
+// Nullable[T] embeds sql.Null[T], whose fields are V and Valid in that
+// order. Indexing them directly avoids FieldByName on hot paths.
+const (
+	innerIndex = 0
+	validIndex = 1
+)
+
+// Valid reports whether the Nullable held by v is valid.
+func Valid(v reflect.Value) bool {
+	return v.Field(0).Field(validIndex).Bool()
+}
+
+// SetValid sets the Valid field of the Nullable held by v.
+func SetValid(v reflect.Value, valid bool) {
+	v.Field(0).Field(validIndex).SetBool(valid)
+}
+
+// Inner returns the V field of the Nullable held by v.
+func Inner(v reflect.Value) reflect.Value {
+	return v.Field(0).Field(innerIndex)
+}
+
+// Field describes a Nullable field reached from a root struct type.
+type Field struct {
+	// Path is the dotted Go field path, e.g. "Address.City".
+	Path string
+	// Index is the index sequence for reflect.Value.FieldByIndex.
+	Index []int
+}
+
+// Fields returns the exported Nullable fields of the struct type t, descending
+// into nested struct fields that are not themselves Nullable.
+func Fields(t reflect.Type) []Field {
+	var fields []Field
+	collect(t, "", nil, &fields)
+	return fields
+}
+
+func collect(t reflect.Type, prefix string, index []int, out *[]Field) {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		idx := append(append([]int(nil), index...), i)
+		path := sf.Name
+		if prefix != "" {
+			path = prefix + "." + sf.Name
+		}
+		switch {
+		case IsNullable(sf.Type):
+			*out = append(*out, Field{Path: path, Index: idx})
+		case sf.Type.Kind() == reflect.Struct:
+			collect(sf.Type, path, idx, out)
+		}
+	}
+}
+
+// Unwrap returns the inner value of x if x is a Nullable, or nil if it is a
+// null Nullable. The boolean result reports whether x was a Nullable.
+func Unwrap(x any) (any, bool) {
+	if x == nil {
+		return nil, false
+	}
+	v := reflect.ValueOf(x)
+	if !IsNullable(v.Type()) {
+		return nil, false
+	}
+	if !Valid(v) {
+		return nil, true
+	}
+	return Inner(v).Interface(), true
+}
+
+// JSONName returns the JSON object key of the struct field sf. The boolean
+// result is false for unexported fields and fields tagged json:"-".
+func JSONName(sf reflect.StructField) (string, bool) {
+	if !sf.IsExported() {
+		return "", false
+	}
+	name, _, ok := JSONTag(sf)
+	if !ok {
+		return "", false
+	}
+	if name == "" {
+		return sf.Name, true
+	}
+	return name, true
+}
+
+// JSONTag splits the json tag of sf into the name it sets, which is empty if
+// the tag names no key, and its comma-separated options. The boolean result
+// is false for a field tagged json:"-"; a tag of "-," names the key "-".
+func JSONTag(sf reflect.StructField) (name, opts string, ok bool) {
+	tag := sf.Tag.Get("json")
+	if tag == "-" {
+		return "", "", false
+	}
+	name, opts, _ = strings.Cut(tag, ",")
+	return name, opts, true
+}
+
+// HasJSONOption reports whether the options returned by JSONTag include opt.
+func HasJSONOption(opts, opt string) bool {
+	for opts != "" {
+		var o string
+		o, opts, _ = strings.Cut(opts, ",")
+		if o == opt {
+			return true
+		}
+	}
+	return false
+}
+
+// IsEmbeddedJSON reports whether encoding/json promotes the fields of sf into
+// the enclosing object: sf is an embedded struct, or pointer to struct,
+// whose json tag names no key. Nullables and wrapper types are not
+// promoted, since they encode as a single value.
+func IsEmbeddedJSON(sf reflect.StructField) bool {
+	name, _, ok := JSONTag(sf)
+	if !sf.Anonymous || !ok || name != "" {
+		return false
+	}
+	t := sf.Type
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct && !IsNullable(t) && !IsWrapper(t)
+}
@@ -0,0 +1,33 @@
+package nullable
+
+import "sync"
+
+// Lazy is a Nullable that is safe for concurrent use, for values computed
+// on first use. The zero value is null and ready to use; a Lazy must not be
+// copied after first use.
+type Lazy[T any] struct {
+	mu sync.Mutex
+	n  Nullable[T]
+}
+
+// GetOrInit returns the value, first setting it to f() if l is null. Only
+// one goroutine runs f at a time; if f panics, l stays null.
+func (l *Lazy[T]) GetOrInit(f func() T) T {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.n.GetOrInit(f)
+}
+
+// Get returns the current value without initializing it.
+func (l *Lazy[T]) Get() Nullable[T] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.n
+}
+
+// Reset makes l null again so the next GetOrInit recomputes it.
+func (l *Lazy[T]) Reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.n = NewNull[T]()
+}
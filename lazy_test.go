@@ -0,0 +1,63 @@
+package nullable
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestGetOrInit(t *testing.T) {
+	calls := 0
+	f := func() int { calls++; return 42 }
+
+	var n Nullable[int]
+	if v := n.GetOrInit(f); v != 42 || !n.Valid {
+		t.Errorf("Expected 42, got %d (%v)", v, n)
+	}
+	if v := n.GetOrInit(f); v != 42 || calls != 1 {
+		t.Errorf("Expected one call, got %d (value %d)", calls, v)
+	}
+
+	set := NewNullable(7)
+	if v := set.GetOrInit(f); v != 7 || calls != 1 {
+		t.Errorf("Expected 7 without calling f, got %d (%d calls)", v, calls)
+	}
+}
+
+func TestLazy(t *testing.T) {
+	var l Lazy[string]
+	var calls atomic.Int32
+	var wg sync.WaitGroup
+	for range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if v := l.GetOrInit(func() string { calls.Add(1); return "ready" }); v != "ready" {
+				t.Errorf("Expected ready, got %q", v)
+			}
+		}()
+	}
+	wg.Wait()
+	if calls.Load() != 1 {
+		t.Errorf("Expected one call, got %d", calls.Load())
+	}
+	if got := l.Get(); !got.Valid || got.V != "ready" {
+		t.Errorf("Expected ready, got %v", got)
+	}
+
+	l.Reset()
+	if got := l.Get(); got.Valid {
+		t.Errorf("Expected null after Reset, got %v", got)
+	}
+
+	func() {
+		defer func() { recover() }()
+		l.GetOrInit(func() string { panic("boom") })
+	}()
+	if got := l.Get(); got.Valid {
+		t.Errorf("Expected null after panic, got %v", got)
+	}
+	if v := l.GetOrInit(func() string { return "again" }); v != "again" {
+		t.Errorf("Expected again, got %q", v)
+	}
+}
@@ -0,0 +1,91 @@
+package nullable
+
+import (
+	"github.com/vmihailenco/msgpack/v5"
+	"github.com/vmihailenco/msgpack/v5/msgpcode"
+)
+
+// EncodeMsgpack implements the msgpack.CustomEncoder interface of
+// github.com/vmihailenco/msgpack/v5. A null value encodes as msgpack nil
+// and a valid one as the value, with the encoder's options.
+func (n Nullable[T]) EncodeMsgpack(enc *msgpack.Encoder) error {
+	if !n.Valid {
+		return enc.EncodeNil()
+	}
+	return enc.Encode(n.V)
+}
+
+// DecodeMsgpack implements the msgpack.CustomDecoder interface. Msgpack nil
+// decodes as null; any other value is decoded into the value. On error n is
+// left unchanged.
+func (n *Nullable[T]) DecodeMsgpack(dec *msgpack.Decoder) error {
+	code, err := dec.PeekCode()
+	if err != nil {
+		return err
+	}
+	if code == msgpcode.Nil {
+		if err := dec.DecodeNil(); err != nil {
+			return err
+		}
+		n.Reset()
+		return nil
+	}
+	var v T
+	if err := dec.Decode(&v); err != nil {
+		return err
+	}
+	n.V, n.Valid = v, true
+	return nil
+}
+
+// DecodeMsgpack implements the msgpack.CustomDecoder interface like
+// Nullable.DecodeMsgpack, rejecting invalid addresses.
+func (e *Email) DecodeMsgpack(dec *msgpack.Decoder) error {
+	return decodeChecked(&e.Nullable, validateEmail, func(dst *Nullable[string]) error {
+		return dst.DecodeMsgpack(dec)
+	})
+}
+
+// DecodeMsgpack implements the msgpack.CustomDecoder interface like
+// Nullable.DecodeMsgpack, rejecting numbers that are not E.164.
+func (p *Phone) DecodeMsgpack(dec *msgpack.Decoder) error {
+	return decodeChecked(&p.Nullable, validatePhone, func(dst *Nullable[string]) error {
+		return dst.DecodeMsgpack(dec)
+	})
+}
+
+// DecodeMsgpack implements the msgpack.CustomDecoder interface like
+// Nullable.DecodeMsgpack, rejecting negative values.
+func (n *NonNegative[T]) DecodeMsgpack(dec *msgpack.Decoder) error {
+	return decodeChecked(&n.Nullable, validateNonNegative[T], func(dst *Nullable[T]) error {
+		return dst.DecodeMsgpack(dec)
+	})
+}
+
+// DecodeMsgpack implements the msgpack.CustomDecoder interface like
+// Nullable.DecodeMsgpack, rejecting values that are not positive.
+func (n *Positive[T]) DecodeMsgpack(dec *msgpack.Decoder) error {
+	return decodeChecked(&n.Nullable, validatePositive[T], func(dst *Nullable[T]) error {
+		return dst.DecodeMsgpack(dec)
+	})
+}
+
+// DecodeMsgpack implements the msgpack.CustomDecoder interface like
+// Nullable.DecodeMsgpack, rejecting values outside 0 to 100.
+func (n *Percentage[T]) DecodeMsgpack(dec *msgpack.Decoder) error {
+	return decodeChecked(&n.Nullable, validatePercentage[T], func(dst *Nullable[T]) error {
+		return dst.DecodeMsgpack(dec)
+	})
+}
+
+// DecodeMsgpack implements the msgpack.CustomDecoder interface like
+// Nullable.DecodeMsgpack, marking o present. Note that msgpack resets a
+// struct field to its zero value on nil without calling DecodeMsgpack, so
+// an explicit nil in a field decodes as absent.
+func (o *Omittable[T]) DecodeMsgpack(dec *msgpack.Decoder) error {
+	if err := o.Nullable.DecodeMsgpack(dec); err != nil {
+		return err
+	}
+	o.present = true
+	return nil
+}
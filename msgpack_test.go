@@ -0,0 +1,101 @@
+package nullable
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+var (
+	_ msgpack.CustomEncoder = Nullable[int]{}
+	_ msgpack.CustomDecoder = (*Nullable[int])(nil)
+)
+
+type msgpackEvent struct {
+	ID      int64               `msgpack:"id"`
+	User    Nullable[string]    `msgpack:"user"`
+	Retries Nullable[int]       `msgpack:"retries"`
+	Sent    Nullable[time.Time] `msgpack:"sent"`
+	Meta    Nullable[map[string]int]
+}
+
+func TestMsgpackRoundTrip(t *testing.T) {
+	sent := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	in := msgpackEvent{ID: 1, User: NewNullable("ada"), Sent: NewNullable(sent), Meta: NewNullable(map[string]int{"a": 1})}
+	data, err := msgpack.Marshal(in)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	out := msgpackEvent{Retries: NewNullable(3)}
+	if err := msgpack.Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if out.ID != 1 || out.User != in.User || out.Retries.Valid || !out.Sent.V.Equal(sent) || out.Meta.V["a"] != 1 {
+		t.Errorf("Expected %+v, got %+v", in, out)
+	}
+
+	var generic map[string]any
+	if err := msgpack.Unmarshal(data, &generic); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if v, ok := generic["retries"]; !ok || v != nil {
+		t.Errorf("Expected retries to be msgpack nil, got %v", v)
+	}
+}
+
+func TestMsgpackNull(t *testing.T) {
+	data, err := msgpack.Marshal(NewNull[string]())
+	if err != nil || !bytes.Equal(data, []byte{0xc0}) {
+		t.Errorf("Expected msgpack nil, got %x (%v)", data, err)
+	}
+
+	n := NewNullable(1)
+	if err := msgpack.Unmarshal([]byte{0xa1, 'x'}, &n); err == nil || n != NewNullable(1) {
+		t.Errorf("Expected error and unchanged value, got %v (%v)", n, err)
+	}
+}
+
+func TestValidatedMsgpack(t *testing.T) {
+	type record struct {
+		Email Email            `msgpack:"email"`
+		Count Positive[int]    `msgpack:"count"`
+		Score Percentage[int]  `msgpack:"score"`
+		Extra Omittable[int]   `msgpack:"extra"`
+		Other Omittable[int]   `msgpack:"other"`
+		Phone Phone            `msgpack:"phone"`
+		Total NonNegative[int] `msgpack:"total"`
+	}
+	data, _ := msgpack.Marshal(map[string]any{"email": "ada@example.com", "count": 2, "extra": 0, "total": 0})
+	var r record
+	if err := msgpack.Unmarshal(data, &r); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if r.Email.V != "ada@example.com" || r.Count.V != 2 || !r.Total.Valid {
+		t.Errorf("Unexpected decode: %+v", r)
+	}
+	if !r.Extra.IsPresent() || r.Extra.V != 0 || r.Other.IsPresent() {
+		t.Errorf("Expected extra present and 0, other absent, got %v and %v", r.Extra, r.Other)
+	}
+
+	for _, in := range []map[string]any{
+		{"email": "nope"},
+		{"phone": "555"},
+		{"count": 0},
+		{"score": 101},
+		{"total": -1},
+	} {
+		data, _ := msgpack.Marshal(in)
+		var fe *FormatError
+		var re *RangeError
+		if err := msgpack.Unmarshal(data, &r); !errors.As(err, &fe) && !errors.As(err, &re) {
+			t.Errorf("%v: Expected validation error, got %v", in, err)
+		}
+	}
+	if r.Email.V != "ada@example.com" || r.Count.V != 2 {
+		t.Errorf("Expected rejected values to leave fields unchanged, got %+v", r)
+	}
+}
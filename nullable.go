@@ -1,9 +1,13 @@
 package nullable
 
 import (
+	"bytes"
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"io"
+	"reflect"
+	"time"
 )
 
 // Nullable represents a value that may be null.
@@ -31,6 +35,103 @@ func NewNull[T any]() Nullable[T] {
 	}
 }
 
+// N is a short alias for NewNullable, for terse literals in table-driven
+// tests and fixtures.
+func N[T any](value T) Nullable[T] {
+	return NewNullable(value)
+}
+
+// Null is a short alias for NewNull.
+func Null[T any]() Nullable[T] {
+	return NewNull[T]()
+}
+
+// Of creates a Nullable holding value with the given validity, so fixture
+// tables can carry validity as a column. When valid is false the value is
+// discarded.
+func Of[T any](value T, valid bool) Nullable[T] {
+	if !valid {
+		return NewNull[T]()
+	}
+	return NewNullable(value)
+}
+
+// FromPtr creates a Nullable from a pointer, which is null if p is nil.
+func FromPtr[T any](p *T) Nullable[T] {
+	if p == nil {
+		return NewNull[T]()
+	}
+	return NewNullable(*p)
+}
+
+// FromMapLookup creates a Nullable from m[k], which is null if k is not in
+// m. A present zero value is valid.
+func FromMapLookup[K comparable, V any](m map[K]V, k K) Nullable[V] {
+	v, ok := m[k]
+	return Of(v, ok)
+}
+
+// NewNonZero creates a Nullable from v that is null if v is the zero value
+// of T, for adapting legacy structs where "" or 0 meant "not set". Use
+// TimeOrNull for times, whose zero check must ignore the location.
+func NewNonZero[T comparable](v T) Nullable[T] {
+	var zero T
+	if v == zero {
+		return NewNull[T]()
+	}
+	return NewNullable(v)
+}
+
+// TimeOrNull creates a Nullable from t that is null if t is the zero time.
+func TimeOrNull(t time.Time) Nullable[time.Time] {
+	if t.IsZero() {
+		return NewNull[time.Time]()
+	}
+	return NewNullable(t)
+}
+
+// IsValid reports whether n holds a value.
+func (n Nullable[T]) IsValid() bool {
+	return n.Valid
+}
+
+// IsZero reports whether n is null, so that encoding/json drops null
+// fields tagged omitzero. A valid zero value is not zero:
+//
+//	Count nullable.Nullable[int] `json:"count,omitzero"` // omitted only when null
+func (n Nullable[T]) IsZero() bool {
+	return !n.Valid
+}
+
+// Get returns the value and whether it is valid.
+func (n Nullable[T]) Get() (T, bool) {
+	return n.V, n.Valid
+}
+
+// Unwrap returns the value, or an error wrapping ErrNull and naming T if n
+// is null, for propagating a missing value through error handling:
+//
+//	id, err := req.AccountID.Unwrap()
+//	if err != nil {
+//		return fmt.Errorf("loading account: %w", err)
+//	}
+func (n Nullable[T]) Unwrap() (T, error) {
+	if !n.Valid {
+		return n.V, &nullError{typ: reflect.TypeFor[T]()}
+	}
+	return n.V, nil
+}
+
+// MustGet returns the value, panicking if n is null. It is intended for
+// values already known to be valid, such as required fields after
+// validation.
+func (n Nullable[T]) MustGet() T {
+	if !n.Valid {
+		panic(fmt.Sprintf("nullable: MustGet called on null Nullable[%s]", reflect.TypeFor[T]()))
+	}
+	return n.V
+}
+
 // Ptr returns a pointer to the value if valid, otherwise nil.
 func (n Nullable[T]) Ptr() *T {
 	if !n.Valid {
@@ -47,6 +148,116 @@ func (n Nullable[T]) ValueOr(defaultValue T) T {
 	return n.V
 }
 
+// ValueOrElse returns the value if valid, and f() otherwise. Unlike
+// ValueOr, the default is only computed when n is null.
+func (n Nullable[T]) ValueOrElse(f func() T) T {
+	if !n.Valid {
+		return f()
+	}
+	return n.V
+}
+
+// Or returns n if it is valid, and other otherwise. Chained calls pick the
+// first valid source:
+//
+//	port := flags.Port.Or(env.Port).Or(file.Port)
+func (n Nullable[T]) Or(other Nullable[T]) Nullable[T] {
+	if n.Valid {
+		return n
+	}
+	return other
+}
+
+// OrElse returns n if it is valid, and f() otherwise. f is only called when
+// n is null.
+func (n Nullable[T]) OrElse(f func() Nullable[T]) Nullable[T] {
+	if n.Valid {
+		return n
+	}
+	return f()
+}
+
+// Map returns f applied to the value if n is valid, and null otherwise. Use
+// Convert to change the value type.
+func (n Nullable[T]) Map(f func(T) T) Nullable[T] {
+	return Convert(n, f)
+}
+
+// Convert returns f applied to the value of n if it is valid, and null
+// otherwise:
+//
+//	length := nullable.Convert(name, func(s string) int { return len(s) })
+func Convert[T, U any](n Nullable[T], f func(T) U) Nullable[U] {
+	if !n.Valid {
+		return NewNull[U]()
+	}
+	return NewNullable(f(n.V))
+}
+
+// Zip returns f applied to the values of a and b if both are valid, and
+// null otherwise:
+//
+//	total := nullable.Zip(price, quantity, func(p float64, q int) float64 { return p * float64(q) })
+func Zip[A, B, C any](a Nullable[A], b Nullable[B], f func(A, B) C) Nullable[C] {
+	if !a.Valid || !b.Valid {
+		return NewNull[C]()
+	}
+	return NewNullable(f(a.V, b.V))
+}
+
+// Flatten collapses a doubly wrapped Nullable, as produced by generic code
+// composing optional results, into a Nullable that is valid only if both
+// levels are.
+//
+// Nested Nullables encode a null at either level as JSON null, so
+// NewNullable(NewNull[T]()) decodes back as a null outer Nullable; Flatten
+// gives the same result for both.
+func Flatten[T any](n Nullable[Nullable[T]]) Nullable[T] {
+	if !n.Valid {
+		return NewNull[T]()
+	}
+	return n.V
+}
+
+// Coalesce returns the first valid Nullable of ns, like SQL COALESCE, or
+// null if there is none:
+//
+//	timeout := nullable.Coalesce(req.Timeout, stored.Timeout, nullable.NewNullable(30))
+func Coalesce[T any](ns ...Nullable[T]) Nullable[T] {
+	for _, n := range ns {
+		if n.Valid {
+			return n
+		}
+	}
+	return NewNull[T]()
+}
+
+// GetOrInit returns the value, first setting it to f() if n is null. It is
+// not safe for concurrent use; use Lazy for fields shared between
+// goroutines.
+func (n *Nullable[T]) GetOrInit(f func() T) T {
+	if !n.Valid {
+		n.V, n.Valid = f(), true
+	}
+	return n.V
+}
+
+// Set makes n valid with value v.
+func (n *Nullable[T]) Set(v T) {
+	n.V, n.Valid = v, true
+}
+
+// SetNull makes n null.
+func (n *Nullable[T]) SetNull() {
+	n.Valid = false
+}
+
+// Reset makes n null and zeroes its value, so that a Nullable reused across
+// decodes does not keep the previous value reachable.
+func (n *Nullable[T]) Reset() {
+	n.V, n.Valid = *new(T), false
+}
+
 // MarshalJSON implements the json.Marshaler interface.
 func (n Nullable[T]) MarshalJSON() ([]byte, error) {
 	if !n.Valid {
@@ -67,10 +278,100 @@ func (n *Nullable[T]) UnmarshalJSON(data []byte) error {
 }
 
 // Scan implements the sql.Scanner interface.
+// For Nullable[bool] it also accepts MySQL tinyint(1) integers (non-zero is
+// true) and single-byte BIT(1) values.
 func (n *Nullable[T]) Scan(value any) error {
+	if value == nil {
+		var zero T
+		n.V, n.Valid = zero, false
+		return nil
+	}
+	if scanDirect(&n.V, value) {
+		n.Valid = true
+		return nil
+	}
+	if b, ok := any(&n.V).(*bool); ok {
+		if v, ok := scanBool(value); ok {
+			*b, n.Valid = v, true
+			return nil
+		}
+	}
 	return n.Null.Scan(value)
 }
 
+// scanDirect stores value in dst without reflection when value is a
+// driver type that dst holds directly, reporting whether it did. Other
+// combinations fall back to sql.Null's conversions.
+func scanDirect(dst, value any) bool {
+	switch d := dst.(type) {
+	case *int64:
+		if v, ok := value.(int64); ok {
+			*d = v
+			return true
+		}
+	case *int:
+		v, ok := value.(int64)
+		if ok && int64(int(v)) == v {
+			*d = int(v)
+			return true
+		}
+	case *int32:
+		v, ok := value.(int64)
+		if ok && int64(int32(v)) == v {
+			*d = int32(v)
+			return true
+		}
+	case *float64:
+		if v, ok := value.(float64); ok {
+			*d = v
+			return true
+		}
+	case *bool:
+		if v, ok := value.(bool); ok {
+			*d = v
+			return true
+		}
+	case *string:
+		switch v := value.(type) {
+		case string:
+			*d = v
+			return true
+		case []byte:
+			*d = string(v)
+			return true
+		}
+	case *[]byte:
+		switch v := value.(type) {
+		case []byte:
+			*d = bytes.Clone(v)
+			return true
+		case string:
+			*d = []byte(v)
+			return true
+		}
+	case *time.Time:
+		if v, ok := value.(time.Time); ok {
+			*d = v
+			return true
+		}
+	}
+	return false
+}
+
+// scanBool converts the integer and bit representations MySQL drivers use
+// for booleans.
+func scanBool(value any) (bool, bool) {
+	switch v := value.(type) {
+	case int64:
+		return v != 0, true
+	case []byte:
+		if len(v) == 1 && v[0] <= 1 {
+			return v[0] == 1, true
+		}
+	}
+	return false, false
+}
+
 // Value implements the T interface.
 func (n Nullable[T]) Value() (T, error) {
 	if !n.Valid {
@@ -89,3 +390,55 @@ func (n Nullable[T]) String() string {
 	}
 	return fmt.Sprintf("%v", n.V)
 }
+
+// Format implements the fmt.Formatter interface. A valid value is formatted
+// with the verb and flags as if it were passed directly, so %d, %q, %.2f
+// and %+v apply to the value rather than to the Nullable struct; a null one
+// prints as null, padded to the width. %#v prints GoString, and %s prints
+// String for values that %s cannot format themselves, such as integers.
+func (n Nullable[T]) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && f.Flag('#'):
+		io.WriteString(f, n.GoString())
+	case !n.Valid:
+		formatNull(f)
+	case verb == 's' && !formatsAsString(n.V):
+		fmt.Fprintf(f, fmt.FormatString(f, verb), n.String())
+	default:
+		fmt.Fprintf(f, fmt.FormatString(f, verb), n.V)
+	}
+}
+
+// formatsAsString reports whether %s formats v as text: strings, byte
+// slices, errors and fmt.Stringers.
+func formatsAsString(v any) bool {
+	switch v.(type) {
+	case fmt.Stringer, error:
+		return true
+	}
+	t := reflect.TypeOf(v)
+	return t != nil && (t.Kind() == reflect.String || t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8)
+}
+
+// formatNull writes null to f, honoring its width and - flag.
+func formatNull(f fmt.State) {
+	w, ok := f.Width()
+	switch {
+	case !ok:
+		io.WriteString(f, "null")
+	case f.Flag('-'):
+		fmt.Fprintf(f, "%-*s", w, "null")
+	default:
+		fmt.Fprintf(f, "%*s", w, "null")
+	}
+}
+
+// GoString implements the fmt.GoStringer interface, returning the Go
+// expression that constructs n, such as nullable.NewNullable[int](5) or
+// nullable.NewNull[string]().
+func (n Nullable[T]) GoString() string {
+	if !n.Valid {
+		return fmt.Sprintf("nullable.NewNull[%s]()", reflect.TypeFor[T]())
+	}
+	return fmt.Sprintf("nullable.NewNullable[%s](%#v)", reflect.TypeFor[T](), n.V)
+}
@@ -2,7 +2,10 @@ package nullable
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 )
 
 func TestNewNullable(t *testing.T) {
@@ -22,6 +25,143 @@ func TestNewNull(t *testing.T) {
 	}
 }
 
+func TestShortConstructors(t *testing.T) {
+	if n := N(3); !n.Valid || n.V != 3 {
+		t.Errorf("Expected valid 3, got %+v", n)
+	}
+	if n := Null[string](); n.Valid {
+		t.Error("Expected Valid to be false")
+	}
+	if n := Of("x", true); !n.Valid || n.V != "x" {
+		t.Errorf("Expected valid 'x', got %+v", n)
+	}
+	if n := Of("x", false); n.Valid || n.V != "" {
+		t.Errorf("Expected null with zero value, got %+v", n)
+	}
+}
+
+func TestFromPtr(t *testing.T) {
+	v := 42
+	if n := FromPtr(&v); !n.Valid || n.V != 42 {
+		t.Errorf("Expected valid 42, got %+v", n)
+	}
+	if n := FromPtr[int](nil); n.Valid {
+		t.Error("Expected Valid to be false")
+	}
+}
+
+func TestFromMapLookup(t *testing.T) {
+	headers := map[string]string{"X-Request-Id": "abc", "X-Empty": ""}
+	if n := FromMapLookup(headers, "X-Request-Id"); !n.Valid || n.V != "abc" {
+		t.Errorf("Expected valid 'abc', got %+v", n)
+	}
+	if n := FromMapLookup(headers, "X-Empty"); !n.Valid || n.V != "" {
+		t.Errorf("Expected valid empty string, got %+v", n)
+	}
+	if n := FromMapLookup(headers, "X-Missing"); n.Valid {
+		t.Error("Expected Valid to be false")
+	}
+	if n := FromMapLookup[string, int](nil, "x"); n.Valid {
+		t.Error("Expected Valid to be false for nil map")
+	}
+}
+
+func TestNewNonZero(t *testing.T) {
+	if n := NewNonZero(""); n.Valid {
+		t.Errorf("Expected empty string to be null, got %+v", n)
+	}
+	if n := NewNonZero(0); n.Valid {
+		t.Errorf("Expected 0 to be null, got %+v", n)
+	}
+	if n := NewNonZero("x"); n != NewNullable("x") {
+		t.Errorf("Expected x, got %+v", n)
+	}
+	type point struct{ X, Y int }
+	if n := NewNonZero(point{}); n.Valid {
+		t.Errorf("Expected zero struct to be null, got %+v", n)
+	}
+	if n := NewNonZero(point{Y: 1}); !n.Valid {
+		t.Errorf("Expected non-zero struct to be valid, got %+v", n)
+	}
+}
+
+func TestTimeOrNull(t *testing.T) {
+	if n := TimeOrNull(time.Time{}); n.Valid {
+		t.Error("Expected zero time to be null")
+	}
+	ts := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	if n := TimeOrNull(ts); !n.Valid || !n.V.Equal(ts) {
+		t.Errorf("Expected valid %v, got %+v", ts, n)
+	}
+}
+
+func TestIsZeroOmitZero(t *testing.T) {
+	type response struct {
+		Count Nullable[int]    `json:"count,omitzero"`
+		Name  Nullable[string] `json:"name,omitzero"`
+	}
+	stale := NewNull[string]()
+	stale.V = "stale"
+	tests := []struct {
+		in   response
+		want string
+	}{
+		{response{}, `{}`},
+		{response{Name: stale}, `{}`},
+		{response{Count: NewNullable(0)}, `{"count":0}`},
+		{response{Count: NewNullable(3), Name: NewNullable("")}, `{"count":3,"name":""}`},
+	}
+	for _, tc := range tests {
+		for name, marshal := range map[string]func(any) ([]byte, error){
+			"encoding/json":    json.Marshal,
+			"nullable.Marshal": func(v any) ([]byte, error) { return Marshal(v) },
+		} {
+			data, err := marshal(tc.in)
+			if err != nil || string(data) != tc.want {
+				t.Errorf("%s: Expected %s, got %s (%v)", name, tc.want, data, err)
+			}
+		}
+	}
+}
+
+func TestGet(t *testing.T) {
+	if v, ok := NewNullable(42).Get(); !ok || v != 42 {
+		t.Errorf("Expected 42, true, got %v, %v", v, ok)
+	}
+	if v, ok := NewNull[string]().Get(); ok || v != "" {
+		t.Errorf("Expected \"\", false, got %q, %v", v, ok)
+	}
+}
+
+func TestUnwrap(t *testing.T) {
+	if v, err := NewNullable(42).Unwrap(); err != nil || v != 42 {
+		t.Errorf("Expected 42, got %v (%v)", v, err)
+	}
+	v, err := NewNull[int64]().Unwrap()
+	if !errors.Is(err, ErrNull) || v != 0 {
+		t.Errorf("Expected ErrNull, got %v (%v)", v, err)
+	}
+	if want := "nullable: Nullable[int64] is null"; err == nil || err.Error() != want {
+		t.Errorf("Expected %q, got %v", want, err)
+	}
+	if wrapped := fmt.Errorf("loading account: %w", err); !errors.Is(wrapped, ErrNull) {
+		t.Errorf("Expected wrapped error to match ErrNull, got %v", wrapped)
+	}
+}
+
+func TestMustGet(t *testing.T) {
+	if v := NewNullable("x").MustGet(); v != "x" {
+		t.Errorf("Expected x, got %q", v)
+	}
+	defer func() {
+		r := recover()
+		if want := "nullable: MustGet called on null Nullable[time.Time]"; r != want {
+			t.Errorf("Expected panic %q, got %v", want, r)
+		}
+	}()
+	NewNull[time.Time]().MustGet()
+}
+
 func TestPtr(t *testing.T) {
 	// Valid nullable
 	n1 := NewNullable(42)
@@ -57,6 +197,150 @@ func TestValueOr(t *testing.T) {
 	}
 }
 
+func TestValueOrElse(t *testing.T) {
+	calls := 0
+	def := func() string { calls++; return "default" }
+	if got := NewNullable("").ValueOrElse(def); got != "" || calls != 0 {
+		t.Errorf("Expected empty value without calling default, got %q (%d calls)", got, calls)
+	}
+	if got := NewNull[string]().ValueOrElse(def); got != "default" || calls != 1 {
+		t.Errorf("Expected default from one call, got %q (%d calls)", got, calls)
+	}
+}
+
+func TestOr(t *testing.T) {
+	null := NewNull[int]()
+	if got := NewNullable(1).Or(NewNullable(2)); got != NewNullable(1) {
+		t.Errorf("Expected 1, got %v", got)
+	}
+	if got := null.Or(NewNullable(0)).Or(NewNullable(2)); got != NewNullable(0) {
+		t.Errorf("Expected 0, got %v", got)
+	}
+	if got := null.Or(null); got.Valid {
+		t.Errorf("Expected null, got %v", got)
+	}
+}
+
+func TestOrElse(t *testing.T) {
+	calls := 0
+	fallback := func() Nullable[int] { calls++; return NewNullable(2) }
+	if got := NewNullable(1).OrElse(fallback); got != NewNullable(1) || calls != 0 {
+		t.Errorf("Expected 1 without calling fallback, got %v (%d calls)", got, calls)
+	}
+	if got := NewNull[int]().OrElse(fallback); got != NewNullable(2) || calls != 1 {
+		t.Errorf("Expected 2 from one fallback call, got %v (%d calls)", got, calls)
+	}
+}
+
+func TestMap(t *testing.T) {
+	double := func(v int) int { return v * 2 }
+	if got := NewNullable(21).Map(double); got != NewNullable(42) {
+		t.Errorf("Expected 42, got %v", got)
+	}
+	called := false
+	got := NewNull[int]().Map(func(v int) int { called = true; return v })
+	if got.Valid || called {
+		t.Errorf("Expected null without calling f, got %v (called %v)", got, called)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	length := func(s string) int { return len(s) }
+	if got := Convert(NewNullable("hello"), length); got != NewNullable(5) {
+		t.Errorf("Expected 5, got %v", got)
+	}
+	if got := Convert(NewNull[string](), length); got.Valid {
+		t.Errorf("Expected null, got %v", got)
+	}
+	if got := Convert(NewNullable(""), length); got != NewNullable(0) {
+		t.Errorf("Expected valid 0 for empty string, got %v", got)
+	}
+}
+
+func TestCoalesce(t *testing.T) {
+	null := NewNull[int]()
+	tests := []struct {
+		in   []Nullable[int]
+		want Nullable[int]
+	}{
+		{nil, null},
+		{[]Nullable[int]{null, null}, null},
+		{[]Nullable[int]{null, NewNullable(0), NewNullable(30)}, NewNullable(0)},
+		{[]Nullable[int]{NewNullable(5), NewNullable(30)}, NewNullable(5)},
+	}
+	for _, tc := range tests {
+		if got := Coalesce(tc.in...); got != tc.want {
+			t.Errorf("Coalesce(%v): Expected %v, got %v", tc.in, tc.want, got)
+		}
+	}
+}
+
+func TestSetAndSetNull(t *testing.T) {
+	var n Nullable[int]
+	n.Set(0)
+	if n != NewNullable(0) {
+		t.Errorf("Expected valid 0, got %+v", n)
+	}
+	n.SetNull()
+	if n.Valid {
+		t.Errorf("Expected null, got %+v", n)
+	}
+}
+
+func TestZip(t *testing.T) {
+	total := func(p float64, q int) float64 { return p * float64(q) }
+	if got := Zip(NewNullable(2.5), NewNullable(4), total); got != NewNullable(10.0) {
+		t.Errorf("Expected 10, got %v", got)
+	}
+	if got := Zip(NewNull[float64](), NewNullable(4), total); got.Valid {
+		t.Errorf("Expected null for null price, got %v", got)
+	}
+	if got := Zip(NewNullable(2.5), NewNull[int](), total); got.Valid {
+		t.Errorf("Expected null for null quantity, got %v", got)
+	}
+	full := Zip(NewNullable("Ada"), NewNullable("Lovelace"), func(first, last string) string { return first + " " + last })
+	if full != NewNullable("Ada Lovelace") {
+		t.Errorf("Expected Ada Lovelace, got %v", full)
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	tests := []struct {
+		in   Nullable[Nullable[int]]
+		want Nullable[int]
+	}{
+		{NewNull[Nullable[int]](), NewNull[int]()},
+		{NewNullable(NewNull[int]()), NewNull[int]()},
+		{NewNullable(NewNullable(0)), NewNullable(0)},
+		{NewNullable(NewNullable(7)), NewNullable(7)},
+	}
+	for _, tc := range tests {
+		if got := Flatten(tc.in); got != tc.want {
+			t.Errorf("Flatten(%+v): Expected %v, got %v", tc.in, tc.want, got)
+		}
+	}
+}
+
+func TestNestedJSON(t *testing.T) {
+	for _, n := range []Nullable[Nullable[int]]{NewNull[Nullable[int]](), NewNullable(NewNull[int]())} {
+		data, err := json.Marshal(n)
+		if err != nil || string(data) != "null" {
+			t.Errorf("Expected null for %+v, got %s (%v)", n, data, err)
+		}
+	}
+
+	var n Nullable[Nullable[int]]
+	if err := json.Unmarshal([]byte("5"), &n); err != nil || Flatten(n) != NewNullable(5) {
+		t.Errorf("Expected 5, got %+v (%v)", n, err)
+	}
+	if err := json.Unmarshal([]byte("null"), &n); err != nil || n.Valid {
+		t.Errorf("Expected outer null, got %+v (%v)", n, err)
+	}
+	if err := Unmarshal([]byte("null"), &n, NullAsZero()); err != nil || Flatten(n) != NewNullable(0) {
+		t.Errorf("Expected NullAsZero to make both levels valid, got %+v (%v)", n, err)
+	}
+}
+
 func TestMarshalJSON(t *testing.T) {
 	// Valid nullable
 	n1 := NewNullable("test")
@@ -202,6 +486,54 @@ func TestString(t *testing.T) {
 	}
 }
 
+func TestFormat(t *testing.T) {
+	type row struct {
+		ID   Nullable[int]
+		Name Nullable[string]
+	}
+	tests := []struct {
+		format string
+		arg    any
+		want   string
+	}{
+		{"%d", NewNullable(42), "42"},
+		{"%05d", NewNullable(42), "00042"},
+		{"%d", NewNull[int](), "null"},
+		{"%6d|", NewNull[int](), "  null|"},
+		{"%-6d|", NewNull[int](), "null  |"},
+		{"%.2f", NewNullable(3.14159), "3.14"},
+		{"%.2f", NewNull[float64](), "null"},
+		{"%q", NewNullable("hi"), `"hi"`},
+		{"%s", NewNullable("hi"), "hi"},
+		{"%s", NewNullable(5), "5"},
+		{"%4s|", NewNullable(5), "   5|"},
+		{"%-4s|", NewNullable(5), "5   |"},
+		{"%s", NewNullable(1.5), "1.5"},
+		{"%s", NewNullable(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)), "2024-01-02 03:04:05 +0000 UTC"},
+		{"%s", NewNullable([]byte("raw")), "raw"},
+		{"%.2s", NewNullable(12345), "12"},
+		{"%s", NewNull[int](), "null"},
+		{"%s", NewOmittable(7), "7"},
+		{"%v", NewNullable([]int{1, 2}), "[1 2]"},
+		{"%+v", row{ID: NewNullable(1)}, "{ID:1 Name:null}"},
+		{"%v", row{Name: NewNullable("Ada")}, "{null Ada}"},
+		{"%#v", NewNullable(5), "nullable.NewNullable[int](5)"},
+		{"%#v", NewNullable("x"), `nullable.NewNullable[string]("x")`},
+		{"%#v", NewNull[time.Time](), "nullable.NewNull[time.Time]()"},
+		{"%#v", NewNullable(NewNull[int]()), "nullable.NewNullable[nullable.Nullable[int]](nullable.NewNull[int]())"},
+		{"%v", NewOmittable(1), "1"},
+		{"%v", Omittable[int]{}, "absent"},
+		{"%d", NewOmittableNull[int](), "null"},
+		{"%#v", Omittable[int]{}, "nullable.Omittable[int]{}"},
+		{"%#v", NewOmittable(1), "nullable.OmittableOf(nullable.NewNullable[int](1))"},
+	}
+	for _, tc := range tests {
+		if got := fmt.Sprintf(tc.format, tc.arg); got != tc.want {
+			t.Errorf("Sprintf(%q): Expected %s, got %s", tc.format, tc.want, got)
+		}
+	}
+}
+
 func TestJSONRoundTrip(t *testing.T) {
 	type TestStruct struct {
 		Name Nullable[string] `json:"name"`
@@ -291,3 +623,106 @@ func TestDatabaseInteraction(t *testing.T) {
 		t.Error("Expected Valid to be false")
 	}
 }
+
+func TestScanBoolCoercion(t *testing.T) {
+	tests := []struct {
+		src  any
+		want bool
+	}{
+		{int64(1), true},
+		{int64(0), false},
+		{int64(2), true},
+		{[]byte{0x01}, true},
+		{[]byte{0x00}, false},
+		{[]byte("1"), true},
+		{[]byte("0"), false},
+		{"true", true},
+		{true, true},
+	}
+	for _, tt := range tests {
+		var n Nullable[bool]
+		if err := n.Scan(tt.src); err != nil {
+			t.Errorf("%v: Unexpected error: %v", tt.src, err)
+			continue
+		}
+		if !n.Valid || n.V != tt.want {
+			t.Errorf("%v: Expected %v, got %+v", tt.src, tt.want, n)
+		}
+	}
+
+	var n Nullable[bool]
+	if err := n.Scan([]byte{0x02}); err == nil {
+		t.Error("Expected error for invalid bit value")
+	}
+	if err := n.Scan(nil); err != nil || n.Valid {
+		t.Errorf("Expected null, got %+v (%v)", n, err)
+	}
+
+	var i Nullable[int64]
+	if err := i.Scan(int64(5)); err != nil || i.V != 5 {
+		t.Errorf("Expected non-bool types to be unaffected, got %+v (%v)", i, err)
+	}
+}
+
+func TestScanDirect(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	var i Nullable[int64]
+	if err := i.Scan(int64(42)); err != nil || !i.Valid || i.V != 42 {
+		t.Errorf("Expected 42, got %+v (%v)", i, err)
+	}
+	var n Nullable[int]
+	if err := n.Scan(int64(7)); err != nil || n.V != 7 {
+		t.Errorf("Expected 7, got %+v (%v)", n, err)
+	}
+	var small Nullable[int32]
+	if err := small.Scan(int64(1 << 40)); err == nil {
+		t.Errorf("Expected overflow error, got %+v", small)
+	}
+	var f Nullable[float64]
+	if err := f.Scan(int64(3)); err != nil || f.V != 3 {
+		t.Errorf("Expected fallback conversion to 3, got %+v (%v)", f, err)
+	}
+	var s Nullable[string]
+	if err := s.Scan([]byte("abc")); err != nil || s.V != "abc" {
+		t.Errorf("Expected abc, got %+v (%v)", s, err)
+	}
+	if err := s.Scan(int64(12)); err != nil || s.V != "12" {
+		t.Errorf("Expected fallback conversion to \"12\", got %+v (%v)", s, err)
+	}
+
+	src := []byte{1, 2, 3}
+	var b Nullable[[]byte]
+	if err := b.Scan(src); err != nil || len(b.V) != 3 {
+		t.Fatalf("Expected 3 bytes, got %+v (%v)", b, err)
+	}
+	src[0] = 9
+	if b.V[0] != 1 {
+		t.Error("Expected Scan to copy driver bytes")
+	}
+
+	var tm Nullable[time.Time]
+	if err := tm.Scan(ts); err != nil || !tm.V.Equal(ts) {
+		t.Errorf("Expected %v, got %+v (%v)", ts, tm, err)
+	}
+	if err := tm.Scan(nil); err != nil || tm.Valid || !tm.V.IsZero() {
+		t.Errorf("Expected null, got %+v (%v)", tm, err)
+	}
+
+	i = NewNullable(int64(5))
+	if err := i.Scan("not a number"); err == nil {
+		t.Error("Expected error")
+	}
+}
+
+func BenchmarkScan(b *testing.B) {
+	var i Nullable[int64]
+	var s Nullable[string]
+	var v any = int64(123)
+	var raw any = []byte("hello")
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = i.Scan(v)
+		_ = s.Scan(raw)
+	}
+}
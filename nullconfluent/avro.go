@@ -0,0 +1,408 @@
+package nullconfluent
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/hamba/avro/v2"
+	"github.com/manattan/nullable/internal/nullreflect"
+)
+
+// Avro serializes messages with an Avro payload for one schema. Struct
+// fields are matched to record fields by the avro struct tag, or the field
+// name when there is none.
+//
+// Nullable fields map to unions with "null", such as ["null", "string"]:
+// null Nullables are written as the null branch and valid ones as the other
+// branch, and reading the null branch yields a null Nullable. Wrapper types
+// are written as their driver.Valuer value and read with Scan.
+type Avro struct {
+	SchemaID uint32
+	Schema   avro.Schema
+}
+
+// NewAvro parses schema and returns an Avro serializer for it.
+func NewAvro(schemaID uint32, schema string) (*Avro, error) {
+	s, err := avro.Parse(schema)
+	if err != nil {
+		return nil, err
+	}
+	return &Avro{SchemaID: schemaID, Schema: s}, nil
+}
+
+// Serialize encodes v as a framed Avro message.
+func (a *Avro) Serialize(v any) ([]byte, error) {
+	native, err := toAvro(a.Schema, reflect.ValueOf(v))
+	if err != nil {
+		return nil, fmt.Errorf("nullconfluent: %w", err)
+	}
+	data, err := avro.Marshal(a.Schema, native)
+	if err != nil {
+		return nil, err
+	}
+	return append(AppendHeader(make([]byte, 0, headerSize+len(data)), a.SchemaID), data...), nil
+}
+
+// Deserialize decodes a framed Avro message into v, which must be a
+// non-nil pointer. Messages with another schema ID are rejected with a
+// *SchemaIDError.
+func (a *Avro) Deserialize(data []byte, v any) error {
+	p, err := payload(data, a.SchemaID)
+	if err != nil {
+		return err
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("nullconfluent: Deserialize needs a non-nil pointer, got %T", v)
+	}
+	var native any
+	if err := avro.Unmarshal(a.Schema, p, &native); err != nil {
+		return err
+	}
+	if err := fromAvro(a.Schema, native, rv.Elem()); err != nil {
+		return fmt.Errorf("nullconfluent: %w", err)
+	}
+	return nil
+}
+
+// toAvro converts v to the generic form hamba/avro encodes for schema s:
+// maps for records, []any for arrays, and the exact Go types its union
+// resolution expects.
+func toAvro(s avro.Schema, v reflect.Value) (any, error) {
+	v, ok, err := unwrap(v)
+	if err != nil || !ok {
+		return nil, err
+	}
+	if ref, isRef := s.(*avro.RefSchema); isRef {
+		s = ref.Schema()
+	}
+
+	switch s := s.(type) {
+	case *avro.UnionSchema:
+		var errs []string
+		for _, t := range s.Types() {
+			if t.Type() == avro.Null {
+				continue
+			}
+			native, err := toAvro(t, v)
+			if err != nil {
+				errs = append(errs, err.Error())
+				continue
+			}
+			if named, ok := t.(avro.NamedSchema); ok {
+				return map[string]any{named.FullName(): native}, nil
+			}
+			if ref, ok := t.(*avro.RefSchema); ok {
+				return map[string]any{ref.Schema().FullName(): native}, nil
+			}
+			return native, nil
+		}
+		return nil, fmt.Errorf("%s matches no branch of %s: %s", v.Type(), s, strings.Join(errs, "; "))
+	case *avro.RecordSchema:
+		return recordToAvro(s, v)
+	case *avro.ArraySchema:
+		if k := v.Kind(); k != reflect.Slice && k != reflect.Array {
+			break
+		}
+		list := make([]any, v.Len())
+		for i := range list {
+			native, err := toAvro(s.Items(), v.Index(i))
+			if err != nil {
+				return nil, fmt.Errorf("element %d: %w", i, err)
+			}
+			list[i] = native
+		}
+		return list, nil
+	case *avro.MapSchema:
+		if v.Kind() != reflect.Map || v.Type().Key().Kind() != reflect.String {
+			break
+		}
+		m := make(map[string]any, v.Len())
+		for it := v.MapRange(); it.Next(); {
+			native, err := toAvro(s.Values(), it.Value())
+			if err != nil {
+				return nil, fmt.Errorf("key %s: %w", it.Key(), err)
+			}
+			m[it.Key().String()] = native
+		}
+		return m, nil
+	default:
+		if native, ok := primitiveToAvro(s.Type(), v); ok {
+			return native, nil
+		}
+	}
+	return nil, fmt.Errorf("cannot encode %s as %s", v.Type(), s.Type())
+}
+
+var valuerType = reflect.TypeFor[driver.Valuer]()
+
+// unwrap returns the value to encode for v, or false if it is null.
+func unwrap(v reflect.Value) (reflect.Value, bool, error) {
+	for v.IsValid() {
+		t := v.Type()
+		switch {
+		case nullreflect.IsNullable(t):
+			if !nullreflect.Valid(v) {
+				return v, false, nil
+			}
+			v = nullreflect.Inner(v)
+			continue
+		case nullreflect.IsWrapper(t):
+			if !t.Implements(valuerType) {
+				v = v.Field(0)
+				continue
+			}
+			dv, err := v.Interface().(driver.Valuer).Value()
+			if err != nil {
+				return v, false, err
+			}
+			v = reflect.ValueOf(dv)
+			continue
+		}
+		switch t.Kind() {
+		case reflect.Pointer, reflect.Interface:
+			if v.IsNil() {
+				return v, false, nil
+			}
+			v = v.Elem()
+			continue
+		}
+		return v, true, nil
+	}
+	return v, false, nil
+}
+
+func recordToAvro(s *avro.RecordSchema, v reflect.Value) (any, error) {
+	var lookup func(name string) (reflect.Value, bool)
+	switch {
+	case v.Kind() == reflect.Struct:
+		lookup = func(name string) (reflect.Value, bool) { return fieldByAvroName(v, name) }
+	case v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String:
+		lookup = func(name string) (reflect.Value, bool) {
+			e := v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key()))
+			return e, e.IsValid()
+		}
+	default:
+		return nil, fmt.Errorf("cannot encode %s as record %s", v.Type(), s.FullName())
+	}
+
+	m := make(map[string]any, len(s.Fields()))
+	for _, f := range s.Fields() {
+		fv, ok := lookup(f.Name())
+		if !ok {
+			// Missing fields take the schema default when there is one.
+			continue
+		}
+		native, err := toAvro(f.Type(), fv)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", s.Name(), f.Name(), err)
+		}
+		m[f.Name()] = native
+	}
+	return m, nil
+}
+
+var (
+	timeType     = reflect.TypeFor[time.Time]()
+	durationType = reflect.TypeFor[time.Duration]()
+)
+
+// primitiveToAvro converts v to the Go type hamba/avro resolves for t.
+func primitiveToAvro(t avro.Type, v reflect.Value) (any, bool) {
+	switch v.Type() {
+	case timeType, durationType:
+		// Logical timestamp, date and time types.
+		return v.Interface(), t == avro.Long || t == avro.Int
+	}
+	switch t {
+	case avro.String, avro.Enum:
+		if v.Kind() == reflect.String {
+			return v.String(), true
+		}
+	case avro.Boolean:
+		if v.Kind() == reflect.Bool {
+			return v.Bool(), true
+		}
+	case avro.Int:
+		if v.CanInt() && v.Int() >= math.MinInt32 && v.Int() <= math.MaxInt32 {
+			return int(v.Int()), true
+		}
+		if v.CanUint() && v.Uint() <= math.MaxInt32 {
+			return int(v.Uint()), true
+		}
+	case avro.Long:
+		if v.CanInt() {
+			return v.Int(), true
+		}
+		if v.CanUint() && v.Uint() <= math.MaxInt64 {
+			return int64(v.Uint()), true
+		}
+	case avro.Float:
+		if v.CanFloat() {
+			return float32(v.Float()), true
+		}
+	case avro.Double:
+		switch {
+		case v.CanFloat():
+			return v.Float(), true
+		case v.CanInt():
+			return float64(v.Int()), true
+		}
+	case avro.Bytes:
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			return v.Bytes(), true
+		}
+	case avro.Fixed:
+		if v.Kind() == reflect.Array && v.Type().Elem().Kind() == reflect.Uint8 {
+			return v.Interface(), true
+		}
+	}
+	return nil, false
+}
+
+// fieldByAvroName returns the field of struct v named name by its avro tag
+// or Go name, searching embedded structs.
+func fieldByAvroName(v reflect.Value, name string) (reflect.Value, bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, hasTag := sf.Tag.Lookup("avro")
+		if tag == "-" {
+			continue
+		}
+		fv := v.Field(i)
+		if sf.Anonymous && !hasTag && fv.Kind() == reflect.Struct && !nullreflect.IsNullable(sf.Type) && !nullreflect.IsWrapper(sf.Type) {
+			if f, ok := fieldByAvroName(fv, name); ok {
+				return f, true
+			}
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if tag == name || !hasTag && sf.Name == name {
+			return fv, true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+var scannerType = reflect.TypeFor[sql.Scanner]()
+
+// fromAvro stores the value decoded by hamba/avro for schema s in dst.
+func fromAvro(s avro.Schema, src any, dst reflect.Value) error {
+	if ref, ok := s.(*avro.RefSchema); ok {
+		s = ref.Schema()
+	}
+	if u, ok := s.(*avro.UnionSchema); ok {
+		if src == nil {
+			dst.SetZero()
+			return nil
+		}
+		branch, value := unionBranch(u, src)
+		return fromAvro(branch, value, dst)
+	}
+	if src == nil {
+		dst.SetZero()
+		return nil
+	}
+
+	t := dst.Type()
+	switch {
+	case nullreflect.IsNullable(t):
+		if err := fromAvro(s, src, nullreflect.Inner(dst)); err != nil {
+			return err
+		}
+		nullreflect.SetValid(dst, true)
+		return nil
+	case t.Kind() == reflect.Pointer:
+		p := reflect.New(t.Elem())
+		if err := fromAvro(s, src, p.Elem()); err != nil {
+			return err
+		}
+		dst.Set(p)
+		return nil
+	case reflect.PointerTo(t).Implements(scannerType):
+		return dst.Addr().Interface().(sql.Scanner).Scan(src)
+	}
+
+	switch s := s.(type) {
+	case *avro.RecordSchema:
+		m, ok := src.(map[string]any)
+		if !ok || dst.Kind() != reflect.Struct {
+			break
+		}
+		for _, f := range s.Fields() {
+			fv, ok := fieldByAvroName(dst, f.Name())
+			if !ok {
+				continue
+			}
+			if err := fromAvro(f.Type(), m[f.Name()], fv); err != nil {
+				return fmt.Errorf("%s.%s: %w", s.Name(), f.Name(), err)
+			}
+		}
+		return nil
+	case *avro.ArraySchema:
+		list, ok := src.([]any)
+		if !ok || dst.Kind() != reflect.Slice {
+			break
+		}
+		out := reflect.MakeSlice(t, len(list), len(list))
+		for i, e := range list {
+			if err := fromAvro(s.Items(), e, out.Index(i)); err != nil {
+				return fmt.Errorf("element %d: %w", i, err)
+			}
+		}
+		dst.Set(out)
+		return nil
+	case *avro.MapSchema:
+		m, ok := src.(map[string]any)
+		if !ok || dst.Kind() != reflect.Map || t.Key().Kind() != reflect.String {
+			break
+		}
+		out := reflect.MakeMapWithSize(t, len(m))
+		for k, e := range m {
+			ev := reflect.New(t.Elem()).Elem()
+			if err := fromAvro(s.Values(), e, ev); err != nil {
+				return fmt.Errorf("key %s: %w", k, err)
+			}
+			out.SetMapIndex(reflect.ValueOf(k).Convert(t.Key()), ev)
+		}
+		dst.Set(out)
+		return nil
+	}
+	return nullreflect.Assign(dst, reflect.ValueOf(src))
+}
+
+// unionBranch returns the schema and value of the non-null branch held by
+// src. hamba/avro decodes named branches as a single-entry map keyed by the
+// type name, and other branches as the bare value.
+func unionBranch(u *avro.UnionSchema, src any) (avro.Schema, any) {
+	var other avro.Schema
+	for _, t := range u.Types() {
+		if t.Type() == avro.Null {
+			continue
+		}
+		name := ""
+		switch n := t.(type) {
+		case avro.NamedSchema:
+			name = n.FullName()
+		case *avro.RefSchema:
+			name = n.Schema().FullName()
+		}
+		if m, ok := src.(map[string]any); ok && name != "" && len(m) == 1 {
+			if value, ok := m[name]; ok {
+				return t, value
+			}
+		}
+		if other == nil {
+			other = t
+		}
+	}
+	return other, src
+}
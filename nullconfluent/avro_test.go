@@ -0,0 +1,163 @@
+package nullconfluent
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/manattan/nullable"
+)
+
+const userSchema = `{
+	"type": "record",
+	"name": "User",
+	"namespace": "example",
+	"fields": [
+		{"name": "name", "type": "string"},
+		{"name": "email", "type": ["null", "string"], "default": null},
+		{"name": "age", "type": ["null", "int"], "default": null},
+		{"name": "visits", "type": ["null", "long"], "default": null},
+		{"name": "score", "type": ["null", "double"], "default": null},
+		{"name": "seen", "type": ["null", {"type": "long", "logicalType": "timestamp-millis"}], "default": null},
+		{"name": "mac", "type": ["null", "string"], "default": null},
+		{"name": "tags", "type": {"type": "array", "items": ["null", "string"]}, "default": []},
+		{"name": "address", "type": ["null", {
+			"type": "record",
+			"name": "Address",
+			"fields": [{"name": "city", "type": ["null", "string"], "default": null}]
+		}], "default": null}
+	]
+}`
+
+type address struct {
+	City nullable.Nullable[string] `avro:"city"`
+}
+
+type user struct {
+	Name    string                       `avro:"name"`
+	Email   nullable.Nullable[string]    `avro:"email"`
+	Age     nullable.Nullable[int]       `avro:"age"`
+	Visits  nullable.Nullable[uint32]    `avro:"visits"`
+	Score   nullable.Nullable[float64]   `avro:"score"`
+	Seen    nullable.Nullable[time.Time] `avro:"seen"`
+	MAC     nullable.HardwareAddr        `avro:"mac"`
+	Tags    []nullable.Nullable[string]  `avro:"tags"`
+	Address nullable.Nullable[address]   `avro:"address"`
+}
+
+func TestAvroRoundTrip(t *testing.T) {
+	s, err := NewAvro(42, userSchema)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	mac, _ := net.ParseMAC("00:1a:2b:3c:4d:5e")
+	seen := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	in := user{
+		Name:    "Ada",
+		Age:     nullable.NewNullable(36),
+		Visits:  nullable.NewNullable[uint32](3),
+		Seen:    nullable.NewNullable(seen),
+		MAC:     nullable.NewHardwareAddr(mac),
+		Tags:    []nullable.Nullable[string]{nullable.NewNullable("a"), nullable.NewNull[string]()},
+		Address: nullable.NewNullable(address{City: nullable.NewNullable("Paris")}),
+	}
+	data, err := s.Serialize(in)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if id, _, err := SplitHeader(data); err != nil || id != 42 {
+		t.Errorf("Expected schema ID 42, got %d (%v)", id, err)
+	}
+
+	var out user
+	if err := s.Deserialize(data, &out); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	switch {
+	case out.Name != "Ada":
+		t.Errorf("Expected name Ada, got %q", out.Name)
+	case out.Email.Valid || out.Score.Valid:
+		t.Errorf("Expected null email and score, got %v %v", out.Email, out.Score)
+	case out.Age != in.Age || out.Visits != in.Visits:
+		t.Errorf("Expected age 36 and visits 3, got %v %v", out.Age, out.Visits)
+	case !out.Seen.Valid || !out.Seen.V.Equal(seen):
+		t.Errorf("Expected seen %v, got %v", seen, out.Seen)
+	case out.MAC.String() != "00:1a:2b:3c:4d:5e":
+		t.Errorf("Expected MAC, got %v", out.MAC)
+	case len(out.Tags) != 2 || out.Tags[0].V != "a" || out.Tags[1].Valid:
+		t.Errorf("Expected tags [a null], got %v", out.Tags)
+	case !out.Address.Valid || out.Address.V.City.V != "Paris":
+		t.Errorf("Expected address in Paris, got %+v", out.Address)
+	}
+
+	data, err = s.Serialize(&user{Name: "Bob"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	out = user{}
+	if err := s.Deserialize(data, &out); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if out.Name != "Bob" || out.Age.Valid || out.MAC.Valid || out.Address.Valid {
+		t.Errorf("Expected Bob with null fields, got %+v", out)
+	}
+}
+
+func TestAvroMap(t *testing.T) {
+	s, err := NewAvro(1, userSchema)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	data, err := s.Serialize(map[string]any{
+		"name":  "Ada",
+		"email": nullable.NewNullable("ada@example.com"),
+		"tags":  []string{"x"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var out user
+	if err := s.Deserialize(data, &out); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if out.Email.V != "ada@example.com" || out.Age.Valid || len(out.Tags) != 1 {
+		t.Errorf("Expected email and one tag, got %+v", out)
+	}
+}
+
+func TestAvroErrors(t *testing.T) {
+	s, err := NewAvro(1, userSchema)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := NewAvro(1, `{"type":"nope"}`); err == nil {
+		t.Error("Expected error for invalid schema")
+	}
+
+	type wrong struct {
+		Name string                  `avro:"name"`
+		Age  nullable.Nullable[bool] `avro:"age"`
+	}
+	if _, err := s.Serialize(wrong{Name: "x", Age: nullable.NewNullable(true)}); err == nil || !strings.Contains(err.Error(), "User.age") {
+		t.Errorf("Expected error naming User.age, got %v", err)
+	}
+	big := user{Name: "x", Age: nullable.NewNullable(1 << 40)}
+	if _, err := s.Serialize(big); err == nil {
+		t.Error("Expected error for int overflowing Avro int")
+	}
+
+	data, _ := s.Serialize(user{Name: "x"})
+	var out user
+	var idErr *SchemaIDError
+	if err := (&Avro{SchemaID: 2, Schema: s.Schema}).Deserialize(data, &out); !errors.As(err, &idErr) {
+		t.Errorf("Expected *SchemaIDError, got %v", err)
+	}
+	if err := s.Deserialize(data, out); err == nil {
+		t.Error("Expected error for non-pointer")
+	}
+	if err := s.Deserialize([]byte("plain"), &out); !errors.Is(err, ErrWireFormat) {
+		t.Errorf("Expected ErrWireFormat, got %v", err)
+	}
+}
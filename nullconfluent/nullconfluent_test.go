@@ -0,0 +1,59 @@
+package nullconfluent
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/manattan/nullable"
+)
+
+func TestHeader(t *testing.T) {
+	data := AppendHeader(nil, 258)
+	if want := []byte{0, 0, 0, 1, 2}; !bytes.Equal(data, want) {
+		t.Errorf("Expected %v, got %v", want, data)
+	}
+	id, p, err := SplitHeader(append(data, 'x'))
+	if err != nil || id != 258 || string(p) != "x" {
+		t.Errorf("Expected 258 and x, got %d %q (%v)", id, p, err)
+	}
+	for _, bad := range [][]byte{nil, {0, 0, 1}, {1, 0, 0, 0, 1}} {
+		if _, _, err := SplitHeader(bad); !errors.Is(err, ErrWireFormat) {
+			t.Errorf("%v: expected ErrWireFormat, got %v", bad, err)
+		}
+	}
+}
+
+func TestJSON(t *testing.T) {
+	type user struct {
+		Name  string                    `json:"name"`
+		Email nullable.Nullable[string] `json:"email"`
+		Age   nullable.Nullable[int]    `json:"age"`
+	}
+	s := JSON{SchemaID: 7}
+	data, err := s.Serialize(user{Name: "Ada", Age: nullable.NewNullable(36)})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := "\x00\x00\x00\x00\x07" + `{"name":"Ada","email":null,"age":36}`; string(data) != want {
+		t.Errorf("Expected %q, got %q", want, data)
+	}
+
+	var got user
+	if err := s.Deserialize(data, &got); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got.Name != "Ada" || got.Email.Valid || got.Age.V != 36 {
+		t.Errorf("Expected Ada with null email, got %+v", got)
+	}
+
+	var idErr *SchemaIDError
+	if err := (JSON{SchemaID: 8}).Deserialize(data, &got); !errors.As(err, &idErr) || idErr.Got != 7 || idErr.Want != 8 {
+		t.Errorf("Expected *SchemaIDError, got %v", err)
+	}
+
+	lenient := JSON{SchemaID: 7, Options: []nullable.DecodeOption{nullable.CoerceNumericStrings()}}
+	if err := lenient.Deserialize(append(AppendHeader(nil, 7), `{"age":"41"}`...), &got); err != nil || got.Age.V != 41 {
+		t.Errorf("Expected 41 with options, got %+v (%v)", got.Age, err)
+	}
+}
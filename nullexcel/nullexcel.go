@@ -0,0 +1,192 @@
+// Package nullexcel writes structs with nullable.Nullable fields to excelize
+// worksheets. Null values, including null wrapper types such as
+// nullable.Email, and nil pointers leave blank cells, and non-null
+// cells get a number format matching their type:
+//
+//	f := excelize.NewFile()
+//	err := nullexcel.WriteRows(f, "Sheet1", users)
+//	err = f.SaveAs("users.xlsx")
+//
+// Column headers are the Go field names, or the name in an excel struct
+// tag; excel:"-" skips a field.
+package nullexcel
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/manattan/nullable"
+	"github.com/manattan/nullable/internal/nullreflect"
+	"github.com/xuri/excelize/v2"
+)
+
+// Number formats applied to non-null cells by type.
+const (
+	DateTimeFormat = "yyyy-mm-dd hh:mm:ss"
+	IntegerFormat  = "0"
+	DecimalFormat  = "0.00##########"
+)
+
+type column struct {
+	header string
+	index  int
+	format string
+}
+
+// WriteRows writes rows, a slice of structs or struct pointers, to sheet
+// starting at cell A1: a header row followed by one row per element. The
+// sheet is created if it does not exist.
+func WriteRows(f *excelize.File, sheet string, rows any) error {
+	rv := reflect.ValueOf(rows)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return fmt.Errorf("nullexcel: rows must be a slice, got %T", rows)
+	}
+	et := rv.Type().Elem()
+	if et.Kind() == reflect.Pointer {
+		et = et.Elem()
+	}
+	if et.Kind() != reflect.Struct {
+		return fmt.Errorf("nullexcel: rows must contain structs, got %s", rv.Type())
+	}
+	if idx, err := f.GetSheetIndex(sheet); err != nil {
+		return err
+	} else if idx < 0 {
+		if _, err := f.NewSheet(sheet); err != nil {
+			return err
+		}
+	}
+
+	cols := columns(et)
+	styles := make(map[string]int)
+	for _, c := range cols {
+		if c.format == "" || styles[c.format] != 0 {
+			continue
+		}
+		format := c.format
+		id, err := f.NewStyle(&excelize.Style{CustomNumFmt: &format})
+		if err != nil {
+			return err
+		}
+		styles[c.format] = id
+	}
+
+	for i, c := range cols {
+		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
+		if err := f.SetCellStr(sheet, cell, c.header); err != nil {
+			return err
+		}
+	}
+	for r := 0; r < rv.Len(); r++ {
+		ev := rv.Index(r)
+		if ev.Kind() == reflect.Pointer {
+			if ev.IsNil() {
+				continue
+			}
+			ev = ev.Elem()
+		}
+		for i, c := range cols {
+			v, ok := cellValue(ev.Field(c.index))
+			if !ok {
+				continue
+			}
+			cell, _ := excelize.CoordinatesToCellName(i+1, r+2)
+			if err := f.SetCellValue(sheet, cell, v); err != nil {
+				return err
+			}
+			if id := styles[c.format]; id != 0 {
+				if err := f.SetCellStyle(sheet, cell, cell, id); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func columns(t reflect.Type) []column {
+	var cols []column
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		header := sf.Name
+		if tag, ok := sf.Tag.Lookup("excel"); ok {
+			if tag == "-" {
+				continue
+			}
+			if tag != "" {
+				header = tag
+			}
+		}
+		cols = append(cols, column{header: header, index: i, format: formatFor(sf.Type)})
+	}
+	return cols
+}
+
+var (
+	timeType    = reflect.TypeFor[time.Time]()
+	decimalType = reflect.TypeFor[nullable.Decimal]()
+)
+
+// formatFor returns the number format for cells of type t, or "" for the
+// General format.
+func formatFor(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer || nullreflect.IsNullable(t) || nullreflect.IsWrapper(t) {
+		switch {
+		case t.Kind() == reflect.Pointer:
+			t = t.Elem()
+		case nullreflect.IsWrapper(t):
+			t = t.Field(0).Type
+		default:
+			f, _ := t.FieldByName("V")
+			t = f.Type
+		}
+	}
+	switch {
+	case t == timeType:
+		return DateTimeFormat
+	case t == decimalType:
+		return DecimalFormat
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return IntegerFormat
+	}
+	return ""
+}
+
+// cellValue returns the value to write for v, reporting false for null
+// values and nil pointers, which leave the cell blank. Wrapper types such
+// as nullable.ZeroAsNull and nullable.Email are written as the value of
+// their Nullable.
+func cellValue(v reflect.Value) (any, bool) {
+	for v.Kind() == reflect.Pointer || nullreflect.IsNullable(v.Type()) || nullreflect.IsWrapper(v.Type()) {
+		switch {
+		case v.Kind() == reflect.Pointer:
+			if v.IsNil() {
+				return nil, false
+			}
+			v = v.Elem()
+		case nullreflect.IsWrapper(v.Type()):
+			v = v.Field(0)
+		default:
+			if !nullreflect.Valid(v) {
+				return nil, false
+			}
+			v = nullreflect.Inner(v)
+		}
+	}
+	switch x := v.Interface().(type) {
+	case nullable.Decimal:
+		f, _ := x.Float64()
+		return f, true
+	case time.Time, bool, string, []byte:
+		return x, true
+	case fmt.Stringer:
+		return x.String(), true
+	}
+	return v.Interface(), true
+}
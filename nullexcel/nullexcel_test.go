@@ -0,0 +1,134 @@
+package nullexcel
+
+import (
+	"testing"
+	"time"
+
+	"github.com/manattan/nullable"
+	"github.com/xuri/excelize/v2"
+)
+
+type user struct {
+	ID      int64
+	Name    nullable.Nullable[string] `excel:"Full Name"`
+	Age     nullable.Nullable[int]
+	Balance nullable.Nullable[nullable.Decimal]
+	Joined  nullable.Nullable[time.Time]
+	Nick    *string
+	Active  bool
+	secret  string
+	Skip    string `excel:"-"`
+}
+
+func TestWriteRows(t *testing.T) {
+	nick := "ada"
+	joined := time.Date(2024, 3, 5, 10, 30, 0, 0, time.UTC)
+	rows := []user{
+		{ID: 1, Name: nullable.NewNullable("Ada"), Age: nullable.NewNullable(36), Balance: nullable.NewNullable(nullable.MustParseDecimal("12.5")), Joined: nullable.NewNullable(joined), Nick: &nick, Active: true},
+		{ID: 2},
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+	if err := WriteRows(f, "Users", rows); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	got, err := f.GetRows("Users", excelize.Options{RawCellValue: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := [][]string{
+		{"ID", "Full Name", "Age", "Balance", "Joined", "Nick", "Active"},
+		{"1", "Ada", "36", "12.5", "45356.4375", "ada", "1"},
+		{"2", "", "", "", "", "", "0"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d rows, got %d: %v", len(want), len(got), got)
+	}
+	for i := range want {
+		for j := range want[i] {
+			var cell string
+			if j < len(got[i]) {
+				cell = got[i][j]
+			}
+			if cell != want[i][j] {
+				t.Errorf("Row %d column %d: Expected %q, got %q", i+1, j+1, want[i][j], cell)
+			}
+		}
+	}
+
+	for cell, format := range map[string]string{"A2": IntegerFormat, "C2": IntegerFormat, "D2": DecimalFormat, "E2": DateTimeFormat} {
+		id, err := f.GetCellStyle("Users", cell)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		style, err := f.GetStyle(id)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if style.CustomNumFmt == nil || *style.CustomNumFmt != format {
+			t.Errorf("%s: Expected format %q, got %+v", cell, format, style.CustomNumFmt)
+		}
+	}
+	if id, _ := f.GetCellStyle("Users", "C3"); id != 0 {
+		t.Errorf("Expected blank null cell to be unstyled, got style %d", id)
+	}
+}
+
+func TestWriteRowsPointers(t *testing.T) {
+	f := excelize.NewFile()
+	defer f.Close()
+	rows := []*user{{ID: 7}, nil}
+	if err := WriteRows(f, "Sheet1", rows); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if v, _ := f.GetCellValue("Sheet1", "A2"); v != "7" {
+		t.Errorf("Expected 7, got %q", v)
+	}
+}
+
+func TestWriteRowsInvalid(t *testing.T) {
+	f := excelize.NewFile()
+	defer f.Close()
+	if err := WriteRows(f, "Sheet1", user{}); err == nil {
+		t.Error("Expected error for non-slice")
+	}
+	if err := WriteRows(f, "Sheet1", []int{1}); err == nil {
+		t.Error("Expected error for non-struct elements")
+	}
+}
+
+func TestWriteRowsWrappers(t *testing.T) {
+	type account struct {
+		Email   nullable.Email
+		Seats   nullable.ZeroAsNull[int]
+		Created nullable.UnixTime
+		Plan    nullable.Omittable[string]
+	}
+	created := time.Date(2024, 3, 5, 10, 30, 0, 0, time.UTC)
+	email, _ := nullable.NewEmail("ada@example.com")
+	rows := []account{
+		{Email: email, Seats: nullable.NewZeroAsNull(3), Created: nullable.NewUnixTime(created), Plan: nullable.NewOmittable("pro")},
+		{Plan: nullable.NewOmittableNull[string]()},
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+	if err := WriteRows(f, "Sheet1", rows); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for cell, want := range map[string]string{
+		"A2": "ada@example.com", "B2": "3", "C2": "45356.4375", "D2": "pro",
+		"A3": "", "B3": "", "C3": "", "D3": "",
+	} {
+		if got, _ := f.GetCellValue("Sheet1", cell, excelize.Options{RawCellValue: true}); got != want {
+			t.Errorf("%s: Expected %q, got %q", cell, want, got)
+		}
+	}
+
+	id, _ := f.GetCellStyle("Sheet1", "C2")
+	if style, _ := f.GetStyle(id); style == nil || style.CustomNumFmt == nil || *style.CustomNumFmt != DateTimeFormat {
+		t.Errorf("Expected UnixTime to use the date-time format, got style %d", id)
+	}
+}
@@ -0,0 +1,197 @@
+// Package nullfake fills structs containing nullable.Nullable fields with
+// fake data from gofakeit, for load tests and demo data seeding.
+package nullfake
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/brianvoe/gofakeit/v7"
+
+	"github.com/manattan/nullable/internal/nullreflect"
+)
+
+// DefaultNullRate is the probability of a Nullable field being null when
+// neither NullRate nor a nullrate struct tag sets one.
+const DefaultNullRate = 0.2
+
+// Option configures Fill.
+type Option func(*options)
+
+type options struct {
+	rate float64
+}
+
+// NullRate sets the probability, between 0 and 1, of a Nullable field being
+// null for fields without a nullrate struct tag.
+func NullRate(rate float64) Option {
+	return func(o *options) {
+		o.rate = rate
+	}
+}
+
+// Fill fills the struct pointed to by v with fake data from f. Fields are
+// generated as by f.Struct, honouring the fake and fakesize tags, except that
+// each Nullable field is null with the configured probability and otherwise
+// holds a value generated from the field's fake tag. A nullrate tag sets the
+// probability for a single field:
+//
+//	type User struct {
+//		Name  string                    `fake:"{name}"`
+//		Email nullable.Nullable[string] `fake:"{email}" nullrate:"0.5"`
+//		Phone nullable.Nullable[string] `fake:"{phone}" nullrate:"0"`
+//	}
+//
+//	var u User
+//	err := nullfake.Fill(gofakeit.New(42), &u, nullfake.NullRate(0.1))
+//
+// Nullable fields are also reached through nested structs, pointers, slices
+// and arrays.
+func Fill(f *gofakeit.Faker, v any, opts ...Option) error {
+	o := options{rate: DefaultNullRate}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.rate < 0 || o.rate > 1 {
+		return fmt.Errorf("nullfake: null rate %v outside [0, 1]", o.rate)
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("nullfake: Fill requires a non-nil pointer to a struct, got %T", v)
+	}
+	return fillStruct(f, rv.Elem(), &o, 0)
+}
+
+func fillStruct(f *gofakeit.Faker, v reflect.Value, o *options, depth int) error {
+	if depth >= gofakeit.RecursiveDepth {
+		return nil
+	}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() && !sf.Anonymous {
+			continue
+		}
+		if tag := sf.Tag.Get("fake"); tag == "skip" || tag == "-" {
+			continue
+		}
+		if err := fillValue(f, v.Field(i), sf.Tag, o, depth+1); err != nil {
+			return fmt.Errorf("%s.%s: %w", t.Name(), sf.Name, err)
+		}
+	}
+	return nil
+}
+
+// fillValue fills v, whose struct field carries tag.
+func fillValue(f *gofakeit.Faker, v reflect.Value, tag reflect.StructTag, o *options, depth int) error {
+	t := v.Type()
+	if nullreflect.IsNullable(t) {
+		return fillNullable(f, v, tag, o, depth)
+	}
+	if !hasNullable(t) {
+		return generate(f, v, tag)
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		return fillStruct(f, v, o, depth)
+	case reflect.Pointer:
+		v.Set(reflect.New(t.Elem()))
+		return fillValue(f, v.Elem(), tag, o, depth)
+	case reflect.Slice:
+		n, err := size(f, tag)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.MakeSlice(t, n, n))
+		fallthrough
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := fillValue(f, v.Index(i), tag, o, depth); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fillNullable makes the Nullable v null or fills its value.
+func fillNullable(f *gofakeit.Faker, v reflect.Value, tag reflect.StructTag, o *options, depth int) error {
+	rate := o.rate
+	if s, ok := tag.Lookup("nullrate"); ok {
+		r, err := strconv.ParseFloat(s, 64)
+		if err != nil || r < 0 || r > 1 {
+			return fmt.Errorf("invalid nullrate tag %q", s)
+		}
+		rate = r
+	}
+
+	v.SetZero()
+	if f.Float64() < rate {
+		return nil
+	}
+	if err := fillValue(f, nullreflect.Inner(v), tag, o, depth); err != nil {
+		return err
+	}
+	v.Field(0).FieldByName("Valid").SetBool(true)
+	return nil
+}
+
+// generate fills v with gofakeit as if it were a struct field with tag.
+func generate(f *gofakeit.Faker, v reflect.Value, tag reflect.StructTag) error {
+	holder := reflect.New(reflect.StructOf([]reflect.StructField{{Name: "V", Type: v.Type(), Tag: tag}}))
+	if err := f.Struct(holder.Interface()); err != nil {
+		return err
+	}
+	v.Set(holder.Elem().Field(0))
+	return nil
+}
+
+// size returns the length of a generated slice, from the fakesize tag
+// ("n" or "min,max") or between 1 and 10 like gofakeit.
+func size(f *gofakeit.Faker, tag reflect.StructTag) (int, error) {
+	s, ok := tag.Lookup("fakesize")
+	if !ok {
+		return f.IntRange(1, 10), nil
+	}
+	lo, hi, isRange := strings.Cut(s, ",")
+	n, err := strconv.Atoi(strings.TrimSpace(lo))
+	if err != nil || !isRange {
+		return n, err
+	}
+	m, err := strconv.Atoi(strings.TrimSpace(hi))
+	if err != nil {
+		return 0, err
+	}
+	return f.IntRange(n, m), nil
+}
+
+// hasNullable reports whether values of t can contain a Nullable that Fill
+// must reach itself.
+func hasNullable(t reflect.Type) bool {
+	return containsNullable(t, make(map[reflect.Type]bool))
+}
+
+func containsNullable(t reflect.Type, seen map[reflect.Type]bool) bool {
+	if seen[t] {
+		return false
+	}
+	seen[t] = true
+	switch t.Kind() {
+	case reflect.Struct:
+		if nullreflect.IsNullable(t) {
+			return true
+		}
+		for i := 0; i < t.NumField(); i++ {
+			if containsNullable(t.Field(i).Type, seen) {
+				return true
+			}
+		}
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		return containsNullable(t.Elem(), seen)
+	}
+	return false
+}
@@ -0,0 +1,121 @@
+package nullfake
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+
+	"github.com/manattan/nullable"
+)
+
+type address struct {
+	City nullable.Nullable[string] `fake:"{city}" nullrate:"0"`
+}
+
+type user struct {
+	Name     string                       `fake:"{firstname}"`
+	Email    nullable.Nullable[string]    `fake:"{email}" nullrate:"0"`
+	Phone    nullable.Nullable[string]    `fake:"{phone}" nullrate:"1"`
+	Age      nullable.Nullable[int]       `fake:"{number:18,90}"`
+	Birthday nullable.Nullable[time.Time] `nullrate:"0"`
+	Skipped  nullable.Nullable[string]    `fake:"skip"`
+	Home     *address
+	Others   []address                   `fakesize:"3"`
+	Tags     []nullable.Nullable[string] `fake:"{word}" fakesize:"2" nullrate:"0"`
+	internal nullable.Nullable[string]
+}
+
+func TestFill(t *testing.T) {
+	var u user
+	if err := Fill(gofakeit.New(1), &u); err != nil {
+		t.Fatal(err)
+	}
+	if u.Name == "" {
+		t.Error("Expected Name to be filled")
+	}
+	if !u.Email.Valid || !strings.Contains(u.Email.V, "@") {
+		t.Errorf("Expected a valid email, got %v", u.Email)
+	}
+	if u.Phone.Valid {
+		t.Errorf("Expected Phone to be null, got %v", u.Phone)
+	}
+	if u.Age.Valid && (u.Age.V < 18 || u.Age.V > 90) {
+		t.Errorf("Expected Age between 18 and 90, got %d", u.Age.V)
+	}
+	if !u.Birthday.Valid || u.Birthday.V.IsZero() {
+		t.Errorf("Expected a valid birthday, got %v", u.Birthday)
+	}
+	if u.Skipped.Valid || u.internal.Valid {
+		t.Error("Expected skipped and unexported fields to stay null")
+	}
+	if u.Home == nil || !u.Home.City.Valid || u.Home.City.V == "" {
+		t.Errorf("Expected a nested city, got %+v", u.Home)
+	}
+	if len(u.Others) != 3 || !u.Others[2].City.Valid {
+		t.Errorf("Expected 3 addresses with cities, got %+v", u.Others)
+	}
+	if len(u.Tags) != 2 || !u.Tags[0].Valid || u.Tags[0].V == "" {
+		t.Errorf("Expected 2 valid tags, got %v", u.Tags)
+	}
+}
+
+func TestFillNullRate(t *testing.T) {
+	type row struct {
+		V nullable.Nullable[int]
+	}
+	f := gofakeit.New(7)
+	for _, tt := range []struct {
+		rate     float64
+		min, max int
+	}{
+		{0, 0, 0},
+		{1, 1000, 1000},
+		{0.3, 220, 380},
+	} {
+		nulls := 0
+		for i := 0; i < 1000; i++ {
+			var r row
+			if err := Fill(f, &r, NullRate(tt.rate)); err != nil {
+				t.Fatal(err)
+			}
+			if !r.V.Valid {
+				nulls++
+			}
+		}
+		if nulls < tt.min || nulls > tt.max {
+			t.Errorf("rate %v: Expected between %d and %d nulls, got %d", tt.rate, tt.min, tt.max, nulls)
+		}
+	}
+}
+
+func TestFillDeterministic(t *testing.T) {
+	var a, b user
+	if err := Fill(gofakeit.New(99), &a); err != nil {
+		t.Fatal(err)
+	}
+	if err := Fill(gofakeit.New(99), &b); err != nil {
+		t.Fatal(err)
+	}
+	if a.Name != b.Name || a.Email != b.Email || a.Age != b.Age {
+		t.Errorf("Expected equal output for equal seeds, got %+v and %+v", a, b)
+	}
+}
+
+func TestFillErrors(t *testing.T) {
+	var u user
+	if err := Fill(gofakeit.New(1), u); err == nil {
+		t.Error("Expected an error for a non-pointer")
+	}
+	if err := Fill(gofakeit.New(1), &u, NullRate(2)); err == nil {
+		t.Error("Expected an error for a rate above 1")
+	}
+	type bad struct {
+		V nullable.Nullable[int] `nullrate:"often"`
+	}
+	err := Fill(gofakeit.New(1), &bad{})
+	if err == nil || !strings.Contains(err.Error(), `invalid nullrate tag "often"`) {
+		t.Errorf("Expected an invalid tag error, got %v", err)
+	}
+}
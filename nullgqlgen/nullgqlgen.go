@@ -0,0 +1,116 @@
+// Package nullgqlgen converts between gqlgen's graphql.Omittable inputs and
+// nullable.Nullable values.
+//
+// gqlgen models optional nullable input fields as graphql.Omittable[*T], which
+// distinguishes an omitted field from an explicit null. These helpers carry
+// that distinction into Nullable fields plus a presence flag, the form used
+// by nullhttp.Presence and the patch structs generated by nullgen:
+//
+//	func (r *mutationResolver) UpdateUser(ctx context.Context, id string, input model.UserInput) (*model.User, error) {
+//		var p UserPatch
+//		if nullgqlgen.Assign(&p.Email, input.Email) {
+//			p.Mark(UserPatchEmail)
+//		}
+//		...
+//	}
+package nullgqlgen
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/99designs/gqlgen/graphql"
+
+	"github.com/manattan/nullable"
+	"github.com/manattan/nullable/internal/nullreflect"
+	"github.com/manattan/nullable/nullhttp"
+)
+
+// FromOmittable converts o to a Nullable and reports whether o was set. An
+// explicit null converts to a null Nullable with set true.
+func FromOmittable[T any](o graphql.Omittable[*T]) (n nullable.Nullable[T], set bool) {
+	p, ok := o.ValueOK()
+	if !ok {
+		return nullable.NewNull[T](), false
+	}
+	return nullable.FromPtr(p), true
+}
+
+// ToOmittable converts n to a set Omittable holding nil when n is null.
+func ToOmittable[T any](n nullable.Nullable[T]) graphql.Omittable[*T] {
+	return graphql.OmittableOf(n.Ptr())
+}
+
+// Assign stores o in dst if o was set, including explicit nulls, and
+// reports whether it did. Omitted inputs leave dst unchanged.
+func Assign[T any](dst *nullable.Nullable[T], o graphql.Omittable[*T]) bool {
+	n, set := FromOmittable(o)
+	if set {
+		*dst = n
+	}
+	return set
+}
+
+// omittable is implemented by every graphql.Omittable instantiation.
+type omittable interface {
+	IsSet() bool
+}
+
+// Presence returns the JSON names of the set Omittable fields of the input
+// struct held in or pointed to by input, for use with code built around
+// nullhttp.Presence. Other fields are present unless they are nil pointers.
+// Fields of nested input objects are reported with dotted paths, e.g.
+// "address.city".
+func Presence(input any) nullhttp.Presence {
+	presence := make(nullhttp.Presence)
+	v := reflect.ValueOf(input)
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Struct {
+		collect(v, "", presence)
+	}
+	return presence
+}
+
+func collect(v reflect.Value, prefix string, presence nullhttp.Presence) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name, ok := nullreflect.JSONName(t.Field(i))
+		if !ok {
+			continue
+		}
+		path := prefix + name
+
+		fv := v.Field(i)
+		if o, ok := fv.Interface().(omittable); ok {
+			if !o.IsSet() {
+				continue
+			}
+			presence[path] = struct{}{}
+			// Descend into set input objects.
+			if inner := reflect.Indirect(fv.MethodByName("Value").Call(nil)[0]); inner.Kind() == reflect.Struct && !isScalar(inner.Type()) {
+				collect(inner, path+".", presence)
+			}
+			continue
+		}
+		if fv.Kind() == reflect.Pointer && fv.IsNil() {
+			continue
+		}
+		presence[path] = struct{}{}
+		if inner := reflect.Indirect(fv); inner.Kind() == reflect.Struct && !isScalar(inner.Type()) {
+			collect(inner, path+".", presence)
+		}
+	}
+}
+
+// isScalar reports whether values of the struct type t decode themselves,
+// as scalars such as time.Time and Nullable do, rather than being input
+// objects.
+func isScalar(t reflect.Type) bool {
+	switch reflect.New(t).Interface().(type) {
+	case json.Unmarshaler, graphql.Unmarshaler, graphql.ContextUnmarshaler:
+		return true
+	}
+	return false
+}
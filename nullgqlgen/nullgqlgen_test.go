@@ -0,0 +1,85 @@
+package nullgqlgen
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+
+	"github.com/manattan/nullable"
+)
+
+func TestFromOmittable(t *testing.T) {
+	s := "a@example.com"
+	tests := []struct {
+		name    string
+		in      graphql.Omittable[*string]
+		want    nullable.Nullable[string]
+		wantSet bool
+	}{
+		{"omitted", graphql.Omittable[*string]{}, nullable.NewNull[string](), false},
+		{"null", graphql.OmittableOf[*string](nil), nullable.NewNull[string](), true},
+		{"value", graphql.OmittableOf(&s), nullable.NewNullable(s), true},
+	}
+	for _, tt := range tests {
+		got, set := FromOmittable(tt.in)
+		if got != tt.want || set != tt.wantSet {
+			t.Errorf("%s: Expected %v, %v, got %v, %v", tt.name, tt.want, tt.wantSet, got, set)
+		}
+	}
+}
+
+func TestToOmittable(t *testing.T) {
+	o := ToOmittable(nullable.NewNullable(3))
+	if v, ok := o.ValueOK(); !ok || v == nil || *v != 3 {
+		t.Errorf("Expected a set 3, got %v, %v", v, ok)
+	}
+	o = ToOmittable(nullable.NewNull[int]())
+	if v, ok := o.ValueOK(); !ok || v != nil {
+		t.Errorf("Expected a set nil, got %v, %v", v, ok)
+	}
+}
+
+func TestAssign(t *testing.T) {
+	dst := nullable.NewNullable("keep")
+	if Assign(&dst, graphql.Omittable[*string]{}) || dst != nullable.NewNullable("keep") {
+		t.Errorf("Expected omitted input to leave dst unchanged, got %v", dst)
+	}
+	if !Assign(&dst, graphql.OmittableOf[*string](nil)) || dst.Valid {
+		t.Errorf("Expected null input to clear dst, got %v", dst)
+	}
+}
+
+type addressInput struct {
+	City graphql.Omittable[*string] `json:"city,omitempty"`
+	Zip  graphql.Omittable[*string] `json:"zip,omitempty"`
+}
+
+type userInput struct {
+	ID       string                           `json:"id"`
+	Email    graphql.Omittable[*string]       `json:"email,omitempty"`
+	Nickname graphql.Omittable[*string]       `json:"nickname,omitempty"`
+	Birthday graphql.Omittable[*time.Time]    `json:"birthday,omitempty"`
+	Address  graphql.Omittable[*addressInput] `json:"address,omitempty"`
+	Note     *string                          `json:"note"`
+	Dash     graphql.Omittable[*string]       `json:"-,"`
+	Internal graphql.Omittable[*string]       `json:"-"`
+}
+
+func TestPresence(t *testing.T) {
+	now := time.Now()
+	in := userInput{
+		ID:       "1",
+		Email:    graphql.OmittableOf[*string](nil),
+		Birthday: graphql.OmittableOf(&now),
+		Address:  graphql.OmittableOf(&addressInput{City: graphql.OmittableOf[*string](nil)}),
+		Dash:     graphql.OmittableOf[*string](nil),
+		Internal: graphql.OmittableOf[*string](nil),
+	}
+	got := Presence(&in).Paths()
+	want := []string{"-", "address", "address.city", "birthday", "email", "id"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,161 @@
+// Package nullhttp provides HTTP helpers for request bodies using
+// nullable.Nullable fields.
+package nullhttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// DefaultMaxBodyBytes is the largest request body DecodePatch reads.
+const DefaultMaxBodyBytes = 1 << 20
+
+// ErrUnsupportedMediaType is returned by DecodePatch when the request has a
+// non-JSON Content-Type.
+var ErrUnsupportedMediaType = errors.New("nullhttp: unsupported media type")
+
+// Presence is the set of JSON object keys present in a decoded body. Keys of
+// nested objects are joined with dots, e.g. "address.city". Arrays are
+// recorded by their own key only.
+type Presence map[string]struct{}
+
+// Has reports whether path was present in the body.
+func (p Presence) Has(path string) bool {
+	_, ok := p[path]
+	return ok
+}
+
+// Paths returns the present paths in sorted order.
+func (p Presence) Paths() []string {
+	paths := make([]string, 0, len(p))
+	for k := range p {
+		paths = append(paths, k)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// DecodePatch decodes the JSON body of r into dst and returns the set of keys
+// present in the body. Together with Nullable fields this distinguishes a
+// field that was omitted from one explicitly set to null:
+//
+//	var req struct {
+//		Name nullable.Nullable[string] `json:"name"`
+//	}
+//	present, err := nullhttp.DecodePatch(r, &req)
+//	if present.Has("name") {
+//		// update name, possibly to NULL
+//	}
+//
+// The body is limited to DefaultMaxBodyBytes.
+func DecodePatch(r *http.Request, dst any) (Presence, error) {
+	if ct := r.Header.Get("Content-Type"); ct != "" {
+		mt, _, err := mime.ParseMediaType(ct)
+		if err != nil || (mt != "application/json" && !strings.HasSuffix(mt, "+json")) {
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedMediaType, ct)
+		}
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r.Body, DefaultMaxBodyBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > DefaultMaxBodyBytes {
+		return nil, fmt.Errorf("nullhttp: request body exceeds %d bytes", DefaultMaxBodyBytes)
+	}
+	return Decode(data, dst)
+}
+
+// Decode unmarshals the JSON object in data into dst and returns the set of
+// keys present in it.
+func Decode(data []byte, dst any) (Presence, error) {
+	presence, err := ScanPresence(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, dst); err != nil {
+		return nil, err
+	}
+	return presence, nil
+}
+
+// ScanPresence returns the set of keys present in the JSON object in data
+// without decoding any values.
+func ScanPresence(data []byte) (Presence, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if tok != json.Delim('{') {
+		return nil, fmt.Errorf("nullhttp: expected JSON object, got %v", tok)
+	}
+
+	presence := make(Presence)
+	if err := scanObject(dec, "", presence); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("nullhttp: unexpected data after top-level object")
+	}
+	return presence, nil
+}
+
+// scanObject records the keys of the object whose opening brace has already
+// been consumed.
+func scanObject(dec *json.Decoder, prefix string, presence Presence) error {
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key := tok.(string)
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		presence[key] = struct{}{}
+
+		tok, err = dec.Token()
+		if err != nil {
+			return err
+		}
+		switch tok {
+		case json.Delim('{'):
+			err = scanObject(dec, key, presence)
+		case json.Delim('['):
+			err = skipArray(dec)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	_, err := dec.Token() // closing brace
+	return err
+}
+
+// skipArray consumes the remainder of an array whose opening bracket has
+// already been consumed.
+func skipArray(dec *json.Decoder) error {
+	for depth := 1; depth > 0; {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch tok {
+		case json.Delim('['), json.Delim('{'):
+			depth++
+		case json.Delim(']'), json.Delim('}'):
+			depth--
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package nullhttp
+
+import (
+	"errors"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/manattan/nullable"
+)
+
+type patchRequest struct {
+	Name    nullable.Nullable[string] `json:"name"`
+	Age     nullable.Nullable[int]    `json:"age"`
+	Email   nullable.Nullable[string] `json:"email"`
+	Tags    []string                  `json:"tags"`
+	Address struct {
+		City nullable.Nullable[string] `json:"city"`
+		Zip  nullable.Nullable[string] `json:"zip"`
+	} `json:"address"`
+}
+
+func TestDecodePatch(t *testing.T) {
+	body := `{"name":"John","age":null,"tags":["a","b"],"address":{"city":null}}`
+	r := httptest.NewRequest("PATCH", "/users/1", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	var req patchRequest
+	present, err := DecodePatch(r, &req)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := []string{"address", "address.city", "age", "name", "tags"}
+	if got := present.Paths(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected paths %v, got %v", want, got)
+	}
+	if !present.Has("age") || req.Age.Valid {
+		t.Error("Expected age to be present and null")
+	}
+	if present.Has("email") {
+		t.Error("Expected email to be absent")
+	}
+	if present.Has("address.zip") {
+		t.Error("Expected address.zip to be absent")
+	}
+	if !req.Name.Valid || req.Name.V != "John" {
+		t.Errorf("Expected name 'John', got %+v", req.Name)
+	}
+}
+
+func TestDecodePatchUnsupportedMediaType(t *testing.T) {
+	r := httptest.NewRequest("PATCH", "/", strings.NewReader(`{}`))
+	r.Header.Set("Content-Type", "text/plain")
+
+	var req patchRequest
+	if _, err := DecodePatch(r, &req); !errors.Is(err, ErrUnsupportedMediaType) {
+		t.Errorf("Expected ErrUnsupportedMediaType, got %v", err)
+	}
+}
+
+func TestDecodePatchBodyTooLarge(t *testing.T) {
+	body := `{"name":"` + strings.Repeat("x", DefaultMaxBodyBytes) + `"}`
+	r := httptest.NewRequest("PATCH", "/", strings.NewReader(body))
+
+	var req patchRequest
+	if _, err := DecodePatch(r, &req); err == nil {
+		t.Error("Expected error for oversized body")
+	}
+}
+
+func TestScanPresenceErrors(t *testing.T) {
+	for _, body := range []string{`[1,2]`, `{"a":`, `{"a":1} {}`, ``} {
+		if _, err := ScanPresence([]byte(body)); err == nil {
+			t.Errorf("Expected error for %q", body)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Package nulljwt supports nullable.Nullable fields in golang-jwt custom
+// claims, for optional claims such as email_verified.
+//
+// Nullable fields marshal and unmarshal inside claims structs like any other
+// field; tag them omitzero so null claims are left out of issued tokens:
+//
+//	type Claims struct {
+//		jwt.RegisteredClaims
+//		Email         nullable.Nullable[string] `json:"email,omitzero"`
+//		EmailVerified nullable.Nullable[bool]   `json:"email_verified,omitzero"`
+//	}
+//
+// ParseWithClaims additionally reports which claims were present in the
+// token, distinguishing an explicit null from an omitted claim, and Require
+// validates that claims are set.
+package nulljwt
+
+import (
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/manattan/nullable/internal/nullreflect"
+	"github.com/manattan/nullable/nullhttp"
+)
+
+// ParseWithClaims parses, verifies and validates tokenString into claims
+// like jwt.ParseWithClaims, and returns the set of claim names present in
+// its payload. Claims set to null are present; omitted claims are not.
+func ParseWithClaims(tokenString string, claims jwt.Claims, keyFunc jwt.Keyfunc, opts ...jwt.ParserOption) (*jwt.Token, nullhttp.Presence, error) {
+	token, err := jwt.ParseWithClaims(tokenString, claims, keyFunc, opts...)
+	if err != nil {
+		return token, nil, err
+	}
+	parts := strings.Split(tokenString, ".")
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return token, nil, fmt.Errorf("%w: %v", jwt.ErrTokenMalformed, err)
+	}
+	present, err := nullhttp.ScanPresence(payload)
+	if err != nil {
+		return token, nil, fmt.Errorf("%w: %v", jwt.ErrTokenMalformed, err)
+	}
+	return token, present, nil
+}
+
+// Require returns an error wrapping jwt.ErrTokenRequiredClaimMissing unless
+// each named claim of claims is set: a valid Nullable or non-nil pointer.
+// Claims are named by their json tag. It is meant for the Validate method
+// of custom claims, which jwt calls after validating the registered claims:
+//
+//	func (c Claims) Validate() error {
+//		return nulljwt.Require(c, "email", "email_verified")
+//	}
+func Require(claims any, names ...string) error {
+	v := reflect.ValueOf(claims)
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("nulljwt: expected a claims struct, got %T", claims)
+	}
+	for _, name := range names {
+		f, ok := claimField(v, name)
+		if !ok {
+			return fmt.Errorf("nulljwt: %s has no claim %q", v.Type(), name)
+		}
+		var set bool
+		switch {
+		case nullreflect.IsNullable(f.Type()):
+			set = nullreflect.Valid(f)
+		case f.Kind() == reflect.Pointer, f.Kind() == reflect.Interface:
+			set = !f.IsNil()
+		default:
+			set = !f.IsZero()
+		}
+		if !set {
+			return fmt.Errorf("%w: %s", jwt.ErrTokenRequiredClaimMissing, name)
+		}
+	}
+	return nil
+}
+
+// claimField returns the field of v encoded under the JSON name, searching
+// embedded structs.
+func claimField(v reflect.Value, name string) (reflect.Value, bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if nullreflect.IsEmbeddedJSON(sf) && sf.Type.Kind() == reflect.Struct {
+			if f, ok := claimField(v.Field(i), name); ok {
+				return f, true
+			}
+			continue
+		}
+		if n, ok := nullreflect.JSONName(sf); ok && n == name {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
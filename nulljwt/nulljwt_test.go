@@ -0,0 +1,130 @@
+package nulljwt
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/manattan/nullable"
+)
+
+var key = []byte("secret")
+
+type claims struct {
+	jwt.RegisteredClaims
+	Email         nullable.Nullable[string] `json:"email,omitzero"`
+	EmailVerified nullable.Nullable[bool]   `json:"email_verified,omitzero"`
+	Tenant        *string                   `json:"tenant,omitempty"`
+}
+
+func sign(t *testing.T, c jwt.Claims) string {
+	t.Helper()
+	s, err := jwt.NewWithClaims(jwt.SigningMethodHS256, c).SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func keyFunc(*jwt.Token) (any, error) { return key, nil }
+
+func TestClaimsRoundTrip(t *testing.T) {
+	in := claims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: "42"},
+		EmailVerified:    nullable.NewNullable(false),
+	}
+	var out claims
+	token, present, err := ParseWithClaims(sign(t, in), &out, keyFunc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !token.Valid {
+		t.Error("Expected a valid token")
+	}
+	if out.Email.Valid {
+		t.Errorf("Expected null email, got %v", out.Email)
+	}
+	if !out.EmailVerified.Valid || out.EmailVerified.V {
+		t.Errorf("Expected email_verified false, got %v", out.EmailVerified)
+	}
+	if !present.Has("email_verified") || !present.Has("sub") || present.Has("email") {
+		t.Errorf("Expected sub and email_verified present, got %v", present.Paths())
+	}
+}
+
+func TestParseWithClaimsExplicitNull(t *testing.T) {
+	token := sign(t, jwt.MapClaims{"sub": "42", "email": nil})
+	var out claims
+	_, present, err := ParseWithClaims(token, &out, keyFunc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !present.Has("email") || out.Email.Valid {
+		t.Errorf("Expected email present and null, got present=%v email=%v", present.Has("email"), out.Email)
+	}
+}
+
+func TestParseWithClaimsInvalidSignature(t *testing.T) {
+	token := sign(t, claims{})
+	var out claims
+	_, _, err := ParseWithClaims(token, &out, func(*jwt.Token) (any, error) { return []byte("other"), nil })
+	if !errors.Is(err, jwt.ErrTokenSignatureInvalid) {
+		t.Errorf("Expected ErrTokenSignatureInvalid, got %v", err)
+	}
+}
+
+type requiredClaims struct {
+	claims
+}
+
+func (c requiredClaims) Validate() error {
+	return Require(c, "email", "email_verified")
+}
+
+func TestRequire(t *testing.T) {
+	tenant := "acme"
+	c := claims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: "42"},
+		Email:            nullable.NewNullable("a@example.com"),
+		Tenant:           &tenant,
+	}
+	if err := Require(c, "sub", "email", "tenant"); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	err := Require(&c, "email_verified")
+	if !errors.Is(err, jwt.ErrTokenRequiredClaimMissing) {
+		t.Errorf("Expected ErrTokenRequiredClaimMissing, got %v", err)
+	}
+	if err := Require(c, "nickname"); err == nil || errors.Is(err, jwt.ErrTokenRequiredClaimMissing) {
+		t.Errorf("Expected an unknown claim error, got %v", err)
+	}
+}
+
+func TestRequireTags(t *testing.T) {
+	c := struct {
+		jwt.RegisteredClaims
+		Dash   nullable.Nullable[string] `json:"-,"`
+		Hidden nullable.Nullable[string] `json:"-"`
+		Plain  nullable.Nullable[string] `json:",omitempty"`
+	}{
+		Dash:   nullable.NewNullable("x"),
+		Hidden: nullable.NewNullable("x"),
+		Plain:  nullable.NewNullable("x"),
+	}
+	if err := Require(c, "-", "Plain"); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if err := Require(c, "Hidden"); err == nil {
+		t.Error("Expected an unknown claim error for a json:\"-\" field")
+	}
+}
+
+func TestRequireInValidate(t *testing.T) {
+	token := sign(t, claims{Email: nullable.NewNullable("a@example.com")})
+	var out requiredClaims
+	_, _, err := ParseWithClaims(token, &out, keyFunc)
+	if !errors.Is(err, jwt.ErrTokenRequiredClaimMissing) {
+		t.Errorf("Expected ErrTokenRequiredClaimMissing, got %v", err)
+	}
+}
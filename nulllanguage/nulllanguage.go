@@ -0,0 +1,107 @@
+// Package nulllanguage provides a nullable BCP 47 language tag backed by
+// golang.org/x/text/language, for optional locale fields:
+//
+//	type Profile struct {
+//		Locale nulllanguage.Tag `json:"locale"`
+//	}
+//
+// Tags are validated when parsed, decoded or scanned and normalized to
+// canonical case, so "EN-us" is stored as "en-US".
+package nulllanguage
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	"github.com/manattan/nullable"
+	"golang.org/x/text/language"
+)
+
+// Tag is a nullable BCP 47 language tag encoded in JSON and SQL as its
+// canonical string.
+type Tag struct {
+	nullable.Nullable[language.Tag]
+}
+
+// New creates a valid Tag.
+func New(t language.Tag) Tag {
+	return Tag{nullable.NewNullable(t)}
+}
+
+// Parse parses s as a well-formed BCP 47 tag. The empty string yields a
+// null Tag.
+func Parse(s string) (Tag, error) {
+	var t Tag
+	if err := t.parse(s); err != nil {
+		return Tag{}, err
+	}
+	return t, nil
+}
+
+func (t *Tag) parse(s string) error {
+	if s == "" {
+		t.V, t.Valid = language.Tag{}, false
+		return nil
+	}
+	tag, err := language.Parse(s)
+	if err != nil {
+		return fmt.Errorf("nulllanguage: invalid language tag %q: %w", s, err)
+	}
+	t.V, t.Valid = tag, true
+	return nil
+}
+
+// String returns the canonical tag, or "null".
+func (t Tag) String() string {
+	if !t.Valid {
+		return "null"
+	}
+	return t.V.String()
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (t Tag) MarshalJSON() ([]byte, error) {
+	if !t.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.V.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. The empty string
+// decodes as null.
+func (t *Tag) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		t.V, t.Valid = language.Tag{}, false
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return t.parse(s)
+}
+
+// Scan implements the sql.Scanner interface for text columns.
+func (t *Tag) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		t.V, t.Valid = language.Tag{}, false
+		return nil
+	case string:
+		return t.parse(v)
+	case []byte:
+		return t.parse(string(v))
+	default:
+		return fmt.Errorf("nulllanguage: cannot scan %T into Tag", value)
+	}
+}
+
+// Value implements the driver.Valuer interface, returning the canonical tag
+// or nil.
+func (t Tag) Value() (driver.Value, error) {
+	if !t.Valid {
+		return nil, nil
+	}
+	return t.V.String(), nil
+}
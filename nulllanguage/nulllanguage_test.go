@@ -0,0 +1,86 @@
+package nulllanguage
+
+import (
+	"encoding/json"
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func TestParse(t *testing.T) {
+	for in, want := range map[string]string{
+		"EN-us":      "en-US",
+		"zh-hant-TW": "zh-Hant-TW",
+		"sr-latn":    "sr-Latn",
+		"de":         "de",
+	} {
+		tag, err := Parse(in)
+		if err != nil {
+			t.Errorf("%q: Unexpected error: %v", in, err)
+			continue
+		}
+		if tag.String() != want {
+			t.Errorf("%q: Expected %s, got %s", in, want, tag)
+		}
+	}
+	for _, in := range []string{"not a tag", "en_", "x", "123"} {
+		if _, err := Parse(in); err == nil {
+			t.Errorf("%q: Expected error", in)
+		}
+	}
+	if tag, err := Parse(""); err != nil || tag.Valid {
+		t.Errorf("Expected null for empty string, got %+v (%v)", tag, err)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	type profile struct {
+		Locale Tag `json:"locale"`
+	}
+	var p profile
+	if err := json.Unmarshal([]byte(`{"locale":"pt-br"}`), &p); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if p.Locale.V != language.BrazilianPortuguese {
+		t.Errorf("Expected pt-BR, got %s", p.Locale)
+	}
+	data, _ := json.Marshal(p)
+	if want := `{"locale":"pt-BR"}`; string(data) != want {
+		t.Errorf("Expected %s, got %s", want, data)
+	}
+
+	for _, in := range []string{`{"locale":null}`, `{"locale":""}`} {
+		if err := json.Unmarshal([]byte(in), &p); err != nil || p.Locale.Valid {
+			t.Errorf("%s: Expected null, got %+v (%v)", in, p.Locale, err)
+		}
+	}
+	data, _ = json.Marshal(p)
+	if want := `{"locale":null}`; string(data) != want {
+		t.Errorf("Expected %s, got %s", want, data)
+	}
+	if err := json.Unmarshal([]byte(`{"locale":"??"}`), &p); err == nil {
+		t.Error("Expected error for invalid tag")
+	}
+}
+
+func TestSQL(t *testing.T) {
+	var tag Tag
+	if err := tag.Scan([]byte("FR-ca")); err != nil || tag.String() != "fr-CA" {
+		t.Errorf("Expected fr-CA, got %s (%v)", tag, err)
+	}
+	if v, err := tag.Value(); err != nil || v != "fr-CA" {
+		t.Errorf("Expected fr-CA, got %v (%v)", v, err)
+	}
+	if err := tag.Scan(nil); err != nil || tag.Valid {
+		t.Errorf("Expected null, got %+v (%v)", tag, err)
+	}
+	if v, err := tag.Value(); err != nil || v != nil {
+		t.Errorf("Expected nil, got %v (%v)", v, err)
+	}
+	if err := tag.Scan(42); err == nil {
+		t.Error("Expected error for int")
+	}
+	if New(language.Japanese).String() != "ja" {
+		t.Errorf("Expected ja, got %s", New(language.Japanese))
+	}
+}
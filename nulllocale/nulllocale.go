@@ -0,0 +1,144 @@
+// Package nulllocale formats nullable.Nullable values for display in a
+// user's locale with golang.org/x/text: numbers, percentages, currency
+// amounts and dates are rendered per locale, and null values as a localized
+// "not set" message.
+//
+// The message is looked up in the x/text message catalog, so translations
+// are registered the usual way:
+//
+//	message.SetString(language.French, nulllocale.NotSetKey, "non renseigné")
+//	p := nulllocale.NewPrinter(language.French)
+//	nulllocale.Currency(p, order.Total, currency.EUR) // "€ 1 234,50" or "non renseigné"
+package nulllocale
+
+import (
+	"time"
+
+	"github.com/manattan/nullable"
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// NotSetKey is the default catalog key printed for null values.
+const NotSetKey = "not set"
+
+// Number is the set of types the numeric formatters accept.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// Option configures a Printer.
+type Option func(*Printer)
+
+// NotSet sets the catalog key printed for null values. The key is
+// translated like a message.Printer.Sprintf format without arguments.
+func NotSet(key string) Option {
+	return func(p *Printer) {
+		p.notSet = key
+	}
+}
+
+// DateLayout overrides the time.Format layout used by Date.
+func DateLayout(layout string) Option {
+	return func(p *Printer) {
+		p.dateLayout = layout
+	}
+}
+
+// Printer formats Nullable values for one locale.
+type Printer struct {
+	p          *message.Printer
+	notSet     string
+	dateLayout string
+}
+
+// NewPrinter returns a Printer for tag.
+func NewPrinter(tag language.Tag, opts ...Option) *Printer {
+	p := &Printer{
+		p:          message.NewPrinter(tag),
+		notSet:     NotSetKey,
+		dateLayout: dateLayoutFor(tag),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// NotSet returns the localized message for null values.
+func (p *Printer) NotSet() string {
+	return p.p.Sprintf(p.notSet)
+}
+
+// Date formats a valid n as a numeric short date in the locale's
+// conventional order, such as 1/2/2006 in American English or 02.01.2006
+// in German, and ISO 8601 for locales without a known convention. x/text
+// has no date formatting, so the layouts come from the CLDR short date
+// patterns of common locales; DateLayout overrides them.
+func (p *Printer) Date(n nullable.Nullable[time.Time]) string {
+	if !n.Valid {
+		return p.NotSet()
+	}
+	return n.V.Format(p.dateLayout)
+}
+
+// Decimal formats a valid n with the locale's digit grouping and decimal
+// separator.
+func Decimal[T Number](p *Printer, n nullable.Nullable[T], opts ...number.Option) string {
+	if !n.Valid {
+		return p.NotSet()
+	}
+	return p.p.Sprint(number.Decimal(n.V, opts...))
+}
+
+// Percent formats a valid n, where 1 is 100%, as a localized percentage.
+func Percent[T Number](p *Printer, n nullable.Nullable[T], opts ...number.Option) string {
+	if !n.Valid {
+		return p.NotSet()
+	}
+	return p.p.Sprint(number.Percent(n.V, opts...))
+}
+
+// Currency formats a valid n as an amount of unit with the currency's
+// symbol in the locale, such as "€ 1.234,50" in German.
+func Currency[T Number](p *Printer, n nullable.Nullable[T], unit currency.Unit) string {
+	if !n.Valid {
+		return p.NotSet()
+	}
+	return p.p.Sprint(currency.Symbol(unit.Amount(n.V)))
+}
+
+// dateLayouts holds numeric CLDR short date patterns for common locales,
+// keyed by tag. Tags without an entry use their nearest parent's.
+var dateLayouts = map[language.Tag]string{
+	language.English:             "1/2/2006",
+	language.MustParse("en-001"): "02/01/2006",
+	language.MustParse("en-AU"):  "2/1/2006",
+	language.MustParse("en-CA"):  "2006-01-02",
+	language.German:              "02.01.2006",
+	language.French:              "02/01/2006",
+	language.MustParse("fr-CA"):  "2006-01-02",
+	language.Spanish:             "2/1/2006",
+	language.Italian:             "02/01/2006",
+	language.Dutch:               "2-1-2006",
+	language.Portuguese:          "02/01/2006",
+	language.Polish:              "2.01.2006",
+	language.Russian:             "02.01.2006",
+	language.Swedish:             "2006-01-02",
+	language.Japanese:            "2006/01/02",
+	language.Chinese:             "2006/1/2",
+	language.Korean:              "2006. 1. 2.",
+}
+
+func dateLayoutFor(tag language.Tag) string {
+	for ; !tag.IsRoot(); tag = tag.Parent() {
+		if layout, ok := dateLayouts[tag]; ok {
+			return layout
+		}
+	}
+	return "2006-01-02"
+}
@@ -0,0 +1,80 @@
+package nulllocale
+
+import (
+	"testing"
+	"time"
+
+	"github.com/manattan/nullable"
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+func TestNumbers(t *testing.T) {
+	us := NewPrinter(language.AmericanEnglish)
+	de := NewPrinter(language.German)
+	amount := nullable.NewNullable(1234567.891)
+
+	tests := []struct{ got, want string }{
+		{Decimal(us, amount), "1,234,567.891"},
+		{Decimal(de, amount), "1.234.567,891"},
+		{Decimal(de, amount, number.MaxFractionDigits(1)), "1.234.567,9"},
+		{Decimal(us, nullable.NewNullable[int64](42)), "42"},
+		{Percent(us, nullable.NewNullable(0.256)), "26%"},
+		{Percent(de, nullable.NewNullable(0.5)), "50\u00a0%"},
+		{Currency(us, nullable.NewNullable(1234.5), currency.USD), "$ 1,234.50"},
+		{Currency(de, nullable.NewNullable(1234.5), currency.EUR), "€ 1.234,50"},
+		{Decimal(us, nullable.NewNull[float64]()), "not set"},
+		{Percent(us, nullable.NewNull[float64]()), "not set"},
+		{Currency(de, nullable.NewNull[int](), currency.EUR), "not set"},
+	}
+	for _, tc := range tests {
+		if tc.got != tc.want {
+			t.Errorf("Expected %q, got %q", tc.want, tc.got)
+		}
+	}
+}
+
+func TestDate(t *testing.T) {
+	d := nullable.NewNullable(time.Date(2024, 3, 7, 15, 0, 0, 0, time.UTC))
+	tests := map[string]string{
+		"en-US": "3/7/2024",
+		"en":    "3/7/2024",
+		"en-GB": "07/03/2024",
+		"de-AT": "07.03.2024",
+		"fr":    "07/03/2024",
+		"ja":    "2024/03/07",
+		"sw":    "2024-03-07",
+		"en-IN": "07/03/2024",
+	}
+	for tag, want := range tests {
+		if got := NewPrinter(language.MustParse(tag)).Date(d); got != want {
+			t.Errorf("%s: expected %s, got %s", tag, want, got)
+		}
+	}
+	if got := NewPrinter(language.German, DateLayout("2 Jan 2006")).Date(d); got != "7 Mar 2024" {
+		t.Errorf("Expected custom layout, got %s", got)
+	}
+	if got := NewPrinter(language.German).Date(nullable.NewNull[time.Time]()); got != "not set" {
+		t.Errorf("Expected not set, got %s", got)
+	}
+}
+
+func TestNotSet(t *testing.T) {
+	if err := message.SetString(language.Italian, NotSetKey, "non impostato"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := message.SetString(language.Italian, "missing", "mancante"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := NewPrinter(language.Italian).NotSet(); got != "non impostato" {
+		t.Errorf("Expected translated message, got %q", got)
+	}
+	if got := Decimal(NewPrinter(language.Italian, NotSet("missing")), nullable.NewNull[int]()); got != "mancante" {
+		t.Errorf("Expected custom key translation, got %q", got)
+	}
+	if got := NewPrinter(language.Spanish, NotSet("—")).NotSet(); got != "—" {
+		t.Errorf("Expected untranslated key, got %q", got)
+	}
+}
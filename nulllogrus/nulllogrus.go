@@ -0,0 +1,68 @@
+// Package nulllogrus integrates nullable.Nullable values with logrus.
+//
+// Install Hook on a logger to render every Nullable field as its inner value,
+// or nil when null, in both the text and JSON formatters:
+//
+//	logger.AddHook(nulllogrus.Hook{})
+//	logger.WithField("age", user.Age).Info("loaded user")
+package nulllogrus
+
+import (
+	"github.com/manattan/nullable"
+	"github.com/manattan/nullable/internal/nullreflect"
+	"github.com/sirupsen/logrus"
+)
+
+// Hook is a logrus.Hook that replaces Nullable values in an entry's fields
+// with their inner value, or nil when null.
+type Hook struct {
+	// LogLevels restricts the hook to the given levels. All levels are
+	// used when empty.
+	LogLevels []logrus.Level
+}
+
+var _ logrus.Hook = Hook{}
+
+// Levels implements logrus.Hook.
+func (h Hook) Levels() []logrus.Level {
+	if len(h.LogLevels) == 0 {
+		return logrus.AllLevels
+	}
+	return h.LogLevels
+}
+
+// Fire implements logrus.Hook.
+func (h Hook) Fire(entry *logrus.Entry) error {
+	for k, v := range entry.Data {
+		if inner, ok := nullreflect.Unwrap(v); ok {
+			entry.Data[k] = inner
+		}
+	}
+	return nil
+}
+
+// Value returns the inner value of n, or nil when n is null. It is useful
+// for call sites logging through loggers without Hook installed.
+func Value[T any](n nullable.Nullable[T]) any {
+	if !n.Valid {
+		return nil
+	}
+	return n.V
+}
+
+// Field returns logrus fields holding the inner value of n under key.
+func Field[T any](key string, n nullable.Nullable[T]) logrus.Fields {
+	return logrus.Fields{key: Value(n)}
+}
+
+// Fields returns a copy of fields with every Nullable value unwrapped.
+func Fields(fields logrus.Fields) logrus.Fields {
+	out := make(logrus.Fields, len(fields))
+	for k, v := range fields {
+		if inner, ok := nullreflect.Unwrap(v); ok {
+			v = inner
+		}
+		out[k] = v
+	}
+	return out
+}
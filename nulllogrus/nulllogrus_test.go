@@ -0,0 +1,87 @@
+package nulllogrus
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/manattan/nullable"
+	"github.com/sirupsen/logrus"
+)
+
+func newLogger(formatter logrus.Formatter) (*logrus.Logger, *bytes.Buffer) {
+	var buf bytes.Buffer
+	logger := logrus.New()
+	logger.Out = &buf
+	logger.Formatter = formatter
+	logger.AddHook(Hook{})
+	return logger, &buf
+}
+
+func TestHookJSONFormatter(t *testing.T) {
+	logger, buf := newLogger(&logrus.JSONFormatter{})
+	logger.WithFields(logrus.Fields{
+		"name": nullable.NewNullable("John"),
+		"age":  nullable.NewNull[int](),
+		"id":   7,
+	}).Info("loaded")
+
+	var got map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got["name"] != "John" {
+		t.Errorf("Expected name 'John', got %v", got["name"])
+	}
+	if v, ok := got["age"]; !ok || v != nil {
+		t.Errorf("Expected age null, got %v", v)
+	}
+	if got["id"] != float64(7) {
+		t.Errorf("Expected id 7, got %v", got["id"])
+	}
+}
+
+func TestHookTextFormatter(t *testing.T) {
+	logger, buf := newLogger(&logrus.TextFormatter{DisableColors: true, DisableTimestamp: true})
+	logger.WithField("age", nullable.NewNullable(30)).WithField("nick", nullable.NewNull[string]()).Info("loaded")
+
+	out := buf.String()
+	if !strings.Contains(out, "age=30") {
+		t.Errorf("Expected age=30 in %q", out)
+	}
+	if !strings.Contains(out, "nick=\"<nil>\"") {
+		t.Errorf("Expected nick=\"<nil>\" in %q", out)
+	}
+}
+
+func TestHookLevels(t *testing.T) {
+	if len(Hook{}.Levels()) != len(logrus.AllLevels) {
+		t.Error("Expected all levels by default")
+	}
+	h := Hook{LogLevels: []logrus.Level{logrus.ErrorLevel}}
+	if got := h.Levels(); len(got) != 1 || got[0] != logrus.ErrorLevel {
+		t.Errorf("Expected only error level, got %v", got)
+	}
+}
+
+func TestFieldHelpers(t *testing.T) {
+	if v := Value(nullable.NewNullable("x")); v != "x" {
+		t.Errorf("Expected 'x', got %v", v)
+	}
+	if v := Value(nullable.NewNull[string]()); v != nil {
+		t.Errorf("Expected nil, got %v", v)
+	}
+	if f := Field("k", nullable.NewNullable(1)); f["k"] != 1 {
+		t.Errorf("Expected 1, got %v", f["k"])
+	}
+
+	in := logrus.Fields{"a": nullable.NewNull[int](), "b": "plain"}
+	out := Fields(in)
+	if out["a"] != nil || out["b"] != "plain" {
+		t.Errorf("Unexpected fields %v", out)
+	}
+	if _, ok := in["a"].(nullable.Nullable[int]); !ok {
+		t.Error("Expected input fields to be left unchanged")
+	}
+}
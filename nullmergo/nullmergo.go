@@ -0,0 +1,59 @@
+// Package nullmergo merges structs with Nullable fields using
+// dario.cat/mergo, for layering configuration from defaults, files and
+// flags.
+//
+// Without a transformer, mergo compares a Nullable's V and Valid fields
+// separately, so a valid zero value such as an explicit "retries: 0" is
+// treated as empty and overwritten. Transformer instead treats a null
+// Nullable as empty and a valid one, zero or not, as set:
+//
+//	defaults := Config{Retries: nullable.NewNullable(3)}
+//	err := mergo.Merge(&cfg, defaults, mergo.WithTransformers(nullmergo.Transformer{}))
+//
+// Wrapper types that embed a Nullable, such as nullable.ULID, are merged
+// the same way.
+package nullmergo
+
+import (
+	"reflect"
+
+	"github.com/manattan/nullable/internal/nullreflect"
+)
+
+// Transformer implements mergo.Transformers for Nullable fields. Its
+// settings mirror the mergo options passed alongside it, which
+// transformers cannot see:
+//
+//	mergo.Merge(&cfg, flags, mergo.WithOverride,
+//		mergo.WithTransformers(nullmergo.Transformer{Override: true}))
+type Transformer struct {
+	// Override makes a valid src field replace a valid dst field, as with
+	// mergo.WithOverride. Without it, only null dst fields are filled.
+	Override bool
+
+	// OverrideWithNull makes a null src field clear the dst field, as with
+	// mergo.WithOverwriteWithEmptyValue.
+	OverrideWithNull bool
+}
+
+// Transformer returns the merge function for Nullable types and nil for
+// all others, which mergo merges as usual.
+func (t Transformer) Transformer(typ reflect.Type) func(dst, src reflect.Value) error {
+	if !nullreflect.IsNullable(typ) {
+		return nil
+	}
+	return func(dst, src reflect.Value) error {
+		if !dst.CanSet() {
+			return nil
+		}
+		switch {
+		case !nullreflect.Valid(src):
+			if t.OverrideWithNull {
+				dst.SetZero()
+			}
+		case !nullreflect.Valid(dst) || t.Override:
+			dst.Set(src)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,75 @@
+package nullmergo
+
+import (
+	"testing"
+
+	"dario.cat/mergo"
+	"github.com/manattan/nullable"
+)
+
+type config struct {
+	Host    string
+	Retries nullable.Nullable[int]
+	Debug   nullable.Nullable[bool]
+	Email   nullable.Email
+	DB      dbConfig
+}
+
+type dbConfig struct {
+	URL nullable.Nullable[string]
+}
+
+func TestMergeDefaults(t *testing.T) {
+	dst := config{
+		Retries: nullable.NewNullable(0),
+	}
+	defaults := config{
+		Host:    "localhost",
+		Retries: nullable.NewNullable(3),
+		Debug:   nullable.NewNullable(true),
+		Email:   nullable.Email{Nullable: nullable.NewNullable("ops@example.com")},
+		DB:      dbConfig{URL: nullable.NewNullable("postgres://localhost")},
+	}
+	if err := mergo.Merge(&dst, defaults, mergo.WithTransformers(Transformer{})); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if dst.Retries != nullable.NewNullable(0) {
+		t.Errorf("Expected explicit 0 retries to be kept, got %v", dst.Retries)
+	}
+	if dst.Host != "localhost" || dst.Debug != nullable.NewNullable(true) {
+		t.Errorf("Expected defaults to fill empty fields, got %+v", dst)
+	}
+	if dst.Email.V != "ops@example.com" || dst.DB.URL.V != "postgres://localhost" {
+		t.Errorf("Expected wrapper and nested fields to be filled, got %+v", dst)
+	}
+}
+
+func TestMergeOverride(t *testing.T) {
+	dst := config{
+		Retries: nullable.NewNullable(3),
+		Debug:   nullable.NewNullable(true),
+		DB:      dbConfig{URL: nullable.NewNullable("postgres://localhost")},
+	}
+	flags := config{
+		Debug: nullable.NewNullable(false),
+	}
+	err := mergo.Merge(&dst, flags, mergo.WithOverride, mergo.WithTransformers(Transformer{Override: true}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if dst.Debug != nullable.NewNullable(false) {
+		t.Errorf("Expected valid false to override, got %v", dst.Debug)
+	}
+	if dst.Retries != nullable.NewNullable(3) || !dst.DB.URL.Valid {
+		t.Errorf("Expected null fields not to override, got %+v", dst)
+	}
+
+	err = mergo.Merge(&dst, flags, mergo.WithOverwriteWithEmptyValue,
+		mergo.WithTransformers(Transformer{Override: true, OverrideWithNull: true}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if dst.Retries.Valid || dst.DB.URL.Valid {
+		t.Errorf("Expected null fields to clear, got %+v", dst)
+	}
+}
@@ -0,0 +1,197 @@
+// Package nullneo4j converts between nullable.Nullable values and the
+// parameters and records of the Neo4j Go driver, so optional graph
+// properties can be written as Cypher nulls and read back as null
+// Nullables.
+package nullneo4j
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/manattan/nullable"
+	"github.com/manattan/nullable/internal/nullreflect"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// Param returns the Cypher parameter for n: nil when null, otherwise the
+// value.
+func Param[T any](n nullable.Nullable[T]) any {
+	if !n.Valid {
+		return nil
+	}
+	return n.V
+}
+
+// Params returns the Cypher parameters for v, which is a struct, a pointer
+// to one, or a map with string keys. Struct fields are named by the neo4j
+// struct tag, or the field name when there is none; a tag of "-" skips the
+// field, and embedded structs without a tag contribute their fields:
+//
+//	type Person struct {
+//		Name     string                   `neo4j:"name"`
+//		Nickname nullable.Nullable[string] `neo4j:"nickname"`
+//	}
+//	params, err := nullneo4j.Params(p)
+//	_, err = neo4j.ExecuteQuery(ctx, driver,
+//		"CREATE (:Person {name: $name, nickname: $nickname})", params, ...)
+//
+// Null Nullables become nil and valid ones their value. Wrapper types and
+// other driver.Valuer implementations are passed as their Value, wrappers
+// without one as their embedded Nullable, and slices and maps are converted
+// element by element.
+func Params(v any) (map[string]any, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	params := make(map[string]any)
+	switch {
+	case rv.Kind() == reflect.Struct:
+		if err := collect(rv, params); err != nil {
+			return nil, err
+		}
+	case rv.Kind() == reflect.Map && rv.Type().Key().Kind() == reflect.String:
+		for it := rv.MapRange(); it.Next(); {
+			p, err := paramValue(it.Value())
+			if err != nil {
+				return nil, fmt.Errorf("nullneo4j: parameter %s: %w", it.Key(), err)
+			}
+			params[it.Key().String()] = p
+		}
+	default:
+		return nil, fmt.Errorf("nullneo4j: expected a struct or map, got %T", v)
+	}
+	return params, nil
+}
+
+func collect(v reflect.Value, params map[string]any) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, hasTag := sf.Tag.Lookup("neo4j")
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "-" || !sf.IsExported() && !sf.Anonymous {
+			continue
+		}
+		fv := v.Field(i)
+		if sf.Anonymous && !hasTag && !nullreflect.IsNullable(sf.Type) && !nullreflect.IsWrapper(sf.Type) {
+			if sf.Type.Kind() == reflect.Pointer {
+				if fv.IsNil() {
+					continue
+				}
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct {
+				if err := collect(fv, params); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		p, err := paramValue(fv)
+		if err != nil {
+			return fmt.Errorf("nullneo4j: field %s: %w", sf.Name, err)
+		}
+		params[name] = p
+	}
+	return nil
+}
+
+var valuerType = reflect.TypeFor[driver.Valuer]()
+
+func paramValue(v reflect.Value) (any, error) {
+	if !v.IsValid() {
+		return nil, nil
+	}
+	t := v.Type()
+	switch {
+	case nullreflect.IsNullable(t):
+		if !nullreflect.Valid(v) {
+			return nil, nil
+		}
+		return paramValue(nullreflect.Inner(v))
+	case t.Implements(valuerType):
+		if t.Kind() == reflect.Pointer && v.IsNil() {
+			return nil, nil
+		}
+		return v.Interface().(driver.Valuer).Value()
+	case nullreflect.IsWrapper(t):
+		return paramValue(v.Field(0))
+	}
+
+	switch t.Kind() {
+	case reflect.Interface, reflect.Pointer:
+		if v.IsNil() {
+			return nil, nil
+		}
+		return paramValue(v.Elem())
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 || v.IsNil() {
+			return v.Interface(), nil
+		}
+		fallthrough
+	case reflect.Array:
+		list := make([]any, v.Len())
+		for i := range list {
+			p, err := paramValue(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			list[i] = p
+		}
+		return list, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String || v.IsNil() {
+			return v.Interface(), nil
+		}
+		m := make(map[string]any, v.Len())
+		for it := v.MapRange(); it.Next(); {
+			p, err := paramValue(it.Value())
+			if err != nil {
+				return nil, err
+			}
+			m[it.Key().String()] = p
+		}
+		return m, nil
+	}
+	return v.Interface(), nil
+}
+
+// Get returns the value of key in rec as a Nullable[T], which is null when
+// the value is a Cypher null. Integers and floats are converted to the
+// numeric type of T when they fit, temporal values to time.Time and back,
+// and lists and maps element by element. A missing key is an error.
+func Get[T any](rec *neo4j.Record, key string) (nullable.Nullable[T], error) {
+	v, ok := rec.Get(key)
+	if !ok {
+		return nullable.NewNull[T](), fmt.Errorf("nullneo4j: record has no key %q", key)
+	}
+	return convert[T](v, key)
+}
+
+// Property returns the property key of a node or relationship as a
+// Nullable[T], converted like Get. Since Neo4j does not store null
+// properties, a missing property is null.
+func Property[T any](e neo4j.Entity, key string) (nullable.Nullable[T], error) {
+	return convert[T](e.GetProperties()[key], key)
+}
+
+func convert[T any](v any, key string) (nullable.Nullable[T], error) {
+	var n nullable.Nullable[T]
+	if v == nil {
+		return n, nil
+	}
+	if err := nullreflect.Assign(reflect.ValueOf(&n.V).Elem(), reflect.ValueOf(v)); err != nil {
+		return nullable.NewNull[T](), fmt.Errorf("nullneo4j: %s: %w", key, err)
+	}
+	n.Valid = true
+	return n, nil
+}
@@ -0,0 +1,144 @@
+package nullneo4j
+
+import (
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/manattan/nullable"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/dbtype"
+)
+
+func TestParam(t *testing.T) {
+	if p := Param(nullable.NewNullable("x")); p != "x" {
+		t.Errorf("Expected x, got %v", p)
+	}
+	if p := Param(nullable.NewNull[string]()); p != nil {
+		t.Errorf("Expected nil, got %v", p)
+	}
+}
+
+func TestParams(t *testing.T) {
+	type base struct {
+		ID string `neo4j:"id"`
+	}
+	type person struct {
+		base
+		Name     string                      `neo4j:"name"`
+		Nickname nullable.Nullable[string]   `neo4j:"nickname"`
+		Age      nullable.Nullable[int]      `neo4j:"age"`
+		MAC      nullable.HardwareAddr       `neo4j:"mac"`
+		Tags     []nullable.Nullable[string] `neo4j:"tags"`
+		Secret   string                      `neo4j:"-"`
+		Plain    int
+		hidden   int
+	}
+	mac, _ := net.ParseMAC("00:1a:2b:3c:4d:5e")
+	p := person{
+		base:     base{ID: "p1"},
+		Name:     "Ada",
+		Nickname: nullable.NewNull[string](),
+		Age:      nullable.NewNullable(36),
+		MAC:      nullable.NewHardwareAddr(mac),
+		Tags:     []nullable.Nullable[string]{nullable.NewNullable("a"), nullable.NewNull[string]()},
+		Secret:   "s",
+		Plain:    1,
+	}
+	got, err := Params(&p)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := map[string]any{
+		"id":       "p1",
+		"name":     "Ada",
+		"nickname": nil,
+		"age":      36,
+		"mac":      "00:1a:2b:3c:4d:5e",
+		"tags":     []any{"a", nil},
+		"Plain":    1,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	got, err = Params(map[string]any{
+		"name":  nullable.NewNullable("Ada"),
+		"email": nullable.Email{},
+		"props": map[string]nullable.Nullable[int]{"x": nullable.NewNull[int]()},
+		"raw":   []byte{1},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want = map[string]any{
+		"name":  "Ada",
+		"email": nil,
+		"props": map[string]any{"x": nil},
+		"raw":   []byte{1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	if _, err := Params(42); err == nil {
+		t.Error("Expected error for int")
+	}
+}
+
+func TestGet(t *testing.T) {
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	rec := &neo4j.Record{
+		Keys:   []string{"name", "nickname", "age", "score", "born", "tags", "attrs", "big"},
+		Values: []any{"Ada", nil, int64(36), int64(7), dbtype.Date(day), []any{"a", nil}, map[string]any{"k": int64(1)}, int64(300)},
+	}
+
+	if n, err := Get[string](rec, "name"); err != nil || !n.Valid || n.V != "Ada" {
+		t.Errorf("Expected Ada, got %+v (%v)", n, err)
+	}
+	if n, err := Get[string](rec, "nickname"); err != nil || n.Valid {
+		t.Errorf("Expected null, got %+v (%v)", n, err)
+	}
+	if n, err := Get[int](rec, "age"); err != nil || !n.Valid || n.V != 36 {
+		t.Errorf("Expected 36, got %+v (%v)", n, err)
+	}
+	if n, err := Get[float64](rec, "score"); err != nil || n.V != 7 {
+		t.Errorf("Expected 7, got %+v (%v)", n, err)
+	}
+	if n, err := Get[time.Time](rec, "born"); err != nil || !n.V.Equal(day) {
+		t.Errorf("Expected %v, got %+v (%v)", day, n, err)
+	}
+	n, err := Get[[]nullable.Nullable[string]](rec, "tags")
+	if err != nil || len(n.V) != 2 || n.V[0].V != "a" || !n.V[0].Valid || n.V[1].Valid {
+		t.Errorf("Expected [a null], got %+v (%v)", n, err)
+	}
+	if n, err := Get[map[string]int](rec, "attrs"); err != nil || n.V["k"] != 1 {
+		t.Errorf("Expected map with k=1, got %+v (%v)", n, err)
+	}
+
+	if _, err := Get[int8](rec, "big"); err == nil || !strings.Contains(err.Error(), "big") {
+		t.Errorf("Expected overflow error naming the key, got %v", err)
+	}
+	if _, err := Get[int](rec, "name"); err == nil {
+		t.Error("Expected error converting string to int")
+	}
+	if _, err := Get[string](rec, "missing"); err == nil {
+		t.Error("Expected error for missing key")
+	}
+}
+
+func TestProperty(t *testing.T) {
+	node := dbtype.Node{Props: map[string]any{"name": "Ada", "age": int64(36)}}
+
+	if n, err := Property[string](node, "name"); err != nil || n.V != "Ada" {
+		t.Errorf("Expected Ada, got %+v (%v)", n, err)
+	}
+	if n, err := Property[int32](node, "age"); err != nil || n.V != 36 {
+		t.Errorf("Expected 36, got %+v (%v)", n, err)
+	}
+	if n, err := Property[string](node, "nickname"); err != nil || n.Valid {
+		t.Errorf("Expected null for missing property, got %+v (%v)", n, err)
+	}
+}
@@ -0,0 +1,72 @@
+package nullprotojson
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+// Marshaler is a gRPC-Gateway runtime.Marshaler producing the same JSON as
+// Marshal and Unmarshal. Messages returned by gateway handlers render as
+// with runtime.JSONPb, while structs from custom handlers that combine
+// messages with Nullable fields follow the same naming and null rules:
+//
+//	mux := runtime.NewServeMux(
+//		runtime.WithMarshalerOption(runtime.MIMEWildcard, &nullprotojson.Marshaler{}),
+//	)
+type Marshaler struct {
+	MarshalOptions
+	UnmarshalOptions
+}
+
+var _ runtime.Marshaler = (*Marshaler)(nil)
+
+// Marshal encodes v like MarshalOptions.Marshal.
+func (m *Marshaler) Marshal(v any) ([]byte, error) {
+	return m.MarshalOptions.Marshal(v)
+}
+
+// Unmarshal decodes data like UnmarshalOptions.Unmarshal.
+func (m *Marshaler) Unmarshal(data []byte, v any) error {
+	return m.UnmarshalOptions.Unmarshal(data, v)
+}
+
+// NewDecoder returns a runtime.Decoder reading consecutive JSON values
+// from r.
+func (m *Marshaler) NewDecoder(r io.Reader) runtime.Decoder {
+	dec := json.NewDecoder(r)
+	return runtime.DecoderFunc(func(v any) error {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return err
+		}
+		return m.Unmarshal(raw, v)
+	})
+}
+
+// NewEncoder returns a runtime.Encoder writing each value to w followed by
+// the delimiter.
+func (m *Marshaler) NewEncoder(w io.Writer) runtime.Encoder {
+	return runtime.EncoderFunc(func(v any) error {
+		data, err := m.Marshal(v)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+		_, err = w.Write(m.Delimiter())
+		return err
+	})
+}
+
+// ContentType returns "application/json".
+func (m *Marshaler) ContentType(any) string {
+	return "application/json"
+}
+
+// Delimiter returns the newline separating streamed messages.
+func (m *Marshaler) Delimiter() []byte {
+	return []byte("\n")
+}
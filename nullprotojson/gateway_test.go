@@ -0,0 +1,46 @@
+package nullprotojson
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/manattan/nullable"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestMarshaler(t *testing.T) {
+	m := &Marshaler{}
+	if ct := m.ContentType(nil); ct != "application/json" {
+		t.Errorf("Expected application/json, got %s", ct)
+	}
+
+	data, err := m.Marshal(wrapperspb.Int64(7))
+	if err != nil || string(data) != `"7"` {
+		t.Errorf(`Expected "7", got %s (%v)`, data, err)
+	}
+	var v wrapperspb.Int64Value
+	if err := m.Unmarshal([]byte(`"8"`), &v); err != nil || v.GetValue() != 8 {
+		t.Errorf("Expected 8, got %v (%v)", v.GetValue(), err)
+	}
+
+	var buf bytes.Buffer
+	enc := m.NewEncoder(&buf)
+	for _, p := range []page{{NextPage: nullable.NewNullable("p2")}, {}} {
+		if err := enc.Encode(p); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if want := "{\"nextPage\":\"p2\"}\n{}\n"; buf.String() != want {
+		t.Errorf("Expected %q, got %q", want, buf.String())
+	}
+
+	dec := m.NewDecoder(strings.NewReader(`{"next_page":"p3"} {"nextPage":null}`))
+	var p page
+	if err := dec.Decode(&p); err != nil || p.NextPage.V != "p3" {
+		t.Errorf("Expected p3, got %+v (%v)", p, err)
+	}
+	if err := dec.Decode(&p); err != nil || p.NextPage.Valid {
+		t.Errorf("Expected null, got %+v (%v)", p, err)
+	}
+}
@@ -0,0 +1,333 @@
+// Package nullprotojson encodes Go structs that mix protobuf messages with
+// nullable.Nullable fields as one consistent JSON document. encoding/json
+// renders messages nested in plain structs with their Go field tags, which
+// differ from protojson's names and well-known type forms; here messages
+// are rendered by protojson and the surrounding fields follow the same
+// conventions:
+//
+//   - Fields without a json tag are named in lowerCamelCase, or in
+//     snake_case when UseProtoNames is set, like message fields.
+//   - Null Nullables and nil messages are omitted unless EmitUnpopulated is
+//     set, in which case they are written as null.
+//
+// Marshaler applies the same rules as a gRPC-Gateway runtime.Marshaler for
+// handlers that respond with such structs.
+package nullprotojson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/manattan/nullable/internal/nullreflect"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// MarshalOptions configures Marshal. The embedded protojson options render
+// messages and also select field naming and null emission for the
+// surrounding structs.
+type MarshalOptions struct {
+	protojson.MarshalOptions
+}
+
+// Marshal encodes v with the default options.
+func Marshal(v any) ([]byte, error) {
+	return MarshalOptions{}.Marshal(v)
+}
+
+// Marshal encodes v as JSON, rendering protobuf messages with protojson.
+func (o MarshalOptions) Marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := o.encode(&buf, reflect.ValueOf(v)); err != nil {
+		return nil, err
+	}
+	if !o.Multiline && o.Indent == "" {
+		return buf.Bytes(), nil
+	}
+	indent := o.Indent
+	if indent == "" {
+		indent = "  "
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, buf.Bytes(), "", indent); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+var (
+	messageType       = reflect.TypeFor[proto.Message]()
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+)
+
+func (o MarshalOptions) encode(buf *bytes.Buffer, v reflect.Value) error {
+	if !v.IsValid() {
+		buf.WriteString("null")
+		return nil
+	}
+	t := v.Type()
+	switch {
+	case t.Implements(messageType):
+		if v.Kind() == reflect.Pointer && v.IsNil() {
+			buf.WriteString("null")
+			return nil
+		}
+		data, err := o.MarshalOptions.Marshal(v.Interface().(proto.Message))
+		if err != nil {
+			return err
+		}
+		// protojson varies its whitespace between runs; normalize it.
+		return json.Compact(buf, data)
+	case nullreflect.IsNullable(t):
+		if !nullreflect.Valid(v) {
+			buf.WriteString("null")
+			return nil
+		}
+		return o.encode(buf, nullreflect.Inner(v))
+	case t.Implements(jsonMarshalerType):
+		return marshalJSON(buf, v)
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			buf.WriteString("null")
+			return nil
+		}
+		return o.encode(buf, v.Elem())
+	case reflect.Struct:
+		buf.WriteByte('{')
+		first := true
+		if err := o.encodeFields(buf, v, &first); err != nil {
+			return err
+		}
+		buf.WriteByte('}')
+		return nil
+	case reflect.Map:
+		return o.encodeMap(buf, v)
+	case reflect.Slice:
+		if v.IsNil() {
+			buf.WriteString("null")
+			return nil
+		}
+		if t.Elem().Kind() == reflect.Uint8 {
+			return marshalJSON(buf, v)
+		}
+		fallthrough
+	case reflect.Array:
+		buf.WriteByte('[')
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := o.encode(buf, v.Index(i)); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		return nil
+	}
+	return marshalJSON(buf, v)
+}
+
+func marshalJSON(buf *bytes.Buffer, v reflect.Value) error {
+	data, err := json.Marshal(v.Interface())
+	if err != nil {
+		return err
+	}
+	buf.Write(data)
+	return nil
+}
+
+func (o MarshalOptions) encodeFields(buf *bytes.Buffer, v reflect.Value, first *bool) error {
+	for _, f := range structFields(v.Type(), o.UseProtoNames) {
+		fv, ok := fieldByIndex(v, f.index)
+		if !ok || f.omitEmpty && isEmpty(fv) || !o.EmitUnpopulated && isUnpopulated(fv) {
+			continue
+		}
+		if !*first {
+			buf.WriteByte(',')
+		}
+		*first = false
+		name, _ := json.Marshal(f.name)
+		buf.Write(name)
+		buf.WriteByte(':')
+		if err := o.encode(buf, fv); err != nil {
+			return fmt.Errorf("nullprotojson: field %s: %w", f.name, err)
+		}
+	}
+	return nil
+}
+
+func (o MarshalOptions) encodeMap(buf *bytes.Buffer, v reflect.Value) error {
+	if v.IsNil() {
+		buf.WriteString("null")
+		return nil
+	}
+	keys := make([]string, 0, v.Len())
+	values := make(map[string]reflect.Value, v.Len())
+	for it := v.MapRange(); it.Next(); {
+		k, err := mapKey(it.Key())
+		if err != nil {
+			return err
+		}
+		keys = append(keys, k)
+		values[k] = it.Value()
+	}
+	slices.Sort(keys)
+	buf.WriteByte('{')
+	for i, k := range keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, _ := json.Marshal(k)
+		buf.Write(name)
+		buf.WriteByte(':')
+		if err := o.encode(buf, values[k]); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+func mapKey(k reflect.Value) (string, error) {
+	switch k.Kind() {
+	case reflect.String:
+		return k.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(k.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(k.Uint(), 10), nil
+	}
+	return "", fmt.Errorf("nullprotojson: unsupported map key type %s", k.Type())
+}
+
+// isUnpopulated reports whether v is a null Nullable or a nil message,
+// which protojson would leave out.
+func isUnpopulated(v reflect.Value) bool {
+	if nullreflect.IsNullable(v.Type()) {
+		return !nullreflect.Valid(v)
+	}
+	return v.Kind() == reflect.Pointer && v.IsNil() && v.Type().Implements(messageType)
+}
+
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Pointer, reflect.Interface:
+		return v.IsNil()
+	case reflect.Struct:
+		return nullreflect.IsNullable(v.Type()) && !nullreflect.Valid(v)
+	}
+	return v.IsZero()
+}
+
+// field is a struct field with its JSON name.
+type field struct {
+	name      string
+	index     []int
+	omitEmpty bool
+	// names are the keys accepted when decoding.
+	names []string
+}
+
+// structFields returns the JSON fields of struct type t, descending into
+// embedded structs without a json tag.
+func structFields(t reflect.Type, protoNames bool) []field {
+	var fields []field
+	var walk func(t reflect.Type, index []int)
+	walk = func(t reflect.Type, index []int) {
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			name, opts, ok := nullreflect.JSONTag(sf)
+			if !ok {
+				continue
+			}
+			idx := append(slices.Clone(index), i)
+			if nullreflect.IsEmbeddedJSON(sf) {
+				ft := sf.Type
+				if ft.Kind() == reflect.Pointer {
+					ft = ft.Elem()
+				}
+				if !reflect.PointerTo(ft).Implements(messageType) {
+					walk(ft, idx)
+					continue
+				}
+			}
+			if !sf.IsExported() {
+				continue
+			}
+			f := field{index: idx, omitEmpty: nullreflect.HasJSONOption(opts, "omitempty")}
+			if name != "" {
+				f.name = name
+				f.names = []string{name}
+			} else {
+				camel, snake := lowerCamel(sf.Name), snakeCase(sf.Name)
+				f.name = camel
+				if protoNames {
+					f.name = snake
+				}
+				f.names = []string{camel, snake, sf.Name}
+			}
+			fields = append(fields, f)
+		}
+	}
+	walk(t, nil)
+	return fields
+}
+
+// fieldByIndex is like reflect.Value.FieldByIndex but reports false
+// instead of panicking at nil embedded pointers.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// lowerCamel lowercases the leading initialism of a Go name, so "UserID"
+// becomes "userID" and "URLPath" becomes "urlPath".
+func lowerCamel(name string) string {
+	r := []rune(name)
+	for i := range r {
+		if !unicode.IsUpper(r[i]) {
+			break
+		}
+		if i > 0 && i+1 < len(r) && unicode.IsLower(r[i+1]) {
+			break
+		}
+		r[i] = unicode.ToLower(r[i])
+	}
+	return string(r)
+}
+
+// snakeCase converts a Go name to snake_case, keeping initialisms
+// together: "UserID" becomes "user_id" and "URLPath" becomes "url_path".
+func snakeCase(name string) string {
+	r := []rune(name)
+	var b strings.Builder
+	for i, c := range r {
+		if unicode.IsUpper(c) {
+			if i > 0 && (!unicode.IsUpper(r[i-1]) || i+1 < len(r) && unicode.IsLower(r[i+1])) {
+				b.WriteByte('_')
+			}
+			c = unicode.ToLower(c)
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
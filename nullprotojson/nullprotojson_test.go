@@ -0,0 +1,162 @@
+package nullprotojson
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/manattan/nullable"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type page struct {
+	NextPage nullable.Nullable[string]
+}
+
+type response struct {
+	page
+	RequestID string
+	Field     *descriptorpb.FieldDescriptorProto
+	UpdatedAt *timestamppb.Timestamp
+	Count     nullable.Nullable[int] `json:"total"`
+	Items     []*wrapperspb.StringValue
+	Labels    map[string]nullable.Nullable[string]
+	Note      string `json:"note,omitempty"`
+	Internal  string `json:"-"`
+}
+
+func sample() response {
+	return response{
+		RequestID: "r1",
+		Field:     &descriptorpb.FieldDescriptorProto{Name: proto.String("id"), JsonName: proto.String("id")},
+		UpdatedAt: timestamppb.New(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)),
+		Count:     nullable.NewNullable(3),
+		Items:     []*wrapperspb.StringValue{wrapperspb.String("a")},
+		Labels:    map[string]nullable.Nullable[string]{"b": nullable.NewNull[string](), "a": nullable.NewNullable("x")},
+		Internal:  "secret",
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	data, err := Marshal(sample())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := `{"requestID":"r1","field":{"name":"id","jsonName":"id"},"updatedAt":"2024-05-01T12:00:00Z","total":3,"items":["a"],"labels":{"a":"x","b":null}}`
+	if string(data) != want {
+		t.Errorf("Expected %s, got %s", want, data)
+	}
+}
+
+func TestMarshalTags(t *testing.T) {
+	in := struct {
+		Dash  string `json:"-,"`
+		Skip  string `json:"-"`
+		Empty string `json:",omitempty"`
+		Plain string `json:",omitempty"`
+	}{Dash: "d", Skip: "s", Plain: "p"}
+	data, err := Marshal(in)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := `{"-":"d","plain":"p"}`
+	if string(data) != want {
+		t.Errorf("Expected %s, got %s", want, data)
+	}
+}
+
+func TestMarshalOptions(t *testing.T) {
+	r := sample()
+	r.Field, r.Count = nil, nullable.NewNull[int]()
+	o := MarshalOptions{protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}}
+	data, err := o.Marshal(r)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := `{"next_page":null,"request_id":"r1","field":null,"updated_at":"2024-05-01T12:00:00Z","total":null,"items":["a"],"labels":{"a":"x","b":null}}`
+	if string(data) != want {
+		t.Errorf("Expected %s, got %s", want, data)
+	}
+
+	o = MarshalOptions{protojson.MarshalOptions{Multiline: true}}
+	data, err = o.Marshal(page{NextPage: nullable.NewNullable("p2")})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := "{\n  \"nextPage\": \"p2\"\n}"; string(data) != want {
+		t.Errorf("Expected %q, got %q", want, data)
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	in := sample()
+	data, err := Marshal(in)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var out response
+	if err := Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	switch {
+	case out.RequestID != "r1" || out.NextPage.Valid || out.Count.V != 3:
+		t.Errorf("Unexpected fields: %+v", out)
+	case !proto.Equal(out.Field, in.Field) || !proto.Equal(out.UpdatedAt, in.UpdatedAt):
+		t.Errorf("Expected messages %v %v, got %v %v", in.Field, in.UpdatedAt, out.Field, out.UpdatedAt)
+	case len(out.Items) != 1 || out.Items[0].GetValue() != "a":
+		t.Errorf("Expected items [a], got %v", out.Items)
+	case out.Labels["a"].V != "x" || out.Labels["b"].Valid:
+		t.Errorf("Expected labels, got %v", out.Labels)
+	}
+
+	snake := `{"next_page":"p2","request_id":"r2","updated_at":null,"field":{"json_name":"x"}}`
+	out = response{}
+	if err := Unmarshal([]byte(snake), &out); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if out.NextPage.V != "p2" || out.RequestID != "r2" || out.UpdatedAt != nil || out.Field.GetJsonName() != "x" {
+		t.Errorf("Expected snake_case keys to decode, got %+v", out)
+	}
+}
+
+func TestUnmarshalUnknown(t *testing.T) {
+	data := []byte(`{"requestID":"r1","extra":1}`)
+	var out response
+	if err := Unmarshal(data, &out); err == nil || !strings.Contains(err.Error(), `"extra"`) {
+		t.Errorf("Expected unknown field error, got %v", err)
+	}
+	o := UnmarshalOptions{protojson.UnmarshalOptions{DiscardUnknown: true}}
+	if err := o.Unmarshal(data, &out); err != nil || out.RequestID != "r1" {
+		t.Errorf("Expected unknown field to be ignored, got %+v (%v)", out, err)
+	}
+	if err := Unmarshal([]byte(`{"field":{"bogus":1}}`), &out); err == nil {
+		t.Error("Expected protojson error for unknown message field")
+	}
+	if err := Unmarshal([]byte(`{`), &out); err == nil {
+		t.Error("Expected syntax error")
+	}
+	if err := Unmarshal(data, out); err == nil {
+		t.Error("Expected error for non-pointer")
+	}
+}
+
+func TestNames(t *testing.T) {
+	for _, tc := range []struct{ in, camel, snake string }{
+		{"UserID", "userID", "user_id"},
+		{"URLPath", "urlPath", "url_path"},
+		{"ID", "id", "id"},
+		{"Name", "name", "name"},
+		{"HTTPStatusCode", "httpStatusCode", "http_status_code"},
+	} {
+		if got := lowerCamel(tc.in); got != tc.camel {
+			t.Errorf("lowerCamel(%s): expected %s, got %s", tc.in, tc.camel, got)
+		}
+		if got := snakeCase(tc.in); got != tc.snake {
+			t.Errorf("snakeCase(%s): expected %s, got %s", tc.in, tc.snake, got)
+		}
+	}
+}
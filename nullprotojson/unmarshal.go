@@ -0,0 +1,180 @@
+package nullprotojson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/manattan/nullable/internal/nullreflect"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// UnmarshalOptions configures Unmarshal. The embedded protojson options
+// decode messages; DiscardUnknown also ignores unknown keys in the
+// surrounding structs, which are otherwise an error as in protojson.
+type UnmarshalOptions struct {
+	protojson.UnmarshalOptions
+}
+
+// Unmarshal decodes data into v with the default options.
+func Unmarshal(data []byte, v any) error {
+	return UnmarshalOptions{}.Unmarshal(data, v)
+}
+
+// Unmarshal decodes the JSON in data into the value pointed to by v,
+// decoding protobuf messages with protojson. Fields without a json tag
+// accept both their lowerCamelCase and snake_case names.
+func (o UnmarshalOptions) Unmarshal(data []byte, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return &json.InvalidUnmarshalError{Type: reflect.TypeOf(v)}
+	}
+	if !json.Valid(data) {
+		return json.Unmarshal(data, v)
+	}
+	if m, ok := v.(proto.Message); ok {
+		return o.UnmarshalOptions.Unmarshal(data, m)
+	}
+	return o.decode(bytes.TrimSpace(data), rv.Elem())
+}
+
+var jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+
+func (o UnmarshalOptions) decode(data []byte, v reflect.Value) error {
+	t := v.Type()
+	null := string(data) == "null"
+	switch {
+	case t.Kind() == reflect.Pointer && t.Implements(messageType):
+		if null {
+			v.SetZero()
+			return nil
+		}
+		if v.IsNil() {
+			v.Set(reflect.New(t.Elem()))
+		}
+		return o.UnmarshalOptions.Unmarshal(data, v.Interface().(proto.Message))
+	case nullreflect.IsNullable(t):
+		if null {
+			v.SetZero()
+			return nil
+		}
+		nullreflect.SetValid(v, true)
+		return o.decode(data, nullreflect.Inner(v))
+	case reflect.PointerTo(t).Implements(jsonUnmarshalerType):
+		return json.Unmarshal(data, v.Addr().Interface())
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		if null {
+			v.SetZero()
+			return nil
+		}
+		if v.IsNil() {
+			v.Set(reflect.New(t.Elem()))
+		}
+		return o.decode(data, v.Elem())
+	case reflect.Struct:
+		if null {
+			return nil
+		}
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return err
+		}
+		return o.decodeFields(obj, v)
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			break
+		}
+		if null {
+			v.SetZero()
+			return nil
+		}
+		var elems []json.RawMessage
+		if err := json.Unmarshal(data, &elems); err != nil {
+			return err
+		}
+		s := reflect.MakeSlice(t, len(elems), len(elems))
+		for i, e := range elems {
+			if err := o.decode(e, s.Index(i)); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+		return nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			break
+		}
+		if null {
+			v.SetZero()
+			return nil
+		}
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return err
+		}
+		if v.IsNil() {
+			v.Set(reflect.MakeMapWithSize(t, len(obj)))
+		}
+		for k, e := range obj {
+			ev := reflect.New(t.Elem()).Elem()
+			if err := o.decode(e, ev); err != nil {
+				return err
+			}
+			v.SetMapIndex(reflect.ValueOf(k).Convert(t.Key()), ev)
+		}
+		return nil
+	}
+	return json.Unmarshal(data, v.Addr().Interface())
+}
+
+func (o UnmarshalOptions) decodeFields(obj map[string]json.RawMessage, v reflect.Value) error {
+	seen := make(map[string]bool, len(obj))
+	for _, f := range structFields(v.Type(), false) {
+		i := slices.IndexFunc(f.names, func(name string) bool { _, ok := obj[name]; return ok })
+		if i < 0 {
+			continue
+		}
+		key := f.names[i]
+		seen[key] = true
+		fv, err := allocField(v, f.index)
+		if err != nil {
+			return err
+		}
+		if err := o.decode(obj[key], fv); err != nil {
+			return fmt.Errorf("nullprotojson: field %s: %w", key, err)
+		}
+	}
+	if o.DiscardUnknown {
+		return nil
+	}
+	for key := range obj {
+		if !seen[key] {
+			return fmt.Errorf("nullprotojson: unknown field %q in %s", key, v.Type())
+		}
+	}
+	return nil
+}
+
+// allocField returns the field of v at index, allocating nil embedded
+// struct pointers on the way.
+func allocField(v reflect.Value, index []int) (reflect.Value, error) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, fmt.Errorf("nullprotojson: cannot set embedded pointer to unexported struct %s", v.Type().Elem())
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, nil
+}
@@ -0,0 +1,59 @@
+// Package nullsecurecookie stores structs with nullable.Nullable fields in
+// gorilla/securecookie cookies and the session stores built on it.
+//
+// Serializer encodes values with the compact binary encoding of
+// nullable.EncodeBinary, which keeps cookies small and preserves nulls:
+//
+//	s := securecookie.New(hashKey, blockKey)
+//	s.SetSerializer(nullsecurecookie.Serializer{})
+//	encoded, err := s.Encode("prefs", prefs)
+//
+// Session stores such as gorilla/sessions serialize their
+// map[interface{}]interface{} values, which the binary encoding cannot
+// describe; Serializer encodes those maps with gob instead, so Nullable types
+// stored in sessions must be registered with Register.
+package nullsecurecookie
+
+import (
+	"bytes"
+	"encoding/gob"
+
+	"github.com/gorilla/securecookie"
+
+	"github.com/manattan/nullable"
+)
+
+// Serializer is a securecookie.Serializer using the binary encoding of the
+// nullable package.
+type Serializer struct{}
+
+var _ securecookie.Serializer = Serializer{}
+
+// Serialize encodes src with nullable.EncodeBinary, or with gob for session
+// value maps.
+func (Serializer) Serialize(src any) ([]byte, error) {
+	switch src.(type) {
+	case map[any]any, *map[any]any:
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(src); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+	return nullable.EncodeBinary(src)
+}
+
+// Deserialize decodes src into dst, which must be a pointer to the type
+// passed to Serialize.
+func (Serializer) Deserialize(src []byte, dst any) error {
+	if _, ok := dst.(*map[any]any); ok {
+		return gob.NewDecoder(bytes.NewReader(src)).Decode(dst)
+	}
+	return nullable.DecodeBinary(src, dst)
+}
+
+// Register registers Nullable[T] with gob so it can be stored as a session
+// value.
+func Register[T any]() {
+	gob.Register(nullable.Nullable[T]{})
+}
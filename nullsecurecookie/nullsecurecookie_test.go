@@ -0,0 +1,78 @@
+package nullsecurecookie
+
+import (
+	"testing"
+
+	"github.com/gorilla/securecookie"
+
+	"github.com/manattan/nullable"
+)
+
+type prefs struct {
+	Theme    nullable.Nullable[string]
+	FontSize nullable.Nullable[int]
+	Beta     bool
+}
+
+func newCodec(sz securecookie.Serializer) *securecookie.SecureCookie {
+	s := securecookie.New([]byte("0123456789abcdef0123456789abcdef"), []byte("0123456789abcdef"))
+	s.SetSerializer(sz)
+	return s
+}
+
+func TestSerializerRoundTrip(t *testing.T) {
+	s := newCodec(Serializer{})
+	in := prefs{Theme: nullable.NewNullable("dark"), Beta: true}
+	encoded, err := s.Encode("prefs", in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out prefs
+	if err := s.Decode("prefs", encoded, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out != in {
+		t.Errorf("Expected %+v, got %+v", in, out)
+	}
+	if out.FontSize.Valid {
+		t.Error("Expected FontSize to stay null")
+	}
+
+	jsonEncoded, err := newCodec(securecookie.JSONEncoder{}).Encode("prefs", in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(encoded) >= len(jsonEncoded) {
+		t.Errorf("Expected binary cookie (%d bytes) to be smaller than JSON (%d bytes)", len(encoded), len(jsonEncoded))
+	}
+}
+
+func TestSerializerSessionValues(t *testing.T) {
+	Register[string]()
+	s := newCodec(Serializer{})
+	values := map[any]any{
+		"name":  nullable.NewNullable("Alice"),
+		"email": nullable.NewNull[string](),
+	}
+	encoded, err := s.Encode("session", values)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out map[any]any
+	if err := s.Decode("session", encoded, &out); err != nil {
+		t.Fatal(err)
+	}
+	if got := out["name"]; got != nullable.NewNullable("Alice") {
+		t.Errorf("Expected name Alice, got %v", got)
+	}
+	if got := out["email"]; got != nullable.NewNull[string]() {
+		t.Errorf("Expected null email, got %v", got)
+	}
+}
+
+func TestSerializerRejectsForeignData(t *testing.T) {
+	var out prefs
+	if err := (Serializer{}).Deserialize([]byte(`{"Theme":"dark"}`), &out); err == nil {
+		t.Error("Expected an error for JSON data")
+	}
+}
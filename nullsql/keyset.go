@@ -0,0 +1,216 @@
+package nullsql
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/manattan/nullable"
+)
+
+// ErrInvalidCursor is returned by DecodeCursor for malformed cursors.
+var ErrInvalidCursor = errors.New("nullsql: invalid cursor")
+
+// NullOrder places NULLs within a sort key.
+type NullOrder int
+
+// Null orderings. NullsDefault uses the dialect's native ordering: NULLs sort
+// above all values on Postgres and below them elsewhere.
+const (
+	NullsDefault NullOrder = iota
+	NullsFirst
+	NullsLast
+)
+
+// SortKey is a column of a keyset ordering.
+type SortKey struct {
+	Column string
+	Desc   bool
+	Nulls  NullOrder
+	// NotNull marks columns that cannot hold NULL, such as primary keys,
+	// sparing their conditions the NULL checks.
+	NotNull bool
+}
+
+// Keyset is an ordering for keyset pagination. The last key should be
+// unique, such as a primary key, so every row has a distinct position.
+//
+// Naive keyset conditions such as (a, b) > (?, ?) break when a sort key can
+// be NULL, since comparisons with NULL are never true. Keyset expands the
+// tuple comparison key by key, matching NULLs with IS NULL and placing them
+// according to each key's null ordering:
+//
+//	ks := nullsql.Keyset{Dialect: nullable.Postgres, Keys: []nullsql.SortKey{
+//		{Column: "due_at", Nulls: nullsql.NullsLast},
+//		{Column: "id", NotNull: true},
+//	}}
+//	var dueAt nullable.Nullable[time.Time]
+//	var id int64
+//	err := nullsql.DecodeCursor(cursor, &dueAt, &id)
+//	cond, args, err := ks.After([]any{dueAt, id}, nil)
+//	query := "SELECT * FROM tasks WHERE " + cond + " " + ks.OrderBy() + " LIMIT 20"
+type Keyset struct {
+	Dialect nullable.Dialect
+	Keys    []SortKey
+}
+
+// nullsFirst reports whether NULLs of key sort before its values.
+func (k Keyset) nullsFirst(key SortKey) bool {
+	switch key.Nulls {
+	case NullsFirst:
+		return true
+	case NullsLast:
+		return false
+	}
+	// NULL is the largest value on Postgres and the smallest elsewhere.
+	return (k.Dialect == nullable.Postgres) == key.Desc
+}
+
+// OrderBy returns the ORDER BY clause of the keyset. Null orderings other
+// than the dialect's native one are emitted as NULLS FIRST/LAST on Postgres
+// and SQLite and emulated with an IS NULL sort term on MySQL and SQL Server.
+func (k Keyset) OrderBy() string {
+	terms := make([]string, 0, len(k.Keys))
+	for _, key := range k.Keys {
+		col := quoteIdent(k.Dialect, key.Column)
+		dir := " ASC"
+		if key.Desc {
+			dir = " DESC"
+		}
+		first := k.nullsFirst(key)
+		native := (k.Dialect == nullable.Postgres) == key.Desc
+		if first == native {
+			terms = append(terms, col+dir)
+			continue
+		}
+		switch k.Dialect {
+		case nullable.Postgres, nullable.SQLite:
+			if first {
+				terms = append(terms, col+dir+" NULLS FIRST")
+			} else {
+				terms = append(terms, col+dir+" NULLS LAST")
+			}
+		case nullable.MySQL:
+			if first {
+				terms = append(terms, col+" IS NOT NULL", col+dir)
+			} else {
+				terms = append(terms, col+" IS NULL", col+dir)
+			}
+		default:
+			if first {
+				terms = append(terms, "CASE WHEN "+col+" IS NULL THEN 0 ELSE 1 END", col+dir)
+			} else {
+				terms = append(terms, "CASE WHEN "+col+" IS NULL THEN 1 ELSE 0 END", col+dir)
+			}
+		}
+	}
+	return "ORDER BY " + strings.Join(terms, ", ")
+}
+
+// After returns a condition matching the rows that follow the row whose sort
+// key values are values, in key order. Values may be Nullables, pointers or
+// nil for NULL. The condition's arguments are appended to args and numbered
+// after them, so it can be combined with a WHERE clause built earlier.
+func (k Keyset) After(values []any, args []any) (string, []any, error) {
+	if len(values) != len(k.Keys) {
+		return "", nil, fmt.Errorf("nullsql: cursor has %d values for %d sort keys", len(values), len(k.Keys))
+	}
+	vals := make([]any, len(values))
+	nulls := make([]bool, len(values))
+	for i, v := range values {
+		vals[i], nulls[i] = cursorArg(v)
+	}
+
+	var disjuncts []string
+	for i, key := range k.Keys {
+		if nulls[i] && !k.nullsFirst(key) {
+			continue // nothing sorts after NULL
+		}
+
+		terms := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			prev := quoteIdent(k.Dialect, k.Keys[j].Column)
+			if nulls[j] {
+				terms = append(terms, prev+" IS NULL")
+				continue
+			}
+			args = append(args, vals[j])
+			terms = append(terms, prev+" = "+placeholder(k.Dialect, len(args)))
+		}
+
+		col := quoteIdent(k.Dialect, key.Column)
+		if nulls[i] {
+			terms = append(terms, col+" IS NOT NULL")
+		} else {
+			op := " > "
+			if key.Desc {
+				op = " < "
+			}
+			args = append(args, vals[i])
+			after := col + op + placeholder(k.Dialect, len(args))
+			if !key.NotNull && !k.nullsFirst(key) {
+				after = "(" + after + " OR " + col + " IS NULL)"
+			}
+			terms = append(terms, after)
+		}
+		disjuncts = append(disjuncts, strings.Join(terms, " AND "))
+	}
+
+	switch len(disjuncts) {
+	case 0:
+		return "1 = 0", args, nil
+	case 1:
+		return disjuncts[0], args, nil
+	}
+	return "(" + strings.Join(disjuncts, " OR ") + ")", args, nil
+}
+
+// cursorArg returns the driver argument of a cursor value and whether it is
+// NULL.
+func cursorArg(v any) (any, bool) {
+	if v == nil {
+		return nil, true
+	}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, true
+		}
+		rv = rv.Elem()
+	}
+	c := newColumn("", rv)
+	return c.arg, !c.valid
+}
+
+// EncodeCursor encodes the sort key values of a row as an opaque URL-safe
+// cursor. Null Nullables are encoded as null and restored by DecodeCursor.
+func EncodeCursor(values ...any) (string, error) {
+	data, err := json.Marshal(values)
+	if err != nil {
+		return "", fmt.Errorf("nullsql: encoding cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeCursor decodes a cursor produced by EncodeCursor into the values
+// pointed to by dst, which must match the encoded values in number and
+// order.
+func DecodeCursor(cursor string, dst ...any) error {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return ErrInvalidCursor
+	}
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil || len(raw) != len(dst) {
+		return ErrInvalidCursor
+	}
+	for i, r := range raw {
+		if err := json.Unmarshal(r, dst[i]); err != nil {
+			return fmt.Errorf("%w: value %d: %v", ErrInvalidCursor, i, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package nullsql
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/manattan/nullable"
+)
+
+func TestKeysetAfter(t *testing.T) {
+	due := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		ks     Keyset
+		values []any
+		want   string
+		args   []any
+	}{
+		{
+			name:   "value nulls last",
+			ks:     Keyset{Dialect: nullable.Postgres, Keys: []SortKey{{Column: "due_at"}, {Column: "id", NotNull: true}}},
+			values: []any{nullable.NewNullable(due), int64(5)},
+			want:   `(("due_at" > $1 OR "due_at" IS NULL) OR "due_at" = $2 AND "id" > $3)`,
+			args:   []any{due, due, int64(5)},
+		},
+		{
+			name:   "null nulls last",
+			ks:     Keyset{Dialect: nullable.Postgres, Keys: []SortKey{{Column: "due_at"}, {Column: "id", NotNull: true}}},
+			values: []any{nullable.NewNull[time.Time](), int64(5)},
+			want:   `"due_at" IS NULL AND "id" > $1`,
+			args:   []any{int64(5)},
+		},
+		{
+			name:   "null nulls first",
+			ks:     Keyset{Dialect: nullable.Postgres, Keys: []SortKey{{Column: "due_at", Nulls: NullsFirst}, {Column: "id", NotNull: true}}},
+			values: []any{nil, int64(5)},
+			want:   `("due_at" IS NOT NULL OR "due_at" IS NULL AND "id" > $1)`,
+			args:   []any{int64(5)},
+		},
+		{
+			name:   "value nulls first",
+			ks:     Keyset{Dialect: nullable.MySQL, Keys: []SortKey{{Column: "due_at"}, {Column: "id", NotNull: true}}},
+			values: []any{&due, int64(5)},
+			want:   "(`due_at` > ? OR `due_at` = ? AND `id` > ?)",
+			args:   []any{due, due, int64(5)},
+		},
+		{
+			name:   "descending",
+			ks:     Keyset{Dialect: nullable.MySQL, Keys: []SortKey{{Column: "due_at", Desc: true}, {Column: "id", Desc: true, NotNull: true}}},
+			values: []any{(*time.Time)(nil), int64(5)},
+			want:   "`due_at` IS NULL AND `id` < ?",
+			args:   []any{int64(5)},
+		},
+		{
+			name:   "last row",
+			ks:     Keyset{Dialect: nullable.Postgres, Keys: []SortKey{{Column: "due_at"}}},
+			values: []any{nil},
+			want:   "1 = 0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cond, args, err := tt.ks.After(tt.values, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cond != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, cond)
+			}
+			if !reflect.DeepEqual(args, tt.args) {
+				t.Errorf("Expected args %v, got %v", tt.args, args)
+			}
+		})
+	}
+}
+
+func TestKeysetAfterAppendsArgs(t *testing.T) {
+	ks := Keyset{Dialect: nullable.Postgres, Keys: []SortKey{{Column: "id", NotNull: true}}}
+	cond, args, err := ks.After([]any{int64(9)}, []any{"open"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cond != `"id" > $2` {
+		t.Errorf(`Expected "id" > $2, got %s`, cond)
+	}
+	if want := []any{"open", int64(9)}; !reflect.DeepEqual(args, want) {
+		t.Errorf("Expected args %v, got %v", want, args)
+	}
+	if _, _, err := ks.After([]any{1, 2}, nil); err == nil {
+		t.Error("Expected an error for a value count mismatch")
+	}
+}
+
+func TestKeysetOrderBy(t *testing.T) {
+	tests := []struct {
+		ks   Keyset
+		want string
+	}{
+		{Keyset{Dialect: nullable.Postgres, Keys: []SortKey{{Column: "due_at", Nulls: NullsLast}, {Column: "id", NotNull: true}}}, `ORDER BY "due_at" ASC, "id" ASC`},
+		{Keyset{Dialect: nullable.Postgres, Keys: []SortKey{{Column: "due_at", Nulls: NullsFirst}}}, `ORDER BY "due_at" ASC NULLS FIRST`},
+		{Keyset{Dialect: nullable.SQLite, Keys: []SortKey{{Column: "due_at", Desc: true, Nulls: NullsFirst}}}, `ORDER BY "due_at" DESC NULLS FIRST`},
+		{Keyset{Dialect: nullable.MySQL, Keys: []SortKey{{Column: "due_at", Nulls: NullsLast}}}, "ORDER BY `due_at` IS NULL, `due_at` ASC"},
+		{Keyset{Dialect: nullable.SQLServer, Keys: []SortKey{{Column: "due_at", Desc: true, Nulls: NullsFirst}}}, "ORDER BY CASE WHEN [due_at] IS NULL THEN 0 ELSE 1 END, [due_at] DESC"},
+	}
+	for _, tt := range tests {
+		if got := tt.ks.OrderBy(); got != tt.want {
+			t.Errorf("Expected %s, got %s", tt.want, got)
+		}
+	}
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	cursor, err := EncodeCursor(nullable.NewNull[time.Time](), int64(42))
+	if err != nil {
+		t.Fatal(err)
+	}
+	due := nullable.NewNullable(time.Now())
+	var id int64
+	if err := DecodeCursor(cursor, &due, &id); err != nil {
+		t.Fatal(err)
+	}
+	if due.Valid || id != 42 {
+		t.Errorf("Expected null and 42, got %v and %d", due, id)
+	}
+
+	for _, bad := range []string{"!!", "bnVsbA", cursor[:len(cursor)-2]} {
+		if err := DecodeCursor(bad, &due, &id); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("%q: Expected ErrInvalidCursor, got %v", bad, err)
+		}
+	}
+	if err := DecodeCursor(cursor, &id); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("Expected ErrInvalidCursor for a count mismatch, got %v", err)
+	}
+}
@@ -0,0 +1,117 @@
+// Package nullsql builds SQL statements from structs with nullable.Nullable
+// fields, using each field's validity to decide which columns a statement
+// touches.
+//
+// Columns are named by the db struct tag, or the field name when there is
+// none; a tag of "-" skips the field. Embedded structs without a db tag
+// contribute their fields. Arguments are returned in placeholder order, with
+// null Nullables passed as nil and valid ones as their inner value.
+package nullsql
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/manattan/nullable"
+	"github.com/manattan/nullable/internal/nullreflect"
+)
+
+// column is a struct field mapped to a database column.
+type column struct {
+	name string
+	// arg is the driver argument for the field's value.
+	arg any
+	// valid is false for null Nullable fields and true otherwise.
+	valid bool
+}
+
+// columns returns the columns of the struct held in or pointed to by row.
+func columns(row any) ([]column, error) {
+	rv := reflect.ValueOf(row)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("nullsql: expected a struct, got %T", row)
+	}
+	var cols []column
+	collect(rv, &cols)
+	if len(cols) == 0 {
+		return nil, fmt.Errorf("nullsql: %s has no columns", rv.Type())
+	}
+	return cols, nil
+}
+
+func collect(v reflect.Value, cols *[]column) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, hasTag := sf.Tag.Lookup("db")
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "-" || !sf.IsExported() && !sf.Anonymous {
+			continue
+		}
+		fv := v.Field(i)
+		if sf.Anonymous && !hasTag && !nullreflect.IsNullable(sf.Type) {
+			if sf.Type.Kind() == reflect.Pointer {
+				if fv.IsNil() {
+					continue
+				}
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct {
+				collect(fv, cols)
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		*cols = append(*cols, newColumn(name, fv))
+	}
+}
+
+func newColumn(name string, v reflect.Value) column {
+	if nullreflect.IsNullable(v.Type()) {
+		if !nullreflect.Valid(v) {
+			return column{name: name}
+		}
+		return column{name: name, arg: nullreflect.Inner(v).Interface(), valid: true}
+	}
+	// Wrapper types embed a Nullable as their first field and implement
+	// driver.Valuer themselves.
+	if v.Kind() == reflect.Struct && v.NumField() > 0 && v.Type().Field(0).Anonymous && nullreflect.IsNullable(v.Field(0).Type()) {
+		return column{name: name, arg: v.Interface(), valid: nullreflect.Valid(v.Field(0))}
+	}
+	if v.Kind() == reflect.Pointer && v.IsNil() {
+		return column{name: name, valid: true}
+	}
+	return column{name: name, arg: v.Interface(), valid: true}
+}
+
+// quoteIdent quotes a column name for dialect d.
+func quoteIdent(d nullable.Dialect, name string) string {
+	switch d {
+	case nullable.MySQL:
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	case nullable.SQLServer:
+		return "[" + strings.ReplaceAll(name, "]", "]]") + "]"
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// placeholder returns the nth (1-based) bind parameter for dialect d.
+func placeholder(d nullable.Dialect, n int) string {
+	switch d {
+	case nullable.Postgres:
+		return "$" + strconv.Itoa(n)
+	case nullable.SQLServer:
+		return "@p" + strconv.Itoa(n)
+	}
+	return "?"
+}
@@ -0,0 +1,58 @@
+package nullsql
+
+import (
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/manattan/nullable"
+)
+
+type timestamps struct {
+	Updated nullable.Nullable[string] `db:"updated_at"`
+}
+
+type user struct {
+	ID    int64                     `db:"id"`
+	Name  nullable.Nullable[string] `db:"name"`
+	Email nullable.Nullable[string] `db:"email"`
+	Mac   nullable.HardwareAddr     `db:"mac"`
+	Note  *string
+	Skip  string `db:"-"`
+	timestamps
+	hidden int
+}
+
+func TestColumns(t *testing.T) {
+	mac, _ := net.ParseMAC("00:00:5e:00:53:01")
+	u := user{
+		ID:         1,
+		Name:       nullable.NewNullable("Alice"),
+		Mac:        nullable.NewHardwareAddr(mac),
+		timestamps: timestamps{Updated: nullable.NewNullable("now")},
+	}
+	cols, err := columns(&u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []column{
+		{name: "id", arg: int64(1), valid: true},
+		{name: "name", arg: "Alice", valid: true},
+		{name: "email"},
+		{name: "mac", arg: u.Mac, valid: true},
+		{name: "Note", valid: true},
+		{name: "updated_at", arg: "now", valid: true},
+	}
+	if !reflect.DeepEqual(cols, want) {
+		t.Errorf("Expected %+v, got %+v", want, cols)
+	}
+}
+
+func TestColumnsErrors(t *testing.T) {
+	if _, err := columns(42); err == nil {
+		t.Error("Expected an error for a non-struct")
+	}
+	if _, err := columns(struct{ x int }{}); err == nil {
+		t.Error("Expected an error for a struct without columns")
+	}
+}
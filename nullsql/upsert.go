@@ -0,0 +1,94 @@
+package nullsql
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/manattan/nullable"
+)
+
+// Upsert returns an INSERT statement for row into table that updates the
+// existing row when it conflicts on the conflict columns, together with its
+// arguments. All columns are inserted, but the update clause only sets
+// columns whose fields are valid, so null fields leave stored values alone:
+//
+//	query, args, err := nullsql.Upsert(nullable.Postgres, "users", u, "id")
+//	// INSERT INTO users ("id", "name", "email") VALUES ($1, $2, $3)
+//	// ON CONFLICT ("id") DO UPDATE SET "name" = EXCLUDED."name"
+//
+// Postgres and SQLite use ON CONFLICT, which requires at least one conflict
+// column, and fall back to DO NOTHING when no column would be updated. MySQL
+// uses ON DUPLICATE KEY UPDATE and its own unique keys; conflict columns are
+// only excluded from the update. SQL Server is not supported.
+func Upsert(d nullable.Dialect, table string, row any, conflict ...string) (string, []any, error) {
+	cols, err := columns(row)
+	if err != nil {
+		return "", nil, err
+	}
+	for _, c := range conflict {
+		if !slices.ContainsFunc(cols, func(col column) bool { return col.name == c }) {
+			return "", nil, fmt.Errorf("nullsql: conflict column %q not found", c)
+		}
+	}
+
+	var update []string
+	for _, c := range cols {
+		if !c.valid || slices.Contains(conflict, c.name) {
+			continue
+		}
+		col := quoteIdent(d, c.name)
+		switch d {
+		case nullable.MySQL:
+			update = append(update, col+" = VALUES("+col+")")
+		default:
+			update = append(update, col+" = EXCLUDED."+col)
+		}
+	}
+
+	var b strings.Builder
+	args := make([]any, 0, len(cols))
+	b.WriteString("INSERT INTO " + table + " (")
+	for i, c := range cols {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(quoteIdent(d, c.name))
+		args = append(args, c.arg)
+	}
+	b.WriteString(") VALUES (")
+	for i := range cols {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(placeholder(d, i+1))
+	}
+	b.WriteString(")")
+
+	switch d {
+	case nullable.Postgres, nullable.SQLite:
+		if len(conflict) == 0 {
+			return "", nil, fmt.Errorf("nullsql: %s upsert requires conflict columns", d)
+		}
+		quoted := make([]string, len(conflict))
+		for i, c := range conflict {
+			quoted[i] = quoteIdent(d, c)
+		}
+		b.WriteString(" ON CONFLICT (" + strings.Join(quoted, ", ") + ")")
+		if len(update) == 0 {
+			b.WriteString(" DO NOTHING")
+		} else {
+			b.WriteString(" DO UPDATE SET " + strings.Join(update, ", "))
+		}
+	case nullable.MySQL:
+		if len(update) == 0 {
+			// Assigning a column to itself keeps the row unchanged.
+			col := quoteIdent(d, cols[0].name)
+			update = append(update, col+" = "+col)
+		}
+		b.WriteString(" ON DUPLICATE KEY UPDATE " + strings.Join(update, ", "))
+	default:
+		return "", nil, fmt.Errorf("nullsql: upsert is not supported for %s", d)
+	}
+	return b.String(), args, nil
+}
@@ -0,0 +1,70 @@
+package nullsql
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/manattan/nullable"
+)
+
+type account struct {
+	ID    int64                     `db:"id"`
+	Name  nullable.Nullable[string] `db:"name"`
+	Email nullable.Nullable[string] `db:"email"`
+}
+
+func TestUpsert(t *testing.T) {
+	a := account{ID: 7, Name: nullable.NewNullable("Alice")}
+	tests := []struct {
+		dialect nullable.Dialect
+		want    string
+	}{
+		{nullable.Postgres, `INSERT INTO accounts ("id", "name", "email") VALUES ($1, $2, $3) ON CONFLICT ("id") DO UPDATE SET "name" = EXCLUDED."name"`},
+		{nullable.SQLite, `INSERT INTO accounts ("id", "name", "email") VALUES (?, ?, ?) ON CONFLICT ("id") DO UPDATE SET "name" = EXCLUDED."name"`},
+		{nullable.MySQL, "INSERT INTO accounts (`id`, `name`, `email`) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE `name` = VALUES(`name`)"},
+	}
+	for _, tt := range tests {
+		query, args, err := Upsert(tt.dialect, "accounts", a, "id")
+		if err != nil {
+			t.Fatalf("%s: %v", tt.dialect, err)
+		}
+		if query != tt.want {
+			t.Errorf("%s: Expected %s, got %s", tt.dialect, tt.want, query)
+		}
+		if want := []any{int64(7), "Alice", nil}; !reflect.DeepEqual(args, want) {
+			t.Errorf("%s: Expected args %v, got %v", tt.dialect, want, args)
+		}
+	}
+}
+
+func TestUpsertNothingToUpdate(t *testing.T) {
+	a := account{ID: 7}
+	query, _, err := Upsert(nullable.Postgres, "accounts", a, "id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `INSERT INTO accounts ("id", "name", "email") VALUES ($1, $2, $3) ON CONFLICT ("id") DO NOTHING`; query != want {
+		t.Errorf("Expected %s, got %s", want, query)
+	}
+
+	query, _, err = Upsert(nullable.MySQL, "accounts", a, "id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "INSERT INTO accounts (`id`, `name`, `email`) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE `id` = `id`"; query != want {
+		t.Errorf("Expected %s, got %s", want, query)
+	}
+}
+
+func TestUpsertErrors(t *testing.T) {
+	a := account{ID: 7}
+	if _, _, err := Upsert(nullable.Postgres, "accounts", a); err == nil {
+		t.Error("Expected an error without conflict columns")
+	}
+	if _, _, err := Upsert(nullable.Postgres, "accounts", a, "uuid"); err == nil {
+		t.Error("Expected an error for an unknown conflict column")
+	}
+	if _, _, err := Upsert(nullable.SQLServer, "accounts", a, "id"); err == nil {
+		t.Error("Expected an error for SQL Server")
+	}
+}
@@ -0,0 +1,52 @@
+package nullsql
+
+import (
+	"strings"
+
+	"github.com/manattan/nullable"
+)
+
+// IsNull is a filter field that matches rows where its column is NULL when
+// true. Where skips it when false. It can share a column with a Nullable
+// field, so a filter can match either a value or NULL:
+//
+//	type UserFilter struct {
+//		Email       nullable.Nullable[string] `db:"email"`
+//		EmailIsNull nullsql.IsNull            `db:"email"`
+//	}
+type IsNull bool
+
+// Where returns a WHERE clause matching the fields of filter, together with
+// its arguments. Valid fields emit "col = ?" conditions joined by AND, null
+// fields are skipped, and true IsNull fields emit "col IS NULL":
+//
+//	where, args, err := nullsql.Where(nullable.Postgres, f)
+//	rows, err := db.Query("SELECT * FROM users "+where, args...)
+//
+// The clause is empty when no field applies, so the query matches every row.
+func Where(d nullable.Dialect, filter any) (string, []any, error) {
+	cols, err := columns(filter)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var conds []string
+	var args []any
+	for _, c := range cols {
+		if m, ok := c.arg.(IsNull); ok {
+			if m {
+				conds = append(conds, quoteIdent(d, c.name)+" IS NULL")
+			}
+			continue
+		}
+		if !c.valid {
+			continue
+		}
+		args = append(args, c.arg)
+		conds = append(conds, quoteIdent(d, c.name)+" = "+placeholder(d, len(args)))
+	}
+	if len(conds) == 0 {
+		return "", nil, nil
+	}
+	return "WHERE " + strings.Join(conds, " AND "), args, nil
+}
@@ -0,0 +1,72 @@
+package nullsql
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/manattan/nullable"
+)
+
+type userFilter struct {
+	Name        nullable.Nullable[string] `db:"name"`
+	Email       nullable.Nullable[string] `db:"email"`
+	EmailIsNull IsNull                    `db:"email"`
+	Age         nullable.Nullable[int]    `db:"age"`
+}
+
+func TestWhere(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect nullable.Dialect
+		filter  userFilter
+		want    string
+		args    []any
+	}{
+		{
+			name:    "values",
+			dialect: nullable.Postgres,
+			filter:  userFilter{Name: nullable.NewNullable("Alice"), Age: nullable.NewNullable(30)},
+			want:    `WHERE "name" = $1 AND "age" = $2`,
+			args:    []any{"Alice", 30},
+		},
+		{
+			name:    "is null",
+			dialect: nullable.MySQL,
+			filter:  userFilter{EmailIsNull: true, Age: nullable.NewNullable(30)},
+			want:    "WHERE `email` IS NULL AND `age` = ?",
+			args:    []any{30},
+		},
+		{
+			name:    "sql server",
+			dialect: nullable.SQLServer,
+			filter:  userFilter{Name: nullable.NewNullable("Alice")},
+			want:    "WHERE [name] = @p1",
+			args:    []any{"Alice"},
+		},
+		{
+			name:    "empty",
+			dialect: nullable.Postgres,
+			want:    "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			where, args, err := Where(tt.dialect, tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if where != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, where)
+			}
+			if !reflect.DeepEqual(args, tt.args) {
+				t.Errorf("Expected args %v, got %v", tt.args, args)
+			}
+		})
+	}
+}
+
+func TestWhereError(t *testing.T) {
+	if _, _, err := Where(nullable.Postgres, "name"); err == nil {
+		t.Error("Expected an error for a non-struct filter")
+	}
+}
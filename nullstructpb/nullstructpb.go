@@ -0,0 +1,92 @@
+// Package nullstructpb converts between nullable domain data and the
+// dynamic google.protobuf.Struct and google.protobuf.Value messages used by
+// dynamic gRPC APIs and document stores such as Firestore.
+//
+// Values are converted through their nullable JSON encoding, so a null
+// Nullable becomes NullValue, struct tags and encode options apply as in
+// nullable.Marshal, and decoding accepts the same forms as
+// nullable.Unmarshal:
+//
+//	s, err := nullstructpb.ToStruct(user) // {"email": null, "age": 36, ...}
+//	age, err := nullstructpb.Get[int](s, "age")
+//
+// Like JSON, google.protobuf.Value stores every number as a double, so
+// integers beyond 2^53 lose precision.
+package nullstructpb
+
+import (
+	"fmt"
+
+	"github.com/manattan/nullable"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// ToValue converts v, which may be a Nullable, a struct with Nullable
+// fields or any other value nullable.Marshal accepts, to a Value. Null
+// Nullables and nil become NullValue.
+func ToValue(v any, opts ...nullable.EncodeOption) (*structpb.Value, error) {
+	data, err := nullable.Marshal(v, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("nullstructpb: %w", err)
+	}
+	var pv structpb.Value
+	if err := protojson.Unmarshal(data, &pv); err != nil {
+		return nil, fmt.Errorf("nullstructpb: %w", err)
+	}
+	return &pv, nil
+}
+
+// ToStruct converts v, which must encode as a JSON object such as a struct
+// or a string-keyed map, to a Struct.
+func ToStruct(v any, opts ...nullable.EncodeOption) (*structpb.Struct, error) {
+	pv, err := ToValue(v, opts...)
+	if err != nil {
+		return nil, err
+	}
+	s := pv.GetStructValue()
+	if s == nil {
+		return nil, fmt.Errorf("nullstructpb: %T does not encode as an object", v)
+	}
+	return s, nil
+}
+
+// FromValue decodes pv into dst, which must be a non-nil pointer. A nil pv
+// decodes like NullValue, making Nullable destinations null.
+func FromValue(pv *structpb.Value, dst any, opts ...nullable.DecodeOption) error {
+	if err := decode(pv, dst, opts); err != nil {
+		return fmt.Errorf("nullstructpb: %w", err)
+	}
+	return nil
+}
+
+// FromStruct decodes s into dst, which must be a non-nil pointer to a
+// struct or map. Fields missing from s are left unchanged, as with
+// nullable.Unmarshal.
+func FromStruct(s *structpb.Struct, dst any, opts ...nullable.DecodeOption) error {
+	if s == nil {
+		s = &structpb.Struct{}
+	}
+	return FromValue(structpb.NewStructValue(s), dst, opts...)
+}
+
+// Get decodes the field key of s as a Nullable[T]. A missing field or
+// NullValue yields null.
+func Get[T any](s *structpb.Struct, key string, opts ...nullable.DecodeOption) (nullable.Nullable[T], error) {
+	var n nullable.Nullable[T]
+	if err := decode(s.GetFields()[key], &n, opts); err != nil {
+		return nullable.NewNull[T](), fmt.Errorf("nullstructpb: field %q: %w", key, err)
+	}
+	return n, nil
+}
+
+func decode(pv *structpb.Value, dst any, opts []nullable.DecodeOption) error {
+	data := []byte("null")
+	if pv != nil {
+		var err error
+		if data, err = protojson.Marshal(pv); err != nil {
+			return err
+		}
+	}
+	return nullable.Unmarshal(data, dst, opts...)
+}
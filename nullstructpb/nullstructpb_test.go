@@ -0,0 +1,118 @@
+package nullstructpb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/manattan/nullable"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+type profile struct {
+	Name  string                       `json:"name"`
+	Email nullable.Nullable[string]    `json:"email"`
+	Age   nullable.Nullable[int]       `json:"age"`
+	Born  nullable.Nullable[time.Time] `json:"born"`
+	Tags  []string                     `json:"tags"`
+}
+
+func TestToStruct(t *testing.T) {
+	p := profile{
+		Name: "Ada",
+		Age:  nullable.NewNullable(36),
+		Born: nullable.NewNullable(time.Date(1815, 12, 10, 0, 0, 0, 0, time.UTC)),
+		Tags: []string{"math"},
+	}
+	s, err := ToStruct(p)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want, _ := structpb.NewStruct(map[string]any{
+		"name":  "Ada",
+		"email": nil,
+		"age":   36,
+		"born":  "1815-12-10T00:00:00Z",
+		"tags":  []any{"math"},
+	})
+	if !proto.Equal(s, want) {
+		t.Errorf("Expected %v, got %v", want, s)
+	}
+
+	if _, err := ToStruct(nullable.NewNullable(1)); err == nil {
+		t.Error("Expected error for a non-object value")
+	}
+}
+
+func TestToValue(t *testing.T) {
+	v, err := ToValue(nullable.NewNull[string]())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, ok := v.Kind.(*structpb.Value_NullValue); !ok {
+		t.Errorf("Expected NullValue, got %v", v)
+	}
+	if v, err := ToValue(nil); err != nil || v.GetKind() == nil {
+		t.Errorf("Expected NullValue for nil, got %v (%v)", v, err)
+	} else if _, ok := v.Kind.(*structpb.Value_NullValue); !ok {
+		t.Errorf("Expected NullValue for nil, got %v", v)
+	}
+	if v, err := ToValue(nullable.NewNullable("x")); err != nil || v.GetStringValue() != "x" {
+		t.Errorf("Expected string value, got %v (%v)", v, err)
+	}
+}
+
+func TestFromStruct(t *testing.T) {
+	s, _ := structpb.NewStruct(map[string]any{
+		"name":  "Ada",
+		"email": nil,
+		"age":   36,
+		"born":  "1815-12-10T00:00:00Z",
+	})
+	p := profile{Email: nullable.NewNullable("old@example.com")}
+	if err := FromStruct(s, &p); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if p.Name != "Ada" || p.Email.Valid || p.Age.V != 36 || p.Born.V.Year() != 1815 {
+		t.Errorf("Unexpected profile %+v", p)
+	}
+
+	if err := FromStruct(s, p); err == nil {
+		t.Error("Expected error for non-pointer destination")
+	}
+	if err := FromStruct(nil, &p); err != nil || p.Name != "Ada" {
+		t.Errorf("Expected nil struct to leave fields unchanged, got %+v (%v)", p, err)
+	}
+}
+
+func TestFromValue(t *testing.T) {
+	n := nullable.NewNullable(5)
+	if err := FromValue(nil, &n); err != nil || n.Valid {
+		t.Errorf("Expected null from nil value, got %+v (%v)", n, err)
+	}
+	if err := FromValue(structpb.NewNumberValue(7), &n); err != nil || n.V != 7 {
+		t.Errorf("Expected 7, got %+v (%v)", n, err)
+	}
+	if err := FromValue(structpb.NewNullValue(), &n, nullable.NullAsZero()); err != nil || !n.Valid || n.V != 0 {
+		t.Errorf("Expected valid zero with NullAsZero, got %+v (%v)", n, err)
+	}
+}
+
+func TestGet(t *testing.T) {
+	s, _ := structpb.NewStruct(map[string]any{"age": 36, "email": nil, "name": "Ada"})
+	if age, err := Get[int](s, "age"); err != nil || age != nullable.NewNullable(36) {
+		t.Errorf("Expected 36, got %v (%v)", age, err)
+	}
+	if email, err := Get[string](s, "email"); err != nil || email.Valid {
+		t.Errorf("Expected null email, got %v (%v)", email, err)
+	}
+	if missing, err := Get[string](s, "missing"); err != nil || missing.Valid {
+		t.Errorf("Expected null for missing field, got %v (%v)", missing, err)
+	}
+	if _, err := Get[int](s, "name"); err == nil {
+		t.Error("Expected error decoding a string as int")
+	}
+	if v, err := Get[int](nil, "age"); err != nil || v.Valid {
+		t.Errorf("Expected null from nil struct, got %v (%v)", v, err)
+	}
+}
@@ -0,0 +1,193 @@
+// Package nulltable renders slices of structs with nullable.Nullable fields
+// as aligned text tables for command-line tools, printing a placeholder for
+// null cells.
+//
+// Write prints a table with text/tabwriter. Rows returns the header and
+// cells instead, in the form olekukonko/tablewriter accepts:
+//
+//	header, cells, err := nulltable.Rows(users, nulltable.Placeholder("-"))
+//	table := tablewriter.NewWriter(os.Stdout)
+//	table.Header(header)
+//	table.Bulk(cells)
+//	table.Render()
+//
+// Columns are named by the table struct tag, or the field name when there
+// is none; a tag of "-" skips the field, and embedded structs without a tag
+// contribute their columns.
+package nulltable
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/manattan/nullable/internal/nullreflect"
+)
+
+// DefaultPlaceholder is the text printed for null cells.
+const DefaultPlaceholder = "NULL"
+
+// Option configures Rows and Write.
+type Option func(*options)
+
+type options struct {
+	placeholder string
+	timeLayout  string
+}
+
+// Placeholder sets the text printed for null Nullables and nil pointers.
+func Placeholder(s string) Option {
+	return func(o *options) {
+		o.placeholder = s
+	}
+}
+
+// TimeLayout sets the layout for time.Time cells, time.RFC3339 by default.
+func TimeLayout(layout string) Option {
+	return func(o *options) {
+		o.timeLayout = layout
+	}
+}
+
+// Rows returns the header and cells for rows, a slice or array of structs
+// or pointers to structs. Nil pointer rows are skipped.
+func Rows(rows any, opts ...Option) ([]string, [][]string, error) {
+	o := options{placeholder: DefaultPlaceholder, timeLayout: time.RFC3339}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	rv := reflect.ValueOf(rows)
+	if k := rv.Kind(); k != reflect.Slice && k != reflect.Array {
+		return nil, nil, fmt.Errorf("nulltable: expected a slice of structs, got %T", rows)
+	}
+	et := rv.Type().Elem()
+	for et.Kind() == reflect.Pointer {
+		et = et.Elem()
+	}
+	if et.Kind() != reflect.Struct {
+		return nil, nil, fmt.Errorf("nulltable: expected a slice of structs, got %T", rows)
+	}
+
+	cols := columns(et, nil)
+	header := make([]string, len(cols))
+	for i, c := range cols {
+		header[i] = c.name
+	}
+	cells := make([][]string, 0, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		row := rv.Index(i)
+		for row.Kind() == reflect.Pointer && !row.IsNil() {
+			row = row.Elem()
+		}
+		if row.Kind() == reflect.Pointer {
+			continue
+		}
+		line := make([]string, len(cols))
+		for j, c := range cols {
+			line[j] = o.cell(row, c.index)
+		}
+		cells = append(cells, line)
+	}
+	return header, cells, nil
+}
+
+// Write prints rows to w as a table with a header line, aligning columns
+// with text/tabwriter.
+func Write(w io.Writer, rows any, opts ...Option) error {
+	header, cells, err := Rows(rows, opts...)
+	if err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	for _, line := range append([][]string{header}, cells...) {
+		for j, cell := range line {
+			line[j] = cellEscaper.Replace(cell)
+		}
+		if _, err := fmt.Fprintln(tw, strings.Join(line, "\t")); err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
+
+// cellEscaper replaces the tabs and newlines that would break tabwriter's
+// alignment.
+var cellEscaper = strings.NewReplacer("\t", " ", "\n", " ")
+
+type column struct {
+	name  string
+	index []int
+}
+
+func columns(t reflect.Type, index []int) []column {
+	var cols []column
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, hasTag := sf.Tag.Lookup("table")
+		if tag == "-" {
+			continue
+		}
+		idx := append(append([]int(nil), index...), i)
+		ft := sf.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if sf.Anonymous && !hasTag && ft.Kind() == reflect.Struct && !nullreflect.IsNullable(ft) && !nullreflect.IsWrapper(ft) {
+			cols = append(cols, columns(ft, idx)...)
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		name := tag
+		if name == "" {
+			name = sf.Name
+		}
+		cols = append(cols, column{name: name, index: idx})
+	}
+	return cols
+}
+
+var timeType = reflect.TypeFor[time.Time]()
+
+func (o *options) cell(row reflect.Value, index []int) string {
+	v := row
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return o.placeholder
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return o.format(v)
+}
+
+func (o *options) format(v reflect.Value) string {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return o.placeholder
+		}
+		v = v.Elem()
+	}
+	t := v.Type()
+	switch {
+	case nullreflect.IsNullable(t):
+		if !nullreflect.Valid(v) {
+			return o.placeholder
+		}
+		return o.format(nullreflect.Inner(v))
+	case nullreflect.IsWrapper(t):
+		if !nullreflect.Valid(v.Field(0)) {
+			return o.placeholder
+		}
+	case t == timeType:
+		return v.Interface().(time.Time).Format(o.timeLayout)
+	}
+	return fmt.Sprint(v.Interface())
+}
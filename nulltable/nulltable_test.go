@@ -0,0 +1,89 @@
+package nulltable
+
+import (
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/manattan/nullable"
+)
+
+type audit struct {
+	CreatedBy nullable.Nullable[string] `table:"CREATED BY"`
+}
+
+type user struct {
+	ID      int
+	Name    nullable.Nullable[string]
+	Joined  nullable.Nullable[time.Time]
+	MAC     nullable.HardwareAddr
+	Manager *string
+	Secret  string `table:"-"`
+	*audit
+}
+
+func users() []*user {
+	mac, _ := net.ParseMAC("00:1a:2b:3c:4d:5e")
+	boss := "Grace"
+	return []*user{
+		{ID: 1, Name: nullable.NewNullable("Ada"), Joined: nullable.NewNullable(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)), MAC: nullable.NewHardwareAddr(mac), Manager: &boss, audit: &audit{nullable.NewNullable("root")}},
+		nil,
+		{ID: 2, Secret: "x"},
+	}
+}
+
+func TestRows(t *testing.T) {
+	header, cells, err := Rows(users())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := []string{"ID", "Name", "Joined", "MAC", "Manager", "CREATED BY"}; !reflect.DeepEqual(header, want) {
+		t.Errorf("Expected header %v, got %v", want, header)
+	}
+	want := [][]string{
+		{"1", "Ada", "2024-01-02T03:04:05Z", "00:1a:2b:3c:4d:5e", "Grace", "root"},
+		{"2", "NULL", "NULL", "NULL", "NULL", "NULL"},
+	}
+	if !reflect.DeepEqual(cells, want) {
+		t.Errorf("Expected cells %v, got %v", want, cells)
+	}
+
+	_, cells, err = Rows(users(), Placeholder("-"), TimeLayout(time.DateOnly))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cells[0][2] != "2024-01-02" || cells[1][1] != "-" {
+		t.Errorf("Expected options to apply, got %v", cells)
+	}
+
+	if _, _, err := Rows(user{}); err == nil {
+		t.Error("Expected error for non-slice")
+	}
+	if _, _, err := Rows([]int{1}); err == nil {
+		t.Error("Expected error for slice of ints")
+	}
+}
+
+func TestWrite(t *testing.T) {
+	type row struct {
+		Name  nullable.Nullable[string]
+		Score nullable.Nullable[float64]
+	}
+	var b strings.Builder
+	err := Write(&b, []row{
+		{Name: nullable.NewNullable("Ada\tLovelace"), Score: nullable.NewNullable(9.5)},
+		{Score: nullable.NewNullable(10.0)},
+	}, Placeholder("(none)"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := "" +
+		"Name          Score\n" +
+		"Ada Lovelace  9.5\n" +
+		"(none)        10\n"
+	if b.String() != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, b.String())
+	}
+}
@@ -0,0 +1,103 @@
+// Package nulltemporal provides a Temporal payload converter that encodes
+// workflow and activity arguments with nullable.Marshal and
+// nullable.Unmarshal, so domain structs with Nullable fields keep their
+// nullable struct tags and decode options across workflow boundaries.
+//
+// Payloads use the standard "json/plain" encoding and stay readable by
+// other SDKs and the Temporal UI. A null Nullable is sent as JSON null;
+// arguments and struct fields missing from a payload decode as null, and
+// NullAsZero turns JSON null into a valid zero value instead.
+//
+// Install it on the client, which workers created from it inherit:
+//
+//	c, err := client.Dial(client.Options{
+//		DataConverter: nulltemporal.NewDataConverter(),
+//	})
+package nulltemporal
+
+import (
+	"fmt"
+
+	"github.com/manattan/nullable"
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/sdk/converter"
+)
+
+// Option configures a PayloadConverter.
+type Option func(*PayloadConverter)
+
+// EncodeOptions sets the options passed to nullable.Marshal.
+func EncodeOptions(opts ...nullable.EncodeOption) Option {
+	return func(c *PayloadConverter) {
+		c.encode = opts
+	}
+}
+
+// DecodeOptions sets the options passed to nullable.Unmarshal.
+func DecodeOptions(opts ...nullable.DecodeOption) Option {
+	return func(c *PayloadConverter) {
+		c.decode = opts
+	}
+}
+
+// PayloadConverter is a converter.PayloadConverter for the "json/plain"
+// encoding backed by nullable.Marshal and nullable.Unmarshal. It replaces
+// converter.JSONPayloadConverter.
+type PayloadConverter struct {
+	encode []nullable.EncodeOption
+	decode []nullable.DecodeOption
+}
+
+var _ converter.PayloadConverter = (*PayloadConverter)(nil)
+
+// NewPayloadConverter returns a PayloadConverter configured by opts.
+func NewPayloadConverter(opts ...Option) *PayloadConverter {
+	c := &PayloadConverter{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// ToPayload encodes value as JSON.
+func (c *PayloadConverter) ToPayload(value any) (*commonpb.Payload, error) {
+	data, err := nullable.Marshal(value, c.encode...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", converter.ErrUnableToEncode, err)
+	}
+	return &commonpb.Payload{
+		Metadata: map[string][]byte{converter.MetadataEncoding: []byte(c.Encoding())},
+		Data:     data,
+	}, nil
+}
+
+// FromPayload decodes the JSON in payload into valuePtr.
+func (c *PayloadConverter) FromPayload(payload *commonpb.Payload, valuePtr any) error {
+	if err := nullable.Unmarshal(payload.GetData(), valuePtr, c.decode...); err != nil {
+		return fmt.Errorf("%w: %v", converter.ErrUnableToDecode, err)
+	}
+	return nil
+}
+
+// ToString returns the JSON text of payload.
+func (c *PayloadConverter) ToString(payload *commonpb.Payload) string {
+	return string(payload.GetData())
+}
+
+// Encoding returns "json/plain".
+func (c *PayloadConverter) Encoding() string {
+	return converter.MetadataEncodingJSON
+}
+
+// NewDataConverter returns the Temporal default data converter with its
+// JSON payload converter replaced by a PayloadConverter configured by opts.
+// Nil values, byte slices and protobuf messages are handled as before.
+func NewDataConverter(opts ...Option) converter.DataConverter {
+	return converter.NewCompositeDataConverter(
+		converter.NewNilPayloadConverter(),
+		converter.NewByteSlicePayloadConverter(),
+		converter.NewProtoJSONPayloadConverter(),
+		converter.NewProtoPayloadConverter(),
+		NewPayloadConverter(opts...),
+	)
+}
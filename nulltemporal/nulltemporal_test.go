@@ -0,0 +1,102 @@
+package nulltemporal
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/manattan/nullable"
+	"go.temporal.io/sdk/converter"
+)
+
+type order struct {
+	ID       string                       `json:"id"`
+	Note     nullable.Nullable[string]    `json:"note"`
+	Shipped  nullable.Nullable[time.Time] `json:"shipped" nullable:"format=date"`
+	Quantity nullable.Nullable[int]       `json:"quantity"`
+}
+
+func TestPayloadConverter(t *testing.T) {
+	c := NewPayloadConverter()
+	in := order{
+		ID:      "o1",
+		Shipped: nullable.NewNullable(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)),
+	}
+	p, err := c.ToPayload(in)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if enc := string(p.GetMetadata()[converter.MetadataEncoding]); enc != "json/plain" {
+		t.Errorf("Expected json/plain, got %s", enc)
+	}
+	if want := `{"id":"o1","note":null,"shipped":"2024-06-01","quantity":null}`; c.ToString(p) != want {
+		t.Errorf("Expected %s, got %s", want, c.ToString(p))
+	}
+
+	var out order
+	if err := c.FromPayload(p, &out); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if out.ID != "o1" || out.Note.Valid || !out.Shipped.V.Equal(in.Shipped.V) {
+		t.Errorf("Expected %+v, got %+v", in, out)
+	}
+
+	p.Data = []byte(`{"id":"o2","quantity":"x"}`)
+	if err := c.FromPayload(p, &out); !errors.Is(err, converter.ErrUnableToDecode) {
+		t.Errorf("Expected ErrUnableToDecode, got %v", err)
+	}
+	if _, err := c.ToPayload(make(chan int)); !errors.Is(err, converter.ErrUnableToEncode) {
+		t.Errorf("Expected ErrUnableToEncode, got %v", err)
+	}
+}
+
+func TestPayloadConverterOptions(t *testing.T) {
+	c := NewPayloadConverter(
+		EncodeOptions(nullable.ZeroTimeAsNull()),
+		DecodeOptions(nullable.CoerceNumericStrings()),
+	)
+	p, err := c.ToPayload(struct {
+		At time.Time `json:"at"`
+	}{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := `{"at":null}`; c.ToString(p) != want {
+		t.Errorf("Expected %s, got %s", want, c.ToString(p))
+	}
+
+	p.Data = []byte(`{"id":"o3","quantity":"4"}`)
+	var out order
+	if err := c.FromPayload(p, &out); err != nil || out.Quantity.V != 4 {
+		t.Errorf("Expected quantity 4, got %+v (%v)", out.Quantity, err)
+	}
+}
+
+func TestDataConverter(t *testing.T) {
+	dc := NewDataConverter()
+	note := nullable.NewNullable("fragile")
+	payloads, err := dc.ToPayloads(order{ID: "o1", Note: note}, nullable.NewNull[int](), []byte("raw"), nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var (
+		o     order
+		n     = nullable.NewNullable(5)
+		raw   []byte
+		empty *order
+		extra nullable.Nullable[string]
+	)
+	if err := dc.FromPayloads(payloads, &o, &n, &raw, &empty, &extra); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if o.Note != note || n.Valid || string(raw) != "raw" || empty != nil {
+		t.Errorf("Unexpected round trip: %+v %v %q %v", o, n, raw, empty)
+	}
+	if extra.Valid {
+		t.Errorf("Expected missing argument to be null, got %v", extra)
+	}
+	if enc := string(payloads.GetPayloads()[1].GetMetadata()[converter.MetadataEncoding]); enc != "json/plain" {
+		t.Errorf("Expected null Nullable to use json/plain, got %s", enc)
+	}
+}
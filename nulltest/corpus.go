@@ -0,0 +1,174 @@
+package nulltest
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/manattan/nullable/internal/nullreflect"
+)
+
+// Corpus returns JSON documents for the struct type of v, for seeding fuzz
+// tests of code decoding it. The corpus contains a document with a valid
+// sample value for every field, one with every field null, and for each
+// field variants where it is null, omitted, or holds a value of the wrong
+// JSON type, plus truncated and non-object documents. Field values of v
+// itself are ignored; only its type is used. Corpus panics if v is not a
+// struct or pointer to struct.
+func Corpus(v any) [][]byte {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("nulltest: Corpus requires a struct, got %T", v))
+	}
+
+	fields := jsonFields(t)
+	valid := make(map[string]any, len(fields))
+	nulls := make(map[string]any, len(fields))
+	for _, f := range fields {
+		valid[f.name] = sample(f.typ, 0)
+		nulls[f.name] = nil
+	}
+
+	var docs [][]byte
+	seen := make(map[string]bool)
+	add := func(doc []byte) {
+		if !seen[string(doc)] {
+			seen[string(doc)] = true
+			docs = append(docs, doc)
+		}
+	}
+	addObject := func(obj map[string]any) {
+		data, err := json.Marshal(obj)
+		if err != nil {
+			panic(err)
+		}
+		add(data)
+	}
+
+	addObject(valid)
+	addObject(nulls)
+	add([]byte("{}"))
+	for _, f := range fields {
+		for _, variant := range []any{nil, wrongType(f.typ), omitted{}} {
+			obj := make(map[string]any, len(valid))
+			for k, v := range valid {
+				obj[k] = v
+			}
+			if _, ok := variant.(omitted); ok {
+				delete(obj, f.name)
+			} else {
+				obj[f.name] = variant
+			}
+			addObject(obj)
+		}
+	}
+
+	base := docs[0]
+	for _, n := range []int{1, len(base) / 2, len(base) - 1} {
+		if n > 0 && n < len(base) {
+			add(append([]byte(nil), base[:n]...))
+		}
+	}
+	for _, doc := range []string{"null", "[]", `""`, "0", `{"":null}`} {
+		add([]byte(doc))
+	}
+	return docs
+}
+
+// SeedCorpus adds every document of Corpus(v) to f.
+func SeedCorpus(f *testing.F, v any) {
+	for _, doc := range Corpus(v) {
+		f.Add(doc)
+	}
+}
+
+type omitted struct{}
+
+type jsonField struct {
+	name string
+	typ  reflect.Type
+}
+
+func jsonFields(t reflect.Type) []jsonField {
+	var fields []jsonField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if name, ok := nullreflect.JSONName(sf); ok {
+			fields = append(fields, jsonField{name: name, typ: sf.Type})
+		}
+	}
+	return fields
+}
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// maxDepth bounds the recursion of sample for self-referential types.
+const maxDepth = 4
+
+// sample returns a valid JSON-encodable value for t.
+func sample(t reflect.Type, depth int) any {
+	if depth > maxDepth {
+		return nil
+	}
+	if nullreflect.IsNullable(t) {
+		return sample(nullreflect.Inner(reflect.New(t).Elem()).Type(), depth)
+	}
+	if t == timeType {
+		return "2006-01-02T15:04:05Z"
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "a"
+	case reflect.Bool:
+		return true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return 1
+	case reflect.Float32, reflect.Float64:
+		return 1.5
+	case reflect.Pointer:
+		return sample(t.Elem(), depth+1)
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return []byte{1}
+		}
+		return []any{sample(t.Elem(), depth+1)}
+	case reflect.Array:
+		elems := make([]any, t.Len())
+		for i := range elems {
+			elems[i] = sample(t.Elem(), depth+1)
+		}
+		return elems
+	case reflect.Map:
+		return map[string]any{"k": sample(t.Elem(), depth+1)}
+	case reflect.Struct:
+		obj := make(map[string]any)
+		for _, f := range jsonFields(t) {
+			obj[f.name] = sample(f.typ, depth+1)
+		}
+		return obj
+	}
+	return "a"
+}
+
+// wrongType returns a JSON value of a different type than sample(t).
+func wrongType(t reflect.Type) any {
+	switch sample(t, 0).(type) {
+	case string, []byte:
+		return 1
+	case int, float64:
+		return "1"
+	case bool:
+		return 0
+	case []any:
+		return map[string]any{}
+	case map[string]any:
+		return []any{}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package nulltest
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/manattan/nullable"
+)
+
+type corpusUser struct {
+	Name    nullable.Nullable[string]    `json:"name"`
+	Age     nullable.Nullable[int]       `json:"age"`
+	Born    nullable.Nullable[time.Time] `json:"born"`
+	Tags    []string                     `json:"tags"`
+	Address *struct {
+		City nullable.Nullable[string] `json:"city"`
+	} `json:"address"`
+	Ignored string `json:"-"`
+}
+
+func TestCorpus(t *testing.T) {
+	docs := Corpus(corpusUser{})
+
+	var valid corpusUser
+	if err := json.Unmarshal(docs[0], &valid); err != nil {
+		t.Fatalf("Expected first document to decode, got %v: %s", err, docs[0])
+	}
+	if !valid.Name.Valid || !valid.Age.Valid || !valid.Born.Valid || valid.Address == nil || !valid.Address.City.Valid {
+		t.Errorf("Expected all fields valid, got %+v", valid)
+	}
+
+	var nulls corpusUser
+	if err := json.Unmarshal(docs[1], &nulls); err != nil {
+		t.Fatalf("Expected second document to decode, got %v", err)
+	}
+	if nulls.Name.Valid || nulls.Age.Valid || nulls.Address != nil {
+		t.Errorf("Expected all fields null, got %+v", nulls)
+	}
+
+	var decodeErrors, syntaxErrors int
+	seen := make(map[string]bool)
+	for _, doc := range docs {
+		if seen[string(doc)] {
+			t.Errorf("Duplicate document %s", doc)
+		}
+		seen[string(doc)] = true
+		if strings.Contains(string(doc), "Ignored") {
+			t.Errorf("Expected ignored field to be skipped: %s", doc)
+		}
+
+		var u corpusUser
+		if !json.Valid(doc) {
+			syntaxErrors++
+		} else if err := json.Unmarshal(doc, &u); err != nil {
+			decodeErrors++
+		}
+	}
+	if syntaxErrors < 3 {
+		t.Errorf("Expected truncated documents, got %d", syntaxErrors)
+	}
+	if decodeErrors < 5 {
+		t.Errorf("Expected wrong-type documents for every field, got %d", decodeErrors)
+	}
+}
+
+func TestCorpusDeterministic(t *testing.T) {
+	a, b := Corpus(&corpusUser{}), Corpus(corpusUser{})
+	if len(a) != len(b) {
+		t.Fatalf("Expected equal corpus sizes, got %d and %d", len(a), len(b))
+	}
+	for i := range a {
+		if string(a[i]) != string(b[i]) {
+			t.Errorf("Document %d differs: %s vs %s", i, a[i], b[i])
+		}
+	}
+}
+
+func FuzzCorpusUser(f *testing.F) {
+	SeedCorpus(f, corpusUser{})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var u corpusUser
+		if err := json.Unmarshal(data, &u); err != nil {
+			return
+		}
+		if _, err := json.Marshal(u); err != nil {
+			t.Errorf("Marshal after successful Unmarshal failed: %v", err)
+		}
+	})
+}
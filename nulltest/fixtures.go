@@ -0,0 +1,67 @@
+// Package nulltest provides helpers for testing code that uses
+// nullable.Nullable values.
+package nulltest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/manattan/nullable/internal/nullreflect"
+)
+
+// maxFields bounds the number of Nullable fields Combinations enumerates.
+const maxFields = 16
+
+// Fixture is a named variant of a struct value produced by Combinations.
+type Fixture[T any] struct {
+	Name  string
+	Value T
+}
+
+// Combinations returns one fixture for every valid/null combination of the
+// Nullable fields in base, including fields of nested structs. Fields that are
+// valid in base keep their value in the variants where they are valid; fields
+// that are null in base are only ever null.
+//
+// Fixture names list the state of each field, e.g. "Name=valid,Age=null", so
+// they can be passed to t.Run directly. Combinations panics if base is not a
+// struct or has more than 16 Nullable fields.
+func Combinations[T any](base T) []Fixture[T] {
+	rv := reflect.ValueOf(&base).Elem()
+	if rv.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("nulltest: Combinations requires a struct, got %s", rv.Type()))
+	}
+
+	var fields []nullreflect.Field
+	for _, f := range nullreflect.Fields(rv.Type()) {
+		if nullreflect.Valid(rv.FieldByIndex(f.Index)) {
+			fields = append(fields, f)
+		}
+	}
+	if len(fields) > maxFields {
+		panic(fmt.Sprintf("nulltest: %s has %d valid Nullable fields, more than the %d Combinations supports", rv.Type(), len(fields), maxFields))
+	}
+
+	fixtures := make([]Fixture[T], 0, 1<<len(fields))
+	for mask := 0; mask < 1<<len(fields); mask++ {
+		v := reflect.New(rv.Type()).Elem()
+		v.Set(rv)
+		states := make([]string, len(fields))
+		for i, f := range fields {
+			if mask&(1<<i) != 0 {
+				fv := v.FieldByIndex(f.Index)
+				fv.Set(reflect.Zero(fv.Type()))
+				states[i] = f.Path + "=null"
+			} else {
+				states[i] = f.Path + "=valid"
+			}
+		}
+		name := strings.Join(states, ",")
+		if name == "" {
+			name = "base"
+		}
+		fixtures = append(fixtures, Fixture[T]{Name: name, Value: v.Interface().(T)})
+	}
+	return fixtures
+}
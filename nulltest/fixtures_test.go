@@ -0,0 +1,80 @@
+package nulltest
+
+import (
+	"testing"
+
+	"github.com/manattan/nullable"
+)
+
+type address struct {
+	City nullable.Nullable[string]
+}
+
+type user struct {
+	ID      int
+	Name    nullable.Nullable[string]
+	Age     nullable.Nullable[int]
+	Nick    nullable.Nullable[string]
+	Address address
+}
+
+func TestCombinations(t *testing.T) {
+	base := user{
+		ID:      1,
+		Name:    nullable.NewNullable("John"),
+		Age:     nullable.NewNullable(30),
+		Nick:    nullable.NewNull[string](),
+		Address: address{City: nullable.NewNullable("Tokyo")},
+	}
+
+	fixtures := Combinations(base)
+	if len(fixtures) != 8 {
+		t.Fatalf("Expected 8 fixtures, got %d", len(fixtures))
+	}
+
+	seen := make(map[string]bool)
+	for _, f := range fixtures {
+		if seen[f.Name] {
+			t.Errorf("Duplicate fixture name %q", f.Name)
+		}
+		seen[f.Name] = true
+		if f.Value.ID != 1 {
+			t.Errorf("%s: Expected ID to be preserved, got %d", f.Name, f.Value.ID)
+		}
+		if f.Value.Nick.Valid {
+			t.Errorf("%s: Expected Nick to stay null", f.Name)
+		}
+		if f.Value.Name.Valid && f.Value.Name.V != "John" {
+			t.Errorf("%s: Expected Name 'John', got %q", f.Name, f.Value.Name.V)
+		}
+	}
+
+	first := fixtures[0]
+	if first.Name != "Name=valid,Age=valid,Address.City=valid" {
+		t.Errorf("Unexpected first fixture name %q", first.Name)
+	}
+	last := fixtures[len(fixtures)-1]
+	if last.Value.Name.Valid || last.Value.Age.Valid || last.Value.Address.City.Valid {
+		t.Errorf("Expected all fields null in last fixture, got %+v", last.Value)
+	}
+
+	if !base.Name.Valid {
+		t.Error("Expected base to be left unchanged")
+	}
+}
+
+func TestCombinationsNoNullableFields(t *testing.T) {
+	fixtures := Combinations(struct{ X int }{X: 1})
+	if len(fixtures) != 1 || fixtures[0].Name != "base" {
+		t.Errorf("Expected a single base fixture, got %+v", fixtures)
+	}
+}
+
+func TestCombinationsPanicsOnNonStruct(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for non-struct value")
+		}
+	}()
+	Combinations(42)
+}
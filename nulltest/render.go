@@ -0,0 +1,148 @@
+package nulltest
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/manattan/nullable/internal/nullreflect"
+)
+
+// NullMarker is the text Render uses for null Nullable values.
+const NullMarker = "<null>"
+
+// Render returns a canonical, deterministic rendering of v for golden-file
+// snapshot tests. Struct fields are listed one per line sorted by name,
+// exported fields only; map entries are sorted by key; null Nullables are
+// rendered as NullMarker and valid ones as their inner value; strings are
+// quoted and times use RFC 3339 with nanoseconds.
+func Render(v any) string {
+	var b strings.Builder
+	render(&b, reflect.ValueOf(v), 0)
+	return b.String()
+}
+
+func render(b *strings.Builder, v reflect.Value, depth int) {
+	if !v.IsValid() {
+		b.WriteString("nil")
+		return
+	}
+	t := v.Type()
+	if nullreflect.IsNullable(t) {
+		if !nullreflect.Valid(v) {
+			b.WriteString(NullMarker)
+			return
+		}
+		render(b, nullreflect.Inner(v), depth)
+		return
+	}
+	if t == timeType {
+		b.WriteString(v.Interface().(time.Time).Format(time.RFC3339Nano))
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		b.WriteString(strconv.Quote(v.String()))
+	case reflect.Pointer:
+		if v.IsNil() {
+			b.WriteString("nil")
+			return
+		}
+		b.WriteByte('&')
+		render(b, v.Elem(), depth)
+	case reflect.Interface:
+		if v.IsNil() {
+			b.WriteString("nil")
+			return
+		}
+		render(b, v.Elem(), depth)
+	case reflect.Struct:
+		renderStruct(b, v, depth)
+	case reflect.Slice, reflect.Array:
+		if t.Kind() == reflect.Slice && v.IsNil() {
+			b.WriteString("nil")
+			return
+		}
+		if v.Len() == 0 {
+			b.WriteString("[]")
+			return
+		}
+		b.WriteString("[\n")
+		for i := 0; i < v.Len(); i++ {
+			indent(b, depth+1)
+			render(b, v.Index(i), depth+1)
+			b.WriteString("\n")
+		}
+		indent(b, depth)
+		b.WriteString("]")
+	case reflect.Map:
+		if v.IsNil() {
+			b.WriteString("nil")
+			return
+		}
+		if v.Len() == 0 {
+			b.WriteString("{}")
+			return
+		}
+		type entry struct{ key, value string }
+		entries := make([]entry, 0, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			var kb, vb strings.Builder
+			render(&kb, iter.Key(), depth+1)
+			render(&vb, iter.Value(), depth+1)
+			entries = append(entries, entry{kb.String(), vb.String()})
+		}
+		sort.Slice(entries, func(i, j int) bool { return entries[i].key < entries[j].key })
+		b.WriteString("{\n")
+		for _, e := range entries {
+			indent(b, depth+1)
+			b.WriteString(e.key + ": " + e.value + "\n")
+		}
+		indent(b, depth)
+		b.WriteString("}")
+	default:
+		fmt.Fprintf(b, "%v", v)
+	}
+}
+
+func renderStruct(b *strings.Builder, v reflect.Value, depth int) {
+	t := v.Type()
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			names = append(names, t.Field(i).Name)
+		}
+	}
+	sort.Strings(names)
+
+	b.WriteString(typeName(t))
+	if len(names) == 0 {
+		b.WriteString("{}")
+		return
+	}
+	b.WriteString("{\n")
+	for _, name := range names {
+		indent(b, depth+1)
+		b.WriteString(name + ": ")
+		render(b, v.FieldByName(name), depth+1)
+		b.WriteString("\n")
+	}
+	indent(b, depth)
+	b.WriteString("}")
+}
+
+func typeName(t reflect.Type) string {
+	if t.Name() == "" {
+		return "struct"
+	}
+	return t.Name()
+}
+
+func indent(b *strings.Builder, depth int) {
+	b.WriteString(strings.Repeat("  ", depth))
+}
@@ -0,0 +1,80 @@
+package nulltest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/manattan/nullable"
+)
+
+type renderAddress struct {
+	City nullable.Nullable[string]
+}
+
+type renderUser struct {
+	Name     nullable.Nullable[string]
+	Age      nullable.Nullable[int]
+	Joined   time.Time
+	Address  *renderAddress
+	Tags     []nullable.Nullable[string]
+	Scores   map[string]int
+	Empty    []int
+	internal string
+}
+
+func TestRender(t *testing.T) {
+	u := renderUser{
+		Name:     nullable.NewNullable("null"),
+		Age:      nullable.NewNull[int](),
+		Joined:   time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC),
+		Address:  &renderAddress{City: nullable.NewNullable("Tokyo")},
+		Tags:     []nullable.Nullable[string]{nullable.NewNullable("a"), nullable.NewNull[string]()},
+		Scores:   map[string]int{"z": 1, "a": 2},
+		Empty:    []int{},
+		internal: "hidden",
+	}
+
+	want := `renderUser{
+  Address: &renderAddress{
+    City: "Tokyo"
+  }
+  Age: <null>
+  Empty: []
+  Joined: 2024-01-02T03:04:05.000000006Z
+  Name: "null"
+  Scores: {
+    "a": 2
+    "z": 1
+  }
+  Tags: [
+    "a"
+    <null>
+  ]
+}`
+	if got := Render(u); got != want {
+		t.Errorf("Unexpected rendering:\n%s\nwant:\n%s", got, want)
+	}
+	if Render(u) != Render(u) {
+		t.Error("Expected deterministic rendering")
+	}
+}
+
+func TestRenderScalars(t *testing.T) {
+	tests := []struct {
+		in   any
+		want string
+	}{
+		{nil, "nil"},
+		{nullable.NewNull[string](), NullMarker},
+		{nullable.NewNullable(1.5), "1.5"},
+		{(*renderAddress)(nil), "nil"},
+		{struct{}{}, "struct{}"},
+		{map[int]bool(nil), "nil"},
+		{[]any{nil, true}, "[\n  nil\n  true\n]"},
+	}
+	for _, tt := range tests {
+		if got := Render(tt.in); got != tt.want {
+			t.Errorf("Render(%#v): Expected %q, got %q", tt.in, tt.want, got)
+		}
+	}
+}
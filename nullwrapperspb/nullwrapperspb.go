@@ -0,0 +1,163 @@
+// Package nullwrapperspb converts between Nullables and the protobuf
+// wrapper messages, well-known time types and proto3 optional fields that
+// gRPC handlers use for nullable scalars, so that handlers need no nil
+// checks of their own:
+//
+//	user.Nickname = nullwrapperspb.FromStringValue(req.GetNickname())
+//	resp.Age = nullwrapperspb.ToInt64Value(user.Age)
+//	resp.Score = nullwrapperspb.ToDoublePtr(user.Score) // optional double score
+//
+// A nil message or pointer converts to a null Nullable and a null Nullable
+// to nil. Optional enum fields use nullable.FromPtr and Nullable.Ptr.
+package nullwrapperspb
+
+import (
+	"time"
+
+	"github.com/manattan/nullable"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// message is a wrapper message pointer, such as *wrapperspb.StringValue.
+type message[T any] interface {
+	comparable
+	GetValue() T
+}
+
+func fromMessage[T any, M message[T]](m M) nullable.Nullable[T] {
+	var zero M
+	if m == zero {
+		return nullable.NewNull[T]()
+	}
+	return nullable.NewNullable(m.GetValue())
+}
+
+func toMessage[T any, M any](n nullable.Nullable[T], f func(T) M) M {
+	if !n.Valid {
+		var zero M
+		return zero
+	}
+	return f(n.V)
+}
+
+// ToStringValue converts n to a StringValue, or nil if n is null.
+func ToStringValue(n nullable.Nullable[string]) *wrapperspb.StringValue {
+	return toMessage(n, wrapperspb.String)
+}
+
+// FromStringValue converts v to a Nullable, which is null if v is nil.
+func FromStringValue(v *wrapperspb.StringValue) nullable.Nullable[string] {
+	return fromMessage[string](v)
+}
+
+// ToBytesValue converts n to a BytesValue, or nil if n is null. The
+// message shares n's byte slice.
+func ToBytesValue(n nullable.Nullable[[]byte]) *wrapperspb.BytesValue {
+	return toMessage(n, wrapperspb.Bytes)
+}
+
+// FromBytesValue converts v to a Nullable, which is null if v is nil. The
+// Nullable shares v's byte slice.
+func FromBytesValue(v *wrapperspb.BytesValue) nullable.Nullable[[]byte] {
+	return fromMessage[[]byte](v)
+}
+
+// ToBoolValue converts n to a BoolValue, or nil if n is null.
+func ToBoolValue(n nullable.Nullable[bool]) *wrapperspb.BoolValue {
+	return toMessage(n, wrapperspb.Bool)
+}
+
+// FromBoolValue converts v to a Nullable, which is null if v is nil.
+func FromBoolValue(v *wrapperspb.BoolValue) nullable.Nullable[bool] {
+	return fromMessage[bool](v)
+}
+
+// ToInt32Value converts n to an Int32Value, or nil if n is null.
+func ToInt32Value(n nullable.Nullable[int32]) *wrapperspb.Int32Value {
+	return toMessage(n, wrapperspb.Int32)
+}
+
+// FromInt32Value converts v to a Nullable, which is null if v is nil.
+func FromInt32Value(v *wrapperspb.Int32Value) nullable.Nullable[int32] {
+	return fromMessage[int32](v)
+}
+
+// ToInt64Value converts n to an Int64Value, or nil if n is null.
+func ToInt64Value(n nullable.Nullable[int64]) *wrapperspb.Int64Value {
+	return toMessage(n, wrapperspb.Int64)
+}
+
+// FromInt64Value converts v to a Nullable, which is null if v is nil.
+func FromInt64Value(v *wrapperspb.Int64Value) nullable.Nullable[int64] {
+	return fromMessage[int64](v)
+}
+
+// ToUInt32Value converts n to a UInt32Value, or nil if n is null.
+func ToUInt32Value(n nullable.Nullable[uint32]) *wrapperspb.UInt32Value {
+	return toMessage(n, wrapperspb.UInt32)
+}
+
+// FromUInt32Value converts v to a Nullable, which is null if v is nil.
+func FromUInt32Value(v *wrapperspb.UInt32Value) nullable.Nullable[uint32] {
+	return fromMessage[uint32](v)
+}
+
+// ToUInt64Value converts n to a UInt64Value, or nil if n is null.
+func ToUInt64Value(n nullable.Nullable[uint64]) *wrapperspb.UInt64Value {
+	return toMessage(n, wrapperspb.UInt64)
+}
+
+// FromUInt64Value converts v to a Nullable, which is null if v is nil.
+func FromUInt64Value(v *wrapperspb.UInt64Value) nullable.Nullable[uint64] {
+	return fromMessage[uint64](v)
+}
+
+// ToFloatValue converts n to a FloatValue, or nil if n is null.
+func ToFloatValue(n nullable.Nullable[float32]) *wrapperspb.FloatValue {
+	return toMessage(n, wrapperspb.Float)
+}
+
+// FromFloatValue converts v to a Nullable, which is null if v is nil.
+func FromFloatValue(v *wrapperspb.FloatValue) nullable.Nullable[float32] {
+	return fromMessage[float32](v)
+}
+
+// ToDoubleValue converts n to a DoubleValue, or nil if n is null.
+func ToDoubleValue(n nullable.Nullable[float64]) *wrapperspb.DoubleValue {
+	return toMessage(n, wrapperspb.Double)
+}
+
+// FromDoubleValue converts v to a Nullable, which is null if v is nil.
+func FromDoubleValue(v *wrapperspb.DoubleValue) nullable.Nullable[float64] {
+	return fromMessage[float64](v)
+}
+
+// ToTimestamp converts n to a Timestamp, or nil if n is null.
+func ToTimestamp(n nullable.Nullable[time.Time]) *timestamppb.Timestamp {
+	return toMessage(n, timestamppb.New)
+}
+
+// FromTimestamp converts ts to a Nullable in UTC, which is null if ts is
+// nil.
+func FromTimestamp(ts *timestamppb.Timestamp) nullable.Nullable[time.Time] {
+	if ts == nil {
+		return nullable.NewNull[time.Time]()
+	}
+	return nullable.NewNullable(ts.AsTime())
+}
+
+// ToDuration converts n to a Duration, or nil if n is null.
+func ToDuration(n nullable.Nullable[time.Duration]) *durationpb.Duration {
+	return toMessage(n, durationpb.New)
+}
+
+// FromDuration converts d to a Nullable, which is null if d is nil. Out of
+// range durations are clamped as by Duration.AsDuration.
+func FromDuration(d *durationpb.Duration) nullable.Nullable[time.Duration] {
+	if d == nil {
+		return nullable.NewNull[time.Duration]()
+	}
+	return nullable.NewNullable(d.AsDuration())
+}
@@ -0,0 +1,67 @@
+package nullwrapperspb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/manattan/nullable"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestWrappers(t *testing.T) {
+	if v := ToStringValue(nullable.NewNullable("")); v == nil || v.GetValue() != "" {
+		t.Errorf("Expected empty StringValue, got %v", v)
+	}
+	if v := ToStringValue(nullable.NewNull[string]()); v != nil {
+		t.Errorf("Expected nil, got %v", v)
+	}
+	if n := FromStringValue(wrapperspb.String("a")); n != nullable.NewNullable("a") {
+		t.Errorf("Expected a, got %v", n)
+	}
+	if n := FromStringValue(nil); n.Valid {
+		t.Errorf("Expected null, got %v", n)
+	}
+
+	if n := FromInt64Value(ToInt64Value(nullable.NewNullable(int64(0)))); n != nullable.NewNullable(int64(0)) {
+		t.Errorf("Expected valid 0, got %v", n)
+	}
+	if v := ToInt64Value(nullable.NewNull[int64]()); v != nil {
+		t.Errorf("Expected nil, got %v", v)
+	}
+	if n := FromUInt32Value(wrapperspb.UInt32(7)); n != nullable.NewNullable(uint32(7)) {
+		t.Errorf("Expected 7, got %v", n)
+	}
+	if n := FromFloatValue(ToFloatValue(nullable.NewNullable(float32(1.5)))); n != nullable.NewNullable(float32(1.5)) {
+		t.Errorf("Expected 1.5, got %v", n)
+	}
+	if n := FromBoolValue(nil); n.Valid {
+		t.Errorf("Expected null, got %v", n)
+	}
+	if n := FromBytesValue(ToBytesValue(nullable.NewNullable([]byte{1}))); !n.Valid || len(n.V) != 1 {
+		t.Errorf("Expected [1], got %v", n)
+	}
+}
+
+func TestTimes(t *testing.T) {
+	at := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+	if n := FromTimestamp(ToTimestamp(nullable.NewNullable(at))); !n.Valid || !n.V.Equal(at) {
+		t.Errorf("Expected %v, got %v", at, n)
+	}
+	if ts := ToTimestamp(nullable.NewNull[time.Time]()); ts != nil {
+		t.Errorf("Expected nil, got %v", ts)
+	}
+	if n := FromTimestamp(nil); n.Valid {
+		t.Errorf("Expected null, got %v", n)
+	}
+
+	if n := FromDuration(durationpb.New(time.Minute)); n != nullable.NewNullable(time.Minute) {
+		t.Errorf("Expected 1m, got %v", n)
+	}
+	if d := ToDuration(nullable.NewNull[time.Duration]()); d != nil {
+		t.Errorf("Expected nil, got %v", d)
+	}
+	if n := FromDuration((*durationpb.Duration)(nil)); n.Valid {
+		t.Errorf("Expected null, got %v", n)
+	}
+}
@@ -0,0 +1,55 @@
+package nullwrapperspb
+
+import "github.com/manattan/nullable"
+
+// The functions below convert proto3 optional scalar fields, which
+// protoc-gen-go generates as pointers. They are named after the protobuf
+// types like the wrapper conversions.
+
+// ToStringPtr converts n to an optional string field value.
+func ToStringPtr(n nullable.Nullable[string]) *string { return n.Ptr() }
+
+// FromStringPtr converts an optional string field value to a Nullable.
+func FromStringPtr(p *string) nullable.Nullable[string] { return nullable.FromPtr(p) }
+
+// ToBoolPtr converts n to an optional bool field value.
+func ToBoolPtr(n nullable.Nullable[bool]) *bool { return n.Ptr() }
+
+// FromBoolPtr converts an optional bool field value to a Nullable.
+func FromBoolPtr(p *bool) nullable.Nullable[bool] { return nullable.FromPtr(p) }
+
+// ToInt32Ptr converts n to an optional int32 field value.
+func ToInt32Ptr(n nullable.Nullable[int32]) *int32 { return n.Ptr() }
+
+// FromInt32Ptr converts an optional int32 field value to a Nullable.
+func FromInt32Ptr(p *int32) nullable.Nullable[int32] { return nullable.FromPtr(p) }
+
+// ToInt64Ptr converts n to an optional int64 field value.
+func ToInt64Ptr(n nullable.Nullable[int64]) *int64 { return n.Ptr() }
+
+// FromInt64Ptr converts an optional int64 field value to a Nullable.
+func FromInt64Ptr(p *int64) nullable.Nullable[int64] { return nullable.FromPtr(p) }
+
+// ToUInt32Ptr converts n to an optional uint32 field value.
+func ToUInt32Ptr(n nullable.Nullable[uint32]) *uint32 { return n.Ptr() }
+
+// FromUInt32Ptr converts an optional uint32 field value to a Nullable.
+func FromUInt32Ptr(p *uint32) nullable.Nullable[uint32] { return nullable.FromPtr(p) }
+
+// ToUInt64Ptr converts n to an optional uint64 field value.
+func ToUInt64Ptr(n nullable.Nullable[uint64]) *uint64 { return n.Ptr() }
+
+// FromUInt64Ptr converts an optional uint64 field value to a Nullable.
+func FromUInt64Ptr(p *uint64) nullable.Nullable[uint64] { return nullable.FromPtr(p) }
+
+// ToFloatPtr converts n to an optional float field value.
+func ToFloatPtr(n nullable.Nullable[float32]) *float32 { return n.Ptr() }
+
+// FromFloatPtr converts an optional float field value to a Nullable.
+func FromFloatPtr(p *float32) nullable.Nullable[float32] { return nullable.FromPtr(p) }
+
+// ToDoublePtr converts n to an optional double field value.
+func ToDoublePtr(n nullable.Nullable[float64]) *float64 { return n.Ptr() }
+
+// FromDoublePtr converts an optional double field value to a Nullable.
+func FromDoublePtr(p *float64) nullable.Nullable[float64] { return nullable.FromPtr(p) }
@@ -0,0 +1,31 @@
+package nullwrapperspb
+
+import (
+	"testing"
+
+	"github.com/manattan/nullable"
+)
+
+func TestOptional(t *testing.T) {
+	if p := ToInt64Ptr(nullable.NewNullable(int64(3))); p == nil || *p != 3 {
+		t.Errorf("Expected 3, got %v", p)
+	}
+	if p := ToInt64Ptr(nullable.NewNull[int64]()); p != nil {
+		t.Errorf("Expected nil, got %v", *p)
+	}
+	x := int64(0)
+	if n := FromInt64Ptr(&x); n != nullable.NewNullable(int64(0)) {
+		t.Errorf("Expected valid 0, got %v", n)
+	}
+	if n := FromInt64Ptr(nil); n.Valid {
+		t.Errorf("Expected null, got %v", n)
+	}
+
+	s := "a"
+	if n := FromStringPtr(&s); n != nullable.NewNullable("a") {
+		t.Errorf("Expected a, got %v", n)
+	}
+	if p := ToDoublePtr(nullable.NewNullable(1.5)); p == nil || *p != 1.5 {
+		t.Errorf("Expected 1.5, got %v", p)
+	}
+}
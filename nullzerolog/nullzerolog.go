@@ -0,0 +1,116 @@
+// Package nullzerolog integrates nullable.Nullable values with zerolog.
+//
+// Field writes a single Nullable with the typed zerolog method matching its
+// inner value, and Object wraps a struct so its Nullable fields are embedded
+// as native JSON values or null:
+//
+//	nullzerolog.Field(log.Info(), "age", user.Age).Msg("loaded user")
+//	log.Info().Object("user", nullzerolog.Object(user)).Msg("loaded user")
+package nullzerolog
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/manattan/nullable"
+	"github.com/manattan/nullable/internal/nullreflect"
+	"github.com/rs/zerolog"
+)
+
+// Interface returns the inner value of n, or nil when null, for use with
+// zerolog's Interface and Fields methods.
+func Interface[T any](n nullable.Nullable[T]) any {
+	if !n.Valid {
+		return nil
+	}
+	return n.V
+}
+
+// Field adds n to e under key as its inner value, or null when n is null.
+func Field[T any](e *zerolog.Event, key string, n nullable.Nullable[T]) *zerolog.Event {
+	if !n.Valid {
+		return e.Interface(key, nil)
+	}
+	return addValue(e, key, n.V)
+}
+
+func addValue(e *zerolog.Event, key string, v any) *zerolog.Event {
+	switch v := v.(type) {
+	case string:
+		return e.Str(key, v)
+	case bool:
+		return e.Bool(key, v)
+	case int:
+		return e.Int(key, v)
+	case int32:
+		return e.Int32(key, v)
+	case int64:
+		return e.Int64(key, v)
+	case uint:
+		return e.Uint(key, v)
+	case uint64:
+		return e.Uint64(key, v)
+	case float32:
+		return e.Float32(key, v)
+	case float64:
+		return e.Float64(key, v)
+	case time.Time:
+		return e.Time(key, v)
+	case time.Duration:
+		return e.Dur(key, v)
+	default:
+		return e.Interface(key, v)
+	}
+}
+
+// Object returns a zerolog.LogObjectMarshaler logging the exported fields of
+// the struct v under their json names, with Nullable fields rendered as their
+// inner value or null. Non-struct values are logged under the key "value".
+func Object(v any) zerolog.LogObjectMarshaler {
+	return object{v: v}
+}
+
+type object struct {
+	v any
+}
+
+// MarshalZerologObject implements zerolog.LogObjectMarshaler.
+func (o object) MarshalZerologObject(e *zerolog.Event) {
+	rv := reflect.ValueOf(o.v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		e.Interface("value", o.v)
+		return
+	}
+
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		key := sf.Name
+		if tag, ok := sf.Tag.Lookup("json"); ok {
+			name, _, _ := strings.Cut(tag, ",")
+			if name == "-" {
+				continue
+			}
+			if name != "" {
+				key = name
+			}
+		}
+
+		fv := rv.Field(i)
+		if nullreflect.IsNullable(fv.Type()) {
+			if !nullreflect.Valid(fv) {
+				e.Interface(key, nil)
+				continue
+			}
+			fv = nullreflect.Inner(fv)
+		}
+		addValue(e, key, fv.Interface())
+	}
+}
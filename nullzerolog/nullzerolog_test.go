@@ -0,0 +1,87 @@
+package nullzerolog
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/manattan/nullable"
+	"github.com/rs/zerolog"
+)
+
+func decode(t *testing.T, buf *bytes.Buffer) map[string]any {
+	t.Helper()
+	var got map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("Unexpected error: %v (%s)", err, buf.String())
+	}
+	return got
+}
+
+func TestField(t *testing.T) {
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf)
+
+	e := logger.Info()
+	e = Field(e, "name", nullable.NewNullable("John"))
+	e = Field(e, "age", nullable.NewNullable(30))
+	e = Field(e, "nick", nullable.NewNull[string]())
+	e.Msg("")
+
+	got := decode(t, &buf)
+	if got["name"] != "John" {
+		t.Errorf("Expected name 'John', got %v", got["name"])
+	}
+	if got["age"] != float64(30) {
+		t.Errorf("Expected age 30, got %v", got["age"])
+	}
+	if v, ok := got["nick"]; !ok || v != nil {
+		t.Errorf("Expected nick null, got %v", v)
+	}
+}
+
+func TestObject(t *testing.T) {
+	type user struct {
+		Name    nullable.Nullable[string] `json:"name"`
+		Age     nullable.Nullable[int]    `json:"age"`
+		ID      int64
+		Secret  string `json:"-"`
+		private string
+	}
+
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf)
+	logger.Info().Object("user", Object(user{
+		Name:   nullable.NewNullable("Alice"),
+		Age:    nullable.NewNull[int](),
+		ID:     3,
+		Secret: "x",
+	})).Msg("")
+
+	got := decode(t, &buf)
+	u, ok := got["user"].(map[string]any)
+	if !ok {
+		t.Fatalf("Expected user object, got %v", got["user"])
+	}
+	if u["name"] != "Alice" {
+		t.Errorf("Expected name 'Alice', got %v", u["name"])
+	}
+	if v, ok := u["age"]; !ok || v != nil {
+		t.Errorf("Expected age null, got %v", v)
+	}
+	if u["ID"] != float64(3) {
+		t.Errorf("Expected ID 3, got %v", u["ID"])
+	}
+	if _, ok := u["Secret"]; ok {
+		t.Error("Expected Secret to be skipped")
+	}
+}
+
+func TestInterface(t *testing.T) {
+	if v := Interface(nullable.NewNullable(1.5)); v != 1.5 {
+		t.Errorf("Expected 1.5, got %v", v)
+	}
+	if v := Interface(nullable.NewNull[float64]()); v != nil {
+		t.Errorf("Expected nil, got %v", v)
+	}
+}
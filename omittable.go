@@ -0,0 +1,149 @@
+package nullable
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// Omittable is a tri-state value for partial updates: absent, null, or a
+// value. The zero value is absent; UnmarshalJSON marks a field present
+// whenever its key appears, so a PATCH handler can tell
+// {"email": null} (clear the email) from {} (leave it alone):
+//
+//	type UserPatch struct {
+//		Email nullable.Omittable[string] `json:"email,omitzero"`
+//	}
+//
+//	if p.Email.IsPresent() {
+//		user.Email = p.Email.Nullable
+//	}
+//
+// Marshaling an absent Omittable produces null, so tag fields with
+// omitzero to leave absent ones out of the output.
+type Omittable[T any] struct {
+	Nullable[T]
+	present bool
+}
+
+// NewOmittable creates a present Omittable holding value.
+func NewOmittable[T any](value T) Omittable[T] {
+	return OmittableOf(NewNullable(value))
+}
+
+// NewOmittableNull creates a present Omittable that is explicitly null.
+func NewOmittableNull[T any]() Omittable[T] {
+	return OmittableOf(NewNull[T]())
+}
+
+// OmittableOf creates a present Omittable holding n.
+func OmittableOf[T any](n Nullable[T]) Omittable[T] {
+	return Omittable[T]{Nullable: n, present: true}
+}
+
+// IsPresent reports whether the value was set, either null or not.
+func (o Omittable[T]) IsPresent() bool {
+	return o.present
+}
+
+// IsNull reports whether the value is present and explicitly null.
+func (o Omittable[T]) IsNull() bool {
+	return o.present && !o.Valid
+}
+
+// IsZero reports whether the value is absent, so that fields tagged
+// omitzero are left out when encoding.
+func (o Omittable[T]) IsZero() bool {
+	return !o.present
+}
+
+// Assign stores the value in dst if it is present and reports whether it
+// did, leaving dst unchanged for an absent value.
+func (o Omittable[T]) Assign(dst *Nullable[T]) bool {
+	if o.present {
+		*dst = o.Nullable
+	}
+	return o.present
+}
+
+// GetOrInit returns the value, first setting it to f() and marking o
+// present if it is null or absent.
+func (o *Omittable[T]) GetOrInit(f func() T) T {
+	if !o.Valid {
+		o.Set(f())
+	}
+	return o.V
+}
+
+// Set makes o present and valid with value v.
+func (o *Omittable[T]) Set(v T) {
+	o.Nullable.Set(v)
+	o.present = true
+}
+
+// SetNull makes o present and explicitly null.
+func (o *Omittable[T]) SetNull() {
+	o.Nullable.SetNull()
+	o.present = true
+}
+
+// MarshalJSON implements the json.Marshaler interface. Absent and null
+// values both encode as null.
+func (o Omittable[T]) MarshalJSON() ([]byte, error) {
+	return o.Nullable.MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, marking o
+// present.
+func (o *Omittable[T]) UnmarshalJSON(data []byte) error {
+	if err := o.Nullable.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	o.present = true
+	return nil
+}
+
+// Scan implements the sql.Scanner interface. Scanned values, including
+// NULL, are present.
+func (o *Omittable[T]) Scan(value any) error {
+	if err := o.Nullable.Scan(value); err != nil {
+		return err
+	}
+	o.present = true
+	return nil
+}
+
+// Reset makes o absent again.
+func (o *Omittable[T]) Reset() {
+	*o = Omittable[T]{}
+}
+
+// String returns "absent" for an absent value and the Nullable's string
+// representation otherwise.
+func (o Omittable[T]) String() string {
+	if !o.present {
+		return "absent"
+	}
+	return o.Nullable.String()
+}
+
+// Format implements the fmt.Formatter interface like Nullable.Format,
+// printing an absent value as absent.
+func (o Omittable[T]) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && f.Flag('#'):
+		io.WriteString(f, o.GoString())
+	case !o.present:
+		io.WriteString(f, "absent")
+	default:
+		o.Nullable.Format(f, verb)
+	}
+}
+
+// GoString implements the fmt.GoStringer interface.
+func (o Omittable[T]) GoString() string {
+	if !o.present {
+		return fmt.Sprintf("nullable.Omittable[%s]{}", reflect.TypeFor[T]())
+	}
+	return fmt.Sprintf("nullable.OmittableOf(%#v)", o.Nullable)
+}
@@ -0,0 +1,131 @@
+package nullable
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type omittablePatch struct {
+	Email Omittable[string] `json:"email,omitzero"`
+	Age   Omittable[int]    `json:"age,omitzero"`
+	Nick  Omittable[string] `json:"nick,omitzero"`
+}
+
+func TestOmittableUnmarshal(t *testing.T) {
+	var p omittablePatch
+	if err := json.Unmarshal([]byte(`{"email":null,"age":36}`), &p); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !p.Email.IsPresent() || !p.Email.IsNull() {
+		t.Errorf("Expected present null email, got %v", p.Email)
+	}
+	if !p.Age.IsPresent() || p.Age.IsNull() || p.Age.V != 36 {
+		t.Errorf("Expected present 36, got %v", p.Age)
+	}
+	if p.Nick.IsPresent() || p.Nick.IsNull() {
+		t.Errorf("Expected absent nick, got %v", p.Nick)
+	}
+
+	var q omittablePatch
+	if err := Unmarshal([]byte(`{"email":"ada@example.com"}`), &q); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if q.Email != NewOmittable("ada@example.com") || q.Age.IsPresent() {
+		t.Errorf("Expected email only, got %+v", q)
+	}
+
+	if err := json.Unmarshal([]byte(`{"age":"x"}`), &q); err == nil {
+		t.Error("Expected error for string age")
+	}
+}
+
+func TestOmittableMarshal(t *testing.T) {
+	p := omittablePatch{
+		Email: NewOmittableNull[string](),
+		Age:   NewOmittable(36),
+	}
+	want := `{"email":null,"age":36}`
+	for name, marshal := range map[string]func(any) ([]byte, error){
+		"encoding/json":    json.Marshal,
+		"nullable.Marshal": func(v any) ([]byte, error) { return Marshal(v) },
+	} {
+		data, err := marshal(p)
+		if err != nil {
+			t.Fatalf("%s: Unexpected error: %v", name, err)
+		}
+		if string(data) != want {
+			t.Errorf("%s: Expected %s, got %s", name, want, data)
+		}
+	}
+	if data, _ := json.Marshal(Omittable[int]{}); string(data) != "null" {
+		t.Errorf("Expected null for absent value, got %s", data)
+	}
+}
+
+func TestOmittableAssign(t *testing.T) {
+	dst := NewNullable("keep")
+	if (Omittable[string]{}).Assign(&dst) || dst != NewNullable("keep") {
+		t.Errorf("Expected absent value to leave dst unchanged, got %v", dst)
+	}
+	if !NewOmittableNull[string]().Assign(&dst) || dst.Valid {
+		t.Errorf("Expected null to clear dst, got %v", dst)
+	}
+	if !OmittableOf(NewNullable("new")).Assign(&dst) || dst != NewNullable("new") {
+		t.Errorf("Expected new, got %v", dst)
+	}
+}
+
+func TestOmittableScanReset(t *testing.T) {
+	var o Omittable[int64]
+	if err := o.Scan(nil); err != nil || !o.IsNull() {
+		t.Errorf("Expected present null, got %v (%v)", o, err)
+	}
+	if err := o.Scan(int64(7)); err != nil || o.V != 7 || !o.IsPresent() {
+		t.Errorf("Expected present 7, got %v (%v)", o, err)
+	}
+	if o.String() != "7" {
+		t.Errorf("Expected 7, got %s", o.String())
+	}
+	o.Reset()
+	if o.IsPresent() || o.Valid || o.String() != "absent" {
+		t.Errorf("Expected absent, got %v", o)
+	}
+}
+
+func TestOmittableSet(t *testing.T) {
+	var o Omittable[int]
+	o.Set(5)
+	if o != NewOmittable(5) {
+		t.Errorf("Expected present 5, got %v", o)
+	}
+	if data, _ := json.Marshal(struct {
+		O Omittable[int] `json:"o,omitzero"`
+	}{o}); string(data) != `{"o":5}` {
+		t.Errorf("Expected {\"o\":5}, got %s", data)
+	}
+	var dst Nullable[int]
+	if !o.Assign(&dst) || dst != NewNullable(5) {
+		t.Errorf("Expected Assign to store 5, got %v", dst)
+	}
+
+	var cleared Omittable[int]
+	cleared.SetNull()
+	if !cleared.IsNull() {
+		t.Errorf("Expected present null, got %v", cleared)
+	}
+}
+
+func TestOmittableGetOrInit(t *testing.T) {
+	var o Omittable[int]
+	if v := o.GetOrInit(func() int { return 3 }); v != 3 || o != NewOmittable(3) {
+		t.Errorf("Expected present 3, got %d and %v", v, o)
+	}
+	if v := o.GetOrInit(func() int { return 4 }); v != 3 {
+		t.Errorf("Expected existing 3, got %d", v)
+	}
+
+	null := NewOmittableNull[int]()
+	if v := null.GetOrInit(func() int { return 5 }); v != 5 || null != NewOmittable(5) {
+		t.Errorf("Expected present 5, got %d and %v", v, null)
+	}
+}
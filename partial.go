@@ -0,0 +1,109 @@
+package nullable
+
+import (
+	"reflect"
+
+	"github.com/manattan/nullable/internal/nullreflect"
+)
+
+// MatchesPartial reports whether actual matches the set fields of expected,
+// for test assertions and rule predicates written as partial structs:
+//
+//	want := UserFilter{Country: nullable.NewNullable("FR")}
+//	if !nullable.MatchesPartial(want, got) { ... }
+//
+// Both arguments are structs or pointers to structs, and fields are paired
+// by name, so expected may be a dedicated filter type. Null Nullable fields
+// of expected are ignored; a valid one matches when actual's field holds an
+// equal value, either directly or in a valid Nullable. Other fields are
+// always compared, and nested structs are matched the same way. Values
+// with an Equal method, such as time.Time, are compared with it, and
+// others with reflect.DeepEqual.
+//
+// MatchesPartial returns false when actual lacks a compared field.
+func MatchesPartial(expected, actual any) bool {
+	e, a := indirect(reflect.ValueOf(expected)), indirect(reflect.ValueOf(actual))
+	if !e.IsValid() || !a.IsValid() {
+		return !e.IsValid() && !a.IsValid()
+	}
+	if e.Kind() != reflect.Struct || a.Kind() != reflect.Struct {
+		return valuesEqual(e, a)
+	}
+	return matchStruct(e, a)
+}
+
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func matchStruct(e, a reflect.Value) bool {
+	t := e.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		ev := e.Field(i)
+		if nullreflect.IsNullable(sf.Type) {
+			if !nullreflect.Valid(ev) {
+				continue
+			}
+			ev = nullreflect.Inner(ev)
+		}
+		af := a.FieldByName(sf.Name)
+		if !af.IsValid() {
+			return false
+		}
+		if nullreflect.IsNullable(af.Type()) {
+			if !nullreflect.Valid(af) {
+				return false
+			}
+			af = nullreflect.Inner(af)
+		}
+		if !matchValue(ev, af) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchValue(e, a reflect.Value) bool {
+	if hasEqual(e.Type()) {
+		return valuesEqual(e, a)
+	}
+	e, a = indirect(e), indirect(a)
+	if !e.IsValid() || !a.IsValid() {
+		return !e.IsValid() && !a.IsValid()
+	}
+	if e.Kind() == reflect.Struct && a.Kind() == reflect.Struct && !hasEqual(e.Type()) {
+		return matchStruct(e, a)
+	}
+	return valuesEqual(e, a)
+}
+
+// hasEqual reports whether t has a method Equal(t) bool.
+func hasEqual(t reflect.Type) bool {
+	m, ok := t.MethodByName("Equal")
+	return ok && m.Type.NumIn() == 2 && m.Type.In(1) == t &&
+		m.Type.NumOut() == 1 && m.Type.Out(0).Kind() == reflect.Bool
+}
+
+func valuesEqual(e, a reflect.Value) bool {
+	if e.Type() != a.Type() {
+		if !a.Type().ConvertibleTo(e.Type()) || e.Kind() != a.Kind() {
+			return false
+		}
+		a = a.Convert(e.Type())
+	}
+	if hasEqual(e.Type()) {
+		m, _ := e.Type().MethodByName("Equal")
+		return m.Func.Call([]reflect.Value{e, a})[0].Bool()
+	}
+	return reflect.DeepEqual(e.Interface(), a.Interface())
+}
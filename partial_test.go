@@ -0,0 +1,65 @@
+package nullable
+
+import (
+	"testing"
+	"time"
+)
+
+type partialAddress struct {
+	City    Nullable[string]
+	Country Nullable[string]
+}
+
+type partialUser struct {
+	Name     string
+	Age      Nullable[int]
+	Email    Nullable[string]
+	Joined   time.Time
+	Address  partialAddress
+	Tags     []string
+	internal int
+}
+
+func TestMatchesPartial(t *testing.T) {
+	joined := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	actual := partialUser{
+		Name:    "Ada",
+		Age:     NewNullable(36),
+		Joined:  joined,
+		Address: partialAddress{City: NewNullable("Paris"), Country: NewNullable("FR")},
+		Tags:    []string{"a"},
+	}
+
+	tests := []struct {
+		name     string
+		expected any
+		want     bool
+	}{
+		{"all set fields match", partialUser{Name: "Ada", Age: NewNullable(36), Joined: joined, Address: partialAddress{Country: NewNullable("FR")}, Tags: []string{"a"}}, true},
+		{"value mismatch", partialUser{Name: "Ada", Age: NewNullable(37), Joined: joined, Tags: []string{"a"}}, false},
+		{"expected valid, actual null", partialUser{Name: "Ada", Email: NewNullable("a@b.c"), Joined: joined, Tags: []string{"a"}}, false},
+		{"plain field compared", partialUser{Name: "Bob", Joined: joined, Tags: []string{"a"}}, false},
+		{"nested mismatch", partialUser{Name: "Ada", Joined: joined, Address: partialAddress{City: NewNullable("Lyon")}, Tags: []string{"a"}}, false},
+		{"time compared with Equal", partialUser{Name: "Ada", Joined: joined.In(time.FixedZone("X", 3600)), Tags: []string{"a"}}, true},
+		{"filter type", struct {
+			Name Nullable[string]
+			Age  Nullable[int]
+		}{Age: NewNullable(36)}, true},
+		{"filter with plain value", struct{ Age int }{36}, true},
+		{"filter with missing field", struct{ Height Nullable[int] }{NewNullable(170)}, false},
+		{"empty filter", struct{ Height Nullable[int] }{}, true},
+		{"pointer", &partialUser{Name: "Ada", Joined: joined, Tags: []string{"a"}}, true},
+	}
+	for _, tc := range tests {
+		if got := MatchesPartial(tc.expected, &actual); got != tc.want {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.want, got)
+		}
+	}
+
+	if !MatchesPartial(nil, nil) || MatchesPartial(partialUser{}, nil) {
+		t.Error("Expected nil to match only nil")
+	}
+	if !MatchesPartial(3, 3) || MatchesPartial(3, 4) {
+		t.Error("Expected non-struct values to be compared directly")
+	}
+}
@@ -0,0 +1,295 @@
+package nullable
+
+import (
+	"cmp"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RangeElement is the set of element types supported by Range.
+type RangeElement interface {
+	int32 | int64 | time.Time | Decimal
+}
+
+// Range is a PostgreSQL range value. A null Lower or Upper bound is
+// unbounded. Use Nullable[Range[T]] for nullable range columns:
+//
+//	var slot nullable.Nullable[nullable.TstzRange]
+//	err := row.Scan(&slot)
+//
+// In JSON a range is encoded as {"lower":...,"upper":...,"bounds":"[)"}, and
+// the empty range as {"empty":true}.
+type Range[T RangeElement] struct {
+	Lower          Nullable[T]
+	Upper          Nullable[T]
+	LowerInclusive bool
+	UpperInclusive bool
+	Empty          bool
+}
+
+// Int4Range is a PostgreSQL int4range.
+type Int4Range = Range[int32]
+
+// Int8Range is a PostgreSQL int8range.
+type Int8Range = Range[int64]
+
+// NumRange is a PostgreSQL numrange.
+type NumRange = Range[Decimal]
+
+// TstzRange is a PostgreSQL tstzrange.
+type TstzRange = Range[time.Time]
+
+// NewRange creates a range between lower and upper with the given bounds,
+// such as "[)" or "[]". Null bounds are unbounded.
+func NewRange[T RangeElement](lower, upper Nullable[T], bounds string) (Range[T], error) {
+	r := Range[T]{Lower: lower, Upper: upper}
+	if err := r.setBounds(bounds); err != nil {
+		return Range[T]{}, err
+	}
+	return r, nil
+}
+
+// Bounds returns the bound characters of r, such as "[)", or "empty".
+func (r Range[T]) Bounds() string {
+	if r.Empty {
+		return "empty"
+	}
+	b := []byte("()")
+	if r.LowerInclusive && r.Lower.Valid {
+		b[0] = '['
+	}
+	if r.UpperInclusive && r.Upper.Valid {
+		b[1] = ']'
+	}
+	return string(b)
+}
+
+func (r *Range[T]) setBounds(bounds string) error {
+	if len(bounds) != 2 || !strings.ContainsRune("[(", rune(bounds[0])) || !strings.ContainsRune("])", rune(bounds[1])) {
+		return fmt.Errorf("nullable: invalid range bounds %q", bounds)
+	}
+	r.LowerInclusive, r.UpperInclusive = bounds[0] == '[', bounds[1] == ']'
+	return nil
+}
+
+// Contains reports whether v lies within r.
+func (r Range[T]) Contains(v T) bool {
+	if r.Empty {
+		return false
+	}
+	if r.Lower.Valid {
+		c := compareElement(v, r.Lower.V)
+		if c < 0 || (c == 0 && !r.LowerInclusive) {
+			return false
+		}
+	}
+	if r.Upper.Valid {
+		c := compareElement(v, r.Upper.V)
+		if c > 0 || (c == 0 && !r.UpperInclusive) {
+			return false
+		}
+	}
+	return true
+}
+
+func compareElement[T RangeElement](a, b T) int {
+	switch a := any(a).(type) {
+	case int32:
+		return cmp.Compare(a, any(b).(int32))
+	case int64:
+		return cmp.Compare(a, any(b).(int64))
+	case time.Time:
+		return a.Compare(any(b).(time.Time))
+	case Decimal:
+		return a.Cmp(any(b).(Decimal))
+	}
+	panic("unreachable")
+}
+
+// String returns the PostgreSQL text form of r, such as "[1,5)".
+func (r Range[T]) String() string {
+	if r.Empty {
+		return "empty"
+	}
+	bounds := r.Bounds()
+	var b strings.Builder
+	b.WriteByte(bounds[0])
+	if r.Lower.Valid {
+		b.WriteString(formatElement(r.Lower.V))
+	}
+	b.WriteByte(',')
+	if r.Upper.Valid {
+		b.WriteString(formatElement(r.Upper.V))
+	}
+	b.WriteByte(bounds[1])
+	return b.String()
+}
+
+const pgTimestamptzLayout = "2006-01-02 15:04:05.999999999Z07:00"
+
+func formatElement[T RangeElement](v T) string {
+	switch v := any(v).(type) {
+	case int32:
+		return strconv.FormatInt(int64(v), 10)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case time.Time:
+		return strconv.Quote(v.Format(pgTimestamptzLayout))
+	case Decimal:
+		return v.String()
+	}
+	panic("unreachable")
+}
+
+func parseElement[T RangeElement](s string) (T, error) {
+	var zero T
+	switch any(zero).(type) {
+	case int32:
+		i, err := strconv.ParseInt(s, 10, 32)
+		return any(int32(i)).(T), err
+	case int64:
+		i, err := strconv.ParseInt(s, 10, 64)
+		return any(i).(T), err
+	case time.Time:
+		for _, layout := range []string{"2006-01-02 15:04:05.999999999Z07", pgTimestamptzLayout, "2006-01-02 15:04:05.999999999Z07:00:00", time.RFC3339Nano} {
+			if t, err := time.Parse(layout, s); err == nil {
+				return any(t).(T), nil
+			}
+		}
+		return zero, fmt.Errorf("nullable: invalid timestamp %q in range", s)
+	case Decimal:
+		d, err := ParseDecimal(s)
+		return any(d).(T), err
+	}
+	panic("unreachable")
+}
+
+// Parse parses the PostgreSQL text form of a range into r.
+func (r *Range[T]) Parse(s string) error {
+	s = strings.TrimSpace(s)
+	if strings.EqualFold(s, "empty") {
+		*r = Range[T]{Empty: true}
+		return nil
+	}
+	if len(s) < 3 {
+		return fmt.Errorf("nullable: invalid range %q", s)
+	}
+
+	var parsed Range[T]
+	if err := parsed.setBounds(s[:1] + s[len(s)-1:]); err != nil {
+		return err
+	}
+	lower, upper, err := splitRange(s[1 : len(s)-1])
+	if err != nil {
+		return fmt.Errorf("nullable: invalid range %q: %w", s, err)
+	}
+	for _, b := range []struct {
+		text string
+		dst  *Nullable[T]
+	}{{lower, &parsed.Lower}, {upper, &parsed.Upper}} {
+		if b.text == "" {
+			continue
+		}
+		v, err := parseElement[T](b.text)
+		if err != nil {
+			return err
+		}
+		*b.dst = NewNullable(v)
+	}
+	*r = parsed
+	return nil
+}
+
+// splitRange splits the inside of a range literal into its lower and upper
+// bound texts, removing quotes and backslash escapes.
+func splitRange(s string) (string, string, error) {
+	var parts [2]strings.Builder
+	part, quoted := 0, false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s):
+			i++
+			parts[part].WriteByte(s[i])
+		case c == '"':
+			if quoted && i+1 < len(s) && s[i+1] == '"' {
+				parts[part].WriteByte('"')
+				i++
+			} else {
+				quoted = !quoted
+			}
+		case c == ',' && !quoted:
+			if part == 1 {
+				return "", "", errors.New("too many bounds")
+			}
+			part = 1
+		default:
+			parts[part].WriteByte(c)
+		}
+	}
+	if part != 1 || quoted {
+		return "", "", errors.New("malformed bounds")
+	}
+	return parts[0].String(), parts[1].String(), nil
+}
+
+// Scan implements the sql.Scanner interface for the PostgreSQL text form.
+func (r *Range[T]) Scan(value any) error {
+	switch v := value.(type) {
+	case string:
+		return r.Parse(v)
+	case []byte:
+		return r.Parse(string(v))
+	default:
+		return fmt.Errorf("nullable: cannot scan %T into range", value)
+	}
+}
+
+// Value implements the driver.Valuer interface.
+func (r Range[T]) Value() (driver.Value, error) {
+	return r.String(), nil
+}
+
+type rangeJSON[T RangeElement] struct {
+	Lower  Nullable[T] `json:"lower"`
+	Upper  Nullable[T] `json:"upper"`
+	Bounds string      `json:"bounds"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (r Range[T]) MarshalJSON() ([]byte, error) {
+	if r.Empty {
+		return []byte(`{"empty":true}`), nil
+	}
+	return json.Marshal(rangeJSON[T]{Lower: r.Lower, Upper: r.Upper, Bounds: r.Bounds()})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. A missing bounds
+// member defaults to "[)".
+func (r *Range[T]) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		rangeJSON[T]
+		Empty bool `json:"empty"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if raw.Empty {
+		*r = Range[T]{Empty: true}
+		return nil
+	}
+	if raw.Bounds == "" {
+		raw.Bounds = "[)"
+	}
+	parsed, err := NewRange(raw.Lower, raw.Upper, raw.Bounds)
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
@@ -0,0 +1,115 @@
+package nullable
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestRangeScan(t *testing.T) {
+	var r Int4Range
+	if err := r.Scan([]byte("[1,5)")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if r.Lower.V != 1 || r.Upper.V != 5 || !r.LowerInclusive || r.UpperInclusive {
+		t.Errorf("Unexpected range %+v", r)
+	}
+	if !r.Contains(1) || !r.Contains(4) || r.Contains(5) || r.Contains(0) {
+		t.Error("Unexpected Contains results for [1,5)")
+	}
+
+	if err := r.Scan("(,10]"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if r.Lower.Valid || !r.Upper.Valid || r.Bounds() != "(]" || !r.Contains(-100) {
+		t.Errorf("Unexpected range %+v", r)
+	}
+
+	if err := r.Scan("empty"); err != nil || !r.Empty || r.Contains(1) {
+		t.Errorf("Expected empty range, got %+v (%v)", r, err)
+	}
+
+	var ts TstzRange
+	if err := ts.Scan(`["2024-01-01 09:00:00+00","2024-01-01 17:30:00.5+09")`); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	if !ts.Lower.V.Equal(want) {
+		t.Errorf("Expected lower %v, got %v", want, ts.Lower.V)
+	}
+	if ts.Upper.V.Nanosecond() != 500_000_000 {
+		t.Errorf("Expected fractional upper bound, got %v", ts.Upper.V)
+	}
+
+	for _, bad := range []any{"[1,2", "{1,2}", "[1,2,3)", "[a,2)", `["1,2)`, 42} {
+		if err := r.Scan(bad); err == nil {
+			t.Errorf("%v: Expected error", bad)
+		}
+	}
+}
+
+func TestRangeValue(t *testing.T) {
+	r, err := NewRange(NewNullable(int64(3)), NewNull[int64](), "[]")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	v, err := r.Value()
+	if err != nil || v != "[3,)" {
+		t.Errorf("Expected '[3,)', got %v (%v)", v, err)
+	}
+
+	ts := TstzRange{Lower: NewNullable(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)), LowerInclusive: true}
+	if s := ts.String(); s != `["2024-01-01 00:00:00Z",)` {
+		t.Errorf("Unexpected text %s", s)
+	}
+	var back TstzRange
+	if err := back.Scan(ts.String()); err != nil || !back.Lower.V.Equal(ts.Lower.V) {
+		t.Errorf("Expected round trip, got %+v (%v)", back, err)
+	}
+
+	num := NumRange{Lower: NewNullable(MustParseDecimal("0.5")), Upper: NewNullable(MustParseDecimal("1.25"))}
+	if s := num.String(); s != "(0.5,1.25)" {
+		t.Errorf("Unexpected text %s", s)
+	}
+
+	if _, err := NewRange(NewNull[int32](), NewNull[int32](), "<>"); err == nil {
+		t.Error("Expected error for invalid bounds")
+	}
+}
+
+func TestRangeJSON(t *testing.T) {
+	type slot struct {
+		Range Nullable[Int4Range] `json:"range"`
+	}
+
+	data, err := json.Marshal(slot{Range: NewNullable(Int4Range{Lower: NewNullable[int32](1), Upper: NewNullable[int32](5), LowerInclusive: true})})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(data) != `{"range":{"lower":1,"upper":5,"bounds":"[)"}}` {
+		t.Errorf("Unexpected JSON %s", data)
+	}
+
+	var s slot
+	if err := json.Unmarshal([]byte(`{"range":{"lower":null,"upper":9}}`), &s); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !s.Range.Valid || s.Range.V.Lower.Valid || s.Range.V.Upper.V != 9 || s.Range.V.Bounds() != "()" {
+		t.Errorf("Unexpected range %+v", s.Range)
+	}
+
+	if err := json.Unmarshal([]byte(`{"range":{"empty":true}}`), &s); err != nil || !s.Range.V.Empty {
+		t.Errorf("Expected empty range, got %+v (%v)", s.Range, err)
+	}
+	data, _ = json.Marshal(s)
+	if string(data) != `{"range":{"empty":true}}` {
+		t.Errorf("Unexpected JSON %s", data)
+	}
+
+	if err := json.Unmarshal([]byte(`{"range":null}`), &s); err != nil || s.Range.Valid {
+		t.Errorf("Expected null range, got %+v (%v)", s.Range, err)
+	}
+	if err := json.Unmarshal([]byte(`{"range":{"bounds":"<>"}}`), &s); err == nil {
+		t.Error("Expected error for invalid bounds")
+	}
+}
@@ -0,0 +1,69 @@
+package nullable
+
+// Pipeline is a lazily evaluated chain of transformations on a Nullable,
+// built with Pipe. No step runs until Value is called, and steps after the
+// value becomes null are skipped:
+//
+//	name := nullable.Pipe(req.Name).
+//		Map(strings.TrimSpace).
+//		Filter(func(s string) bool { return s != "" }).
+//		Value()
+//
+// Methods cannot change the value type; use PipeMap for that. The zero
+// Pipeline yields null.
+type Pipeline[T any] struct {
+	eval func() Nullable[T]
+}
+
+// Pipe starts a Pipeline from n.
+func Pipe[T any](n Nullable[T]) Pipeline[T] {
+	return Pipeline[T]{eval: func() Nullable[T] { return n }}
+}
+
+// Map adds a step that replaces a valid value with f(value).
+func (p Pipeline[T]) Map(f func(T) T) Pipeline[T] {
+	return PipeMap(p, f)
+}
+
+// Filter adds a step that turns a valid value into null unless keep
+// reports true for it.
+func (p Pipeline[T]) Filter(keep func(T) bool) Pipeline[T] {
+	return p.Then(func(v T) Nullable[T] {
+		if !keep(v) {
+			return NewNull[T]()
+		}
+		return NewNullable(v)
+	})
+}
+
+// Then adds a step that replaces a valid value with the Nullable f returns,
+// which may be null.
+func (p Pipeline[T]) Then(f func(T) Nullable[T]) Pipeline[T] {
+	return PipeThen(p, f)
+}
+
+// Value runs the pipeline and returns its result. Each call evaluates the
+// steps again.
+func (p Pipeline[T]) Value() Nullable[T] {
+	if p.eval == nil {
+		return NewNull[T]()
+	}
+	return p.eval()
+}
+
+// PipeMap adds a step to p that converts a valid value to another type.
+func PipeMap[T, U any](p Pipeline[T], f func(T) U) Pipeline[U] {
+	return PipeThen(p, func(v T) Nullable[U] { return NewNullable(f(v)) })
+}
+
+// PipeThen adds a step to p that converts a valid value to a Nullable of
+// another type.
+func PipeThen[T, U any](p Pipeline[T], f func(T) Nullable[U]) Pipeline[U] {
+	return Pipeline[U]{eval: func() Nullable[U] {
+		n := p.Value()
+		if !n.Valid {
+			return NewNull[U]()
+		}
+		return f(n.V)
+	}}
+}
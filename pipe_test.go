@@ -0,0 +1,55 @@
+package nullable
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestPipe(t *testing.T) {
+	nonEmpty := func(s string) bool { return s != "" }
+
+	got := Pipe(NewNullable("  Ada ")).Map(strings.TrimSpace).Filter(nonEmpty).Map(strings.ToUpper).Value()
+	if !got.Valid || got.V != "ADA" {
+		t.Errorf("Expected ADA, got %v", got)
+	}
+	if got := Pipe(NewNullable("   ")).Map(strings.TrimSpace).Filter(nonEmpty).Value(); got.Valid {
+		t.Errorf("Expected null after filter, got %v", got)
+	}
+	if got := (Pipeline[string]{}).Value(); got.Valid {
+		t.Errorf("Expected null from zero Pipeline, got %v", got)
+	}
+
+	parse := func(s string) Nullable[int] {
+		n, err := strconv.Atoi(s)
+		return Of(n, err == nil)
+	}
+	n := PipeMap(PipeThen(Pipe(NewNullable("41")), parse), func(n int) float64 { return float64(n) + 1 }).Value()
+	if !n.Valid || n.V != 42 {
+		t.Errorf("Expected 42, got %v", n)
+	}
+	if n := PipeThen(Pipe(NewNullable("x")), parse).Value(); n.Valid {
+		t.Errorf("Expected null for unparsable input, got %v", n)
+	}
+}
+
+func TestPipeLazy(t *testing.T) {
+	calls := 0
+	count := func(s string) string { calls++; return s }
+
+	p := Pipe(NewNullable("a")).Map(count).Map(count)
+	if calls != 0 {
+		t.Fatalf("Expected no calls before Value, got %d", calls)
+	}
+	p.Value()
+	p.Value()
+	if calls != 4 {
+		t.Errorf("Expected 4 calls, got %d", calls)
+	}
+
+	calls = 0
+	Pipe(NewNull[string]()).Map(count).Filter(func(string) bool { calls++; return true }).Value()
+	if calls != 0 {
+		t.Errorf("Expected steps to be skipped for null, got %d calls", calls)
+	}
+}
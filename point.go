@@ -0,0 +1,177 @@
+package nullable
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Point is a geographic location in degrees. It is encoded in JSON as a
+// GeoJSON Point geometry and in SQL as PostgreSQL point text "(lon,lat)". Use
+// Nullable[Point] for optional locations:
+//
+//	var loc nullable.Nullable[nullable.Point]
+//	err := row.Scan(&loc)
+//
+// For PostGIS columns, pass WKT to ST_GeomFromText, or select the column
+// directly: Scan also accepts WKT, EWKT and the hex EWKB PostGIS returns.
+type Point struct {
+	Lat float64
+	Lon float64
+}
+
+// Validate reports an error if the coordinates are out of range.
+func (p Point) Validate() error {
+	if math.IsNaN(p.Lat) || p.Lat < -90 || p.Lat > 90 {
+		return fmt.Errorf("nullable: latitude %v out of range [-90, 90]", p.Lat)
+	}
+	if math.IsNaN(p.Lon) || p.Lon < -180 || p.Lon > 180 {
+		return fmt.Errorf("nullable: longitude %v out of range [-180, 180]", p.Lon)
+	}
+	return nil
+}
+
+// WKT returns the point as Well-Known Text, "POINT(lon lat)".
+func (p Point) WKT() string {
+	return "POINT(" + formatCoord(p.Lon) + " " + formatCoord(p.Lat) + ")"
+}
+
+// String returns the PostgreSQL point text "(lon,lat)".
+func (p Point) String() string {
+	return "(" + formatCoord(p.Lon) + "," + formatCoord(p.Lat) + ")"
+}
+
+func formatCoord(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+type geoJSONPoint struct {
+	Type        string    `json:"type"`
+	Coordinates []float64 `json:"coordinates"`
+}
+
+// MarshalJSON implements the json.Marshaler interface, encoding p as
+// {"type":"Point","coordinates":[lon,lat]}.
+func (p Point) MarshalJSON() ([]byte, error) {
+	return json.Marshal(geoJSONPoint{Type: "Point", Coordinates: []float64{p.Lon, p.Lat}})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, accepting a
+// GeoJSON Point geometry with in-range coordinates. An altitude, if
+// present, is ignored.
+func (p *Point) UnmarshalJSON(data []byte) error {
+	var g geoJSONPoint
+	if err := json.Unmarshal(data, &g); err != nil {
+		return err
+	}
+	if g.Type != "Point" {
+		return fmt.Errorf("nullable: GeoJSON type %q is not Point", g.Type)
+	}
+	if len(g.Coordinates) < 2 || len(g.Coordinates) > 3 {
+		return fmt.Errorf("nullable: GeoJSON Point needs 2 or 3 coordinates, got %d", len(g.Coordinates))
+	}
+	return p.set(Point{Lon: g.Coordinates[0], Lat: g.Coordinates[1]})
+}
+
+func (p *Point) set(v Point) error {
+	if err := v.Validate(); err != nil {
+		return err
+	}
+	*p = v
+	return nil
+}
+
+// Scan implements the sql.Scanner interface for PostgreSQL point text,
+// WKT or EWKT points, and hex-encoded EWKB points.
+func (p *Point) Scan(value any) error {
+	var s string
+	switch v := value.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	case nil:
+		return fmt.Errorf("nullable: cannot scan NULL into Point; use Nullable[Point]")
+	default:
+		return fmt.Errorf("nullable: cannot scan %T into Point", value)
+	}
+	v, err := parsePoint(strings.TrimSpace(s))
+	if err != nil {
+		return err
+	}
+	return p.set(v)
+}
+
+func parsePoint(s string) (Point, error) {
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		x, y, ok := strings.Cut(s[1:len(s)-1], ",")
+		if ok {
+			return parseCoords(x, y, s)
+		}
+	}
+	if _, rest, ok := strings.Cut(s, ";"); ok && strings.HasPrefix(strings.ToUpper(s), "SRID=") {
+		s = rest
+	}
+	if upper := strings.ToUpper(s); strings.HasPrefix(upper, "POINT") {
+		inner := strings.TrimSpace(s[len("POINT"):])
+		if strings.HasPrefix(inner, "(") && strings.HasSuffix(inner, ")") {
+			if f := strings.Fields(inner[1 : len(inner)-1]); len(f) == 2 {
+				return parseCoords(f[0], f[1], s)
+			}
+		}
+	}
+	if b, err := hex.DecodeString(s); err == nil {
+		return parseEWKB(b)
+	}
+	return Point{}, fmt.Errorf("nullable: invalid point %q", s)
+}
+
+func parseCoords(x, y, src string) (Point, error) {
+	lon, err1 := strconv.ParseFloat(strings.TrimSpace(x), 64)
+	lat, err2 := strconv.ParseFloat(strings.TrimSpace(y), 64)
+	if err1 != nil || err2 != nil {
+		return Point{}, fmt.Errorf("nullable: invalid point %q", src)
+	}
+	return Point{Lat: lat, Lon: lon}, nil
+}
+
+// parseEWKB decodes a 2D point in (E)WKB with an optional SRID.
+func parseEWKB(b []byte) (Point, error) {
+	const sridFlag = 0x20000000
+	if len(b) < 5 {
+		return Point{}, fmt.Errorf("nullable: EWKB too short")
+	}
+	var order binary.ByteOrder = binary.LittleEndian
+	if b[0] == 0 {
+		order = binary.BigEndian
+	}
+	typ := order.Uint32(b[1:5])
+	b = b[5:]
+	if typ&sridFlag != 0 {
+		if len(b) < 4 {
+			return Point{}, fmt.Errorf("nullable: EWKB too short")
+		}
+		b = b[4:]
+	}
+	if typ&^sridFlag != 1 {
+		return Point{}, fmt.Errorf("nullable: EWKB geometry type %d is not a 2D Point", typ&^sridFlag)
+	}
+	if len(b) != 16 {
+		return Point{}, fmt.Errorf("nullable: EWKB point has %d coordinate bytes, want 16", len(b))
+	}
+	return Point{
+		Lon: math.Float64frombits(order.Uint64(b[:8])),
+		Lat: math.Float64frombits(order.Uint64(b[8:])),
+	}, nil
+}
+
+// Value implements the driver.Valuer interface, returning the PostgreSQL
+// point text "(lon,lat)".
+func (p Point) Value() (driver.Value, error) {
+	return p.String(), nil
+}
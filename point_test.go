@@ -0,0 +1,89 @@
+package nullable
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPointJSON(t *testing.T) {
+	type shop struct {
+		Location Nullable[Point] `json:"location"`
+	}
+	s := shop{Location: NewNullable(Point{Lat: 52.52, Lon: 13.405})}
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := `{"location":{"type":"Point","coordinates":[13.405,52.52]}}`; string(data) != want {
+		t.Errorf("Expected %s, got %s", want, data)
+	}
+
+	var decoded shop
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if decoded.Location != s.Location {
+		t.Errorf("Expected %+v, got %+v", s.Location, decoded.Location)
+	}
+	if err := json.Unmarshal([]byte(`{"location":null}`), &decoded); err != nil || decoded.Location.Valid {
+		t.Errorf("Expected null, got %+v (%v)", decoded.Location, err)
+	}
+
+	for _, in := range []string{
+		`{"type":"LineString","coordinates":[1,2]}`,
+		`{"type":"Point","coordinates":[1]}`,
+		`{"type":"Point","coordinates":[200,0]}`,
+		`{"type":"Point","coordinates":[0,-91]}`,
+	} {
+		var p Point
+		if err := json.Unmarshal([]byte(in), &p); err == nil {
+			t.Errorf("%s: Expected error", in)
+		}
+	}
+	var p Point
+	if err := json.Unmarshal([]byte(`{"type":"Point","coordinates":[1,2,30]}`), &p); err != nil || p != (Point{Lat: 2, Lon: 1}) {
+		t.Errorf("Expected altitude to be ignored, got %+v (%v)", p, err)
+	}
+}
+
+func TestPointScan(t *testing.T) {
+	want := Point{Lat: 52.52, Lon: 13.405}
+	for _, in := range []any{
+		"(13.405,52.52)",
+		[]byte("POINT(13.405 52.52)"),
+		"SRID=4326;POINT(13.405 52.52)",
+		"0101000020E61000008FC2F5285CCF2A40C3F5285C8F424A40",
+		"0000000001402ACF5C28F5C28F404A428F5C28F5C3",
+	} {
+		var p Point
+		if err := p.Scan(in); err != nil {
+			t.Errorf("%v: Unexpected error: %v", in, err)
+			continue
+		}
+		if p != want {
+			t.Errorf("%v: Expected %+v, got %+v", in, want, p)
+		}
+	}
+
+	var p Point
+	for _, in := range []any{"(1)", "POINT(1)", "LINESTRING(0 0, 1 1)", "(0,100)", "0102000000", nil, 1.5} {
+		if err := p.Scan(in); err == nil {
+			t.Errorf("%v: Expected error", in)
+		}
+	}
+
+	var n Nullable[Point]
+	if err := n.Scan(nil); err != nil || n.Valid {
+		t.Errorf("Expected null, got %+v (%v)", n, err)
+	}
+}
+
+func TestPointValue(t *testing.T) {
+	p := Point{Lat: -33.8688, Lon: 151.2093}
+	if v, err := p.Value(); err != nil || v != "(151.2093,-33.8688)" {
+		t.Errorf("Expected (151.2093,-33.8688), got %v (%v)", v, err)
+	}
+	if got := p.WKT(); got != "POINT(151.2093 -33.8688)" {
+		t.Errorf("Expected POINT(151.2093 -33.8688), got %s", got)
+	}
+}
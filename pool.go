@@ -0,0 +1,46 @@
+package nullable
+
+import "sync"
+
+// Resetter is implemented by values that can clear themselves for reuse,
+// such as *Nullable and the patch structs generated by nullgen.
+type Resetter interface {
+	Reset()
+}
+
+// Pool is a typed sync.Pool for reusing decode targets across requests:
+//
+//	var patches nullable.Pool[UserPatch]
+//
+//	p := patches.Get()
+//	defer patches.Put(p)
+//	if err := json.NewDecoder(r.Body).Decode(p); err != nil { ... }
+//
+// Put clears values before pooling them, calling Reset when *T is a
+// Resetter and zeroing them otherwise, so a value from Get never carries
+// fields from an earlier request. The zero Pool is ready to use and must
+// not be copied after first use.
+type Pool[T any] struct {
+	p sync.Pool
+}
+
+// Get returns a cleared *T from the pool, allocating one if it is empty.
+func (p *Pool[T]) Get() *T {
+	if v, ok := p.p.Get().(*T); ok {
+		return v
+	}
+	return new(T)
+}
+
+// Put clears v and returns it to the pool. v must not be used afterwards.
+func (p *Pool[T]) Put(v *T) {
+	if v == nil {
+		return
+	}
+	if r, ok := any(v).(Resetter); ok {
+		r.Reset()
+	} else {
+		*v = *new(T)
+	}
+	p.p.Put(v)
+}
@@ -0,0 +1,55 @@
+package nullable
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type resetCounter struct {
+	Name   Nullable[string]
+	resets int
+}
+
+func (r *resetCounter) Reset() {
+	r.Name.Reset()
+	r.resets++
+}
+
+func TestPool(t *testing.T) {
+	type request struct {
+		Name Nullable[string] `json:"name"`
+		Age  Nullable[int]    `json:"age"`
+	}
+	var pool Pool[request]
+
+	for _, body := range []string{`{"name":"Ada","age":36}`, `{"name":null}`} {
+		r := pool.Get()
+		if r.Name.Valid || r.Age.Valid {
+			t.Errorf("Expected cleared value from pool, got %+v", r)
+		}
+		if err := json.Unmarshal([]byte(body), r); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		pool.Put(r)
+		if r.Name.Valid || r.Age.Valid || r.Name.V != "" {
+			t.Errorf("Expected Put to clear the value, got %+v", r)
+		}
+	}
+	pool.Put(nil)
+
+	var counters Pool[resetCounter]
+	c := counters.Get()
+	c.Name = NewNullable("x")
+	counters.Put(c)
+	if c.resets != 1 || c.Name.Valid {
+		t.Errorf("Expected Reset to be called, got %+v", c)
+	}
+}
+
+func TestNullableReset(t *testing.T) {
+	n := NewNullable([]byte("secret"))
+	n.Reset()
+	if n.Valid || n.V != nil {
+		t.Errorf("Expected zero null, got %+v", n)
+	}
+}
@@ -0,0 +1,73 @@
+package nullable
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// ErrNull reports a missing value. Errors returned by Unwrap wrap it, and
+// *NullFieldError matches it, so callers can test for any null value with
+// errors.Is(err, nullable.ErrNull).
+var ErrNull = errors.New("nullable: value is null")
+
+// Validity is implemented by Nullable and the wrapper types embedding it.
+type Validity interface {
+	IsValid() bool
+}
+
+// NullFieldError reports a required field that is null.
+type NullFieldError struct {
+	Field string
+}
+
+func (e *NullFieldError) Error() string {
+	return fmt.Sprintf("nullable: %s is required", e.Field)
+}
+
+// Is reports whether target is ErrNull.
+func (e *NullFieldError) Is(target error) bool {
+	return target == ErrNull
+}
+
+// nullError is returned by Unwrap and names the type of the null value.
+type nullError struct {
+	typ reflect.Type
+}
+
+func (e *nullError) Error() string {
+	return fmt.Sprintf("nullable: Nullable[%s] is null", e.typ)
+}
+
+func (e *nullError) Unwrap() error {
+	return ErrNull
+}
+
+// ValidateAll checks that every value in fields is valid, for fields that
+// are required together. It returns nil when all are set, otherwise the
+// errors.Join of a *NullFieldError for each null field, in name order:
+//
+//	err := nullable.ValidateAll(map[string]nullable.Validity{
+//		"street": addr.Street,
+//		"city":   addr.City,
+//	})
+//
+// A nil Validity counts as null.
+func ValidateAll(fields map[string]Validity) error {
+	var missing []string
+	for name, v := range fields {
+		if v == nil || !v.IsValid() {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	slices.Sort(missing)
+	errs := make([]error, len(missing))
+	for i, name := range missing {
+		errs[i] = &NullFieldError{Field: name}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,41 @@
+package nullable
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateAll(t *testing.T) {
+	email, _ := NewEmail("ada@example.com")
+	err := ValidateAll(map[string]Validity{
+		"street": NewNullable("1 Main St"),
+		"email":  email,
+		"ulid":   NewULID([16]byte{1}),
+	})
+	if err != nil {
+		t.Errorf("Expected nil, got %v", err)
+	}
+
+	err = ValidateAll(map[string]Validity{
+		"street": NewNullable("1 Main St"),
+		"zip":    NewNull[string](),
+		"city":   NewNull[string](),
+		"phone":  Phone{},
+		"other":  nil,
+	})
+	want := "nullable: city is required\nnullable: other is required\nnullable: phone is required\nnullable: zip is required"
+	if err == nil || err.Error() != want {
+		t.Fatalf("Expected %q, got %v", want, err)
+	}
+	var fe *NullFieldError
+	if !errors.As(err, &fe) || fe.Field != "city" {
+		t.Errorf("Expected *NullFieldError for city, got %v", fe)
+	}
+	if !errors.Is(err, ErrNull) {
+		t.Errorf("Expected %v to match ErrNull", err)
+	}
+
+	if err := ValidateAll(nil); err != nil {
+		t.Errorf("Expected nil for no fields, got %v", err)
+	}
+}
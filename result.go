@@ -0,0 +1,72 @@
+package nullable
+
+// Result holds either a value or an error, for threading fallible optional
+// computations through pipelines. The zero value is a successful Result
+// holding the zero value of T.
+type Result[T any] struct {
+	value T
+	err   error
+}
+
+// Ok creates a successful Result holding value.
+func Ok[T any](value T) Result[T] {
+	return Result[T]{value: value}
+}
+
+// Err creates a failed Result holding err. It panics if err is nil.
+func Err[T any](err error) Result[T] {
+	if err == nil {
+		panic("nullable: Err called with nil error")
+	}
+	return Result[T]{err: err}
+}
+
+// ResultOf creates a Result from a (value, error) pair, as returned by most
+// Go functions. The value is discarded if err is non-nil.
+func ResultOf[T any](value T, err error) Result[T] {
+	if err != nil {
+		return Result[T]{err: err}
+	}
+	return Result[T]{value: value}
+}
+
+// IsOk reports whether r holds a value.
+func (r Result[T]) IsOk() bool {
+	return r.err == nil
+}
+
+// Err returns the error held by r, or nil if it holds a value.
+func (r Result[T]) Err() error {
+	return r.err
+}
+
+// Get returns the value and error held by r.
+func (r Result[T]) Get() (T, error) {
+	return r.value, r.err
+}
+
+// ValueOr returns the value if r is successful, otherwise returns the
+// default value.
+func (r Result[T]) ValueOr(defaultValue T) T {
+	if r.err != nil {
+		return defaultValue
+	}
+	return r.value
+}
+
+// ToNullable converts r to a Nullable, which is null if r failed. The error
+// is dropped.
+func (r Result[T]) ToNullable() Nullable[T] {
+	if r.err != nil {
+		return NewNull[T]()
+	}
+	return NewNullable(r.value)
+}
+
+// OkOr converts n to a Result, failing with err if n is null.
+func (n Nullable[T]) OkOr(err error) Result[T] {
+	if !n.Valid {
+		return Err[T](err)
+	}
+	return Ok(n.V)
+}
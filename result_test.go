@@ -0,0 +1,64 @@
+package nullable
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestResult(t *testing.T) {
+	r := Ok(5)
+	if !r.IsOk() || r.Err() != nil {
+		t.Error("Expected successful result")
+	}
+	if v, err := r.Get(); v != 5 || err != nil {
+		t.Errorf("Expected 5, got %v, %v", v, err)
+	}
+	if n := r.ToNullable(); !n.Valid || n.V != 5 {
+		t.Errorf("Expected valid 5, got %+v", n)
+	}
+
+	errBoom := errors.New("boom")
+	f := Err[int](errBoom)
+	if f.IsOk() || !errors.Is(f.Err(), errBoom) {
+		t.Error("Expected failed result")
+	}
+	if f.ValueOr(7) != 7 {
+		t.Error("Expected default value for failed result")
+	}
+	if n := f.ToNullable(); n.Valid {
+		t.Error("Expected null for failed result")
+	}
+}
+
+func TestResultOf(t *testing.T) {
+	if r := ResultOf(strconv.Atoi("12")); !r.IsOk() || r.ValueOr(0) != 12 {
+		t.Errorf("Expected 12, got %+v", r)
+	}
+	r := ResultOf(strconv.Atoi("x"))
+	if r.IsOk() {
+		t.Error("Expected failed result")
+	}
+	if v, _ := r.Get(); v != 0 {
+		t.Errorf("Expected value to be discarded, got %v", v)
+	}
+}
+
+func TestOkOr(t *testing.T) {
+	errMissing := errors.New("missing")
+	if r := NewNullable("x").OkOr(errMissing); !r.IsOk() || r.ValueOr("") != "x" {
+		t.Errorf("Expected 'x', got %+v", r)
+	}
+	if r := NewNull[string]().OkOr(errMissing); !errors.Is(r.Err(), errMissing) {
+		t.Errorf("Expected missing error, got %v", r.Err())
+	}
+}
+
+func TestErrNilPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for nil error")
+		}
+	}()
+	Err[int](nil)
+}
@@ -0,0 +1,223 @@
+package nullable
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ScanOption configures the behavior of ScanWith.
+type ScanOption func(*scanOptions)
+
+type scanOptions struct {
+	numericStrings bool
+	location       *time.Location
+	assumeLocation *time.Location
+	validators     []func(v any) error
+}
+
+// ScanNumericStrings makes ScanWith accept numeric strings and byte slices
+// such as " 42", "\"42\"" or "42.0" for integer and float types, as returned
+// by some older drivers for numeric columns.
+func ScanNumericStrings() ScanOption {
+	return func(o *scanOptions) {
+		o.numericStrings = true
+	}
+}
+
+// ScanInLocation makes ScanWith convert scanned time.Time values to loc, so
+// values compare consistently regardless of the driver's session time zone.
+func ScanInLocation(loc *time.Location) ScanOption {
+	return func(o *scanOptions) {
+		o.location = loc
+	}
+}
+
+// ScanUTC makes ScanWith convert scanned time.Time values to UTC.
+func ScanUTC() ScanOption {
+	return ScanInLocation(time.UTC)
+}
+
+// ScanAssumeLocation makes ScanWith reinterpret the wall clock of scanned
+// time.Time values as being in loc, for drivers returning naive timestamps
+// (such as MySQL DATETIME or Postgres timestamp without time zone) labeled
+// as UTC or local time. It is applied before ScanInLocation.
+func ScanAssumeLocation(loc *time.Location) ScanOption {
+	return func(o *scanOptions) {
+		o.assumeLocation = loc
+	}
+}
+
+// ScanOneOf makes ScanWith reject values not in allowed, for mapping
+// database enums into defined string types such as
+//
+//	type Status string
+//
+//	err := n.ScanWith(src, nullable.ScanOneOf[Status]("active", "disabled"))
+//
+// NULL is always accepted. On rejection the Nullable is left null.
+func ScanOneOf[T comparable](allowed ...T) ScanOption {
+	return func(o *scanOptions) {
+		o.validators = append(o.validators, func(v any) error {
+			tv, ok := v.(T)
+			if !ok {
+				return fmt.Errorf("nullable: ScanOneOf[%T] used to scan %T", *new(T), v)
+			}
+			for _, a := range allowed {
+				if tv == a {
+					return nil
+				}
+			}
+			return fmt.Errorf("nullable: %v is not an allowed %T value", tv, tv)
+		})
+	}
+}
+
+// ScanWith scans value into n like Scan, applying opts.
+func (n *Nullable[T]) ScanWith(value any, opts ...ScanOption) error {
+	var o scanOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if value != nil && o.numericStrings {
+		if s, ok := asString(value); ok {
+			if rv := reflect.ValueOf(&n.V).Elem(); isNumericKind(rv.Kind()) {
+				if err := setNumericString(rv, s); err != nil {
+					n.Valid = false
+					return err
+				}
+				n.Valid = true
+				return nil
+			}
+		}
+	}
+	if err := n.Scan(value); err != nil {
+		return err
+	}
+	if n.Valid {
+		for _, validate := range o.validators {
+			if err := validate(n.V); err != nil {
+				var zero T
+				n.V, n.Valid = zero, false
+				return err
+			}
+		}
+	}
+	if n.Valid && (o.location != nil || o.assumeLocation != nil) {
+		if t, ok := any(&n.V).(*time.Time); ok {
+			*t = normalizeTime(*t, &o)
+		}
+	}
+	return nil
+}
+
+func normalizeTime(t time.Time, o *scanOptions) time.Time {
+	if o.assumeLocation != nil {
+		y, mo, d := t.Date()
+		h, mi, s := t.Clock()
+		t = time.Date(y, mo, d, h, mi, s, t.Nanosecond(), o.assumeLocation)
+	}
+	if o.location != nil {
+		t = t.In(o.location)
+	}
+	return t
+}
+
+// Scanner returns an sql.Scanner scanning into n with opts, for use with
+// Rows.Scan:
+//
+//	rows.Scan(nullable.Scanner(&u.Age, nullable.ScanNumericStrings()))
+func Scanner[T any](n *Nullable[T], opts ...ScanOption) sql.Scanner {
+	return scannerFunc(func(value any) error {
+		return n.ScanWith(value, opts...)
+	})
+}
+
+type scannerFunc func(value any) error
+
+func (f scannerFunc) Scan(value any) error {
+	return f(value)
+}
+
+func asString(value any) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case []byte:
+		return string(v), true
+	}
+	return "", false
+}
+
+func isNumericKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// setNumericString parses s leniently into the numeric value v.
+func setNumericString(v reflect.Value, s string) error {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		s = strings.TrimSpace(s[1 : len(s)-1])
+	}
+
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("nullable: converting %q to %s: %w", s, v.Type(), err)
+		}
+		v.SetFloat(f)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			f, ferr := integralFloat(s)
+			if ferr != nil || f < 0 {
+				return fmt.Errorf("nullable: converting %q to %s: %w", s, v.Type(), err)
+			}
+			u = uint64(f)
+		}
+		if v.OverflowUint(u) {
+			return fmt.Errorf("nullable: converting %q to %s: value out of range", s, v.Type())
+		}
+		v.SetUint(u)
+		return nil
+	default:
+		i, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			f, ferr := integralFloat(s)
+			if ferr != nil {
+				return fmt.Errorf("nullable: converting %q to %s: %w", s, v.Type(), err)
+			}
+			i = int64(f)
+		}
+		if v.OverflowInt(i) {
+			return fmt.Errorf("nullable: converting %q to %s: value out of range", s, v.Type())
+		}
+		v.SetInt(i)
+		return nil
+	}
+}
+
+// integralFloat parses s as a float with no fractional part, such as "42.0".
+func integralFloat(s string) (float64, error) {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, err
+	}
+	if f != math.Trunc(f) || math.Abs(f) > 1<<53 {
+		return 0, fmt.Errorf("%q is not an integer", s)
+	}
+	return f, nil
+}
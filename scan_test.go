@@ -0,0 +1,133 @@
+package nullable
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScanWithNumericStrings(t *testing.T) {
+	var i Nullable[int]
+	for _, src := range []any{" 42 ", []byte("42"), `"42"`, "42.0"} {
+		if err := i.ScanWith(src, ScanNumericStrings()); err != nil {
+			t.Errorf("%q: Unexpected error: %v", src, err)
+		}
+		if !i.Valid || i.V != 42 {
+			t.Errorf("%q: Expected 42, got %+v", src, i)
+		}
+	}
+
+	var f Nullable[float64]
+	if err := f.ScanWith([]byte("1.5"), ScanNumericStrings()); err != nil || f.V != 1.5 {
+		t.Errorf("Expected 1.5, got %+v (%v)", f, err)
+	}
+
+	var u Nullable[uint8]
+	if err := u.ScanWith("300", ScanNumericStrings()); err == nil {
+		t.Error("Expected overflow error")
+	}
+	if u.Valid {
+		t.Error("Expected Valid to be false after error")
+	}
+	if err := u.ScanWith("-1", ScanNumericStrings()); err == nil {
+		t.Error("Expected error for negative unsigned value")
+	}
+	if err := i.ScanWith("4.5", ScanNumericStrings()); err == nil {
+		t.Error("Expected error for fractional integer value")
+	}
+}
+
+func TestScanWithFallback(t *testing.T) {
+	var i Nullable[int64]
+	if err := i.ScanWith(int64(7), ScanNumericStrings()); err != nil || i.V != 7 {
+		t.Errorf("Expected 7, got %+v (%v)", i, err)
+	}
+	if err := i.ScanWith(nil, ScanNumericStrings()); err != nil || i.Valid {
+		t.Errorf("Expected null, got %+v (%v)", i, err)
+	}
+
+	var s Nullable[string]
+	if err := s.ScanWith(" 42 ", ScanNumericStrings()); err != nil || s.V != " 42 " {
+		t.Errorf("Expected strings to be scanned unchanged, got %+v (%v)", s, err)
+	}
+
+	if err := i.ScanWith("42.0"); err == nil {
+		t.Error("Expected error without ScanNumericStrings")
+	}
+}
+
+func TestScanner(t *testing.T) {
+	var i Nullable[int]
+	if err := Scanner(&i, ScanNumericStrings()).Scan([]byte("5")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !i.Valid || i.V != 5 {
+		t.Errorf("Expected 5, got %+v", i)
+	}
+}
+
+func TestScanWithLocation(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	src := time.Date(2024, 5, 1, 9, 0, 0, 0, tokyo)
+
+	var n Nullable[time.Time]
+	if err := n.ScanWith(src, ScanUTC()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if n.V.Location() != time.UTC || n.V.Hour() != 0 || !n.V.Equal(src) {
+		t.Errorf("Expected UTC time equal to source, got %v", n.V)
+	}
+
+	naive := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	if err := n.ScanWith(naive, ScanAssumeLocation(tokyo), ScanUTC()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !n.V.Equal(src) || n.V.Location() != time.UTC {
+		t.Errorf("Expected naive time reinterpreted in JST, got %v", n.V)
+	}
+
+	if err := n.ScanWith(nil, ScanUTC()); err != nil || n.Valid {
+		t.Errorf("Expected null, got %+v (%v)", n, err)
+	}
+
+	var s Nullable[string]
+	if err := s.ScanWith("x", ScanUTC()); err != nil || s.V != "x" {
+		t.Errorf("Expected non-time values to be unaffected, got %+v (%v)", s, err)
+	}
+}
+
+type scanStatus string
+
+func TestScanDefinedStringType(t *testing.T) {
+	var n Nullable[scanStatus]
+	for _, src := range []any{[]byte("active"), "active"} {
+		if err := n.Scan(src); err != nil {
+			t.Fatalf("%v: Unexpected error: %v", src, err)
+		}
+		if !n.Valid || n.V != "active" {
+			t.Errorf("%v: Expected 'active', got %+v", src, n)
+		}
+	}
+}
+
+func TestScanOneOf(t *testing.T) {
+	allowed := ScanOneOf[scanStatus]("active", "disabled")
+
+	var n Nullable[scanStatus]
+	if err := n.ScanWith([]byte("disabled"), allowed); err != nil || n.V != "disabled" {
+		t.Errorf("Expected 'disabled', got %+v (%v)", n, err)
+	}
+	if err := n.ScanWith(nil, allowed); err != nil || n.Valid {
+		t.Errorf("Expected null, got %+v (%v)", n, err)
+	}
+	if err := n.ScanWith("deleted", allowed); err == nil {
+		t.Error("Expected error for disallowed value")
+	}
+	if n.Valid || n.V != "" {
+		t.Errorf("Expected null after rejection, got %+v", n)
+	}
+
+	var s Nullable[string]
+	if err := s.ScanWith("active", allowed); err == nil {
+		t.Error("Expected error for mismatched type")
+	}
+}
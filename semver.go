@@ -0,0 +1,136 @@
+package nullable
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+// Semver is a semantic version such as "1.4.0-rc.1+build.5", with or
+// without a leading "v". It is validated when parsed, scanned or decoded
+// and keeps the form it was given. Use Nullable[Semver] for optional
+// version fields:
+//
+//	type Manifest struct {
+//		MinVersion nullable.Nullable[nullable.Semver] `json:"min_version"`
+//	}
+//
+// The zero value is 0.0.0.
+type Semver struct {
+	s string
+}
+
+// ParseSemver parses s as a full semantic version: major, minor and patch
+// numbers with optional pre-release and build metadata.
+func ParseSemver(s string) (Semver, error) {
+	s = strings.TrimSpace(s)
+	v := "v" + strings.TrimPrefix(s, "v")
+	core, _, _ := strings.Cut(strings.SplitN(v, "+", 2)[0], "-")
+	if !semver.IsValid(v) || strings.Count(core, ".") != 2 {
+		return Semver{}, fmt.Errorf("nullable: invalid semantic version %q", s)
+	}
+	return Semver{s: s}, nil
+}
+
+// MustParseSemver is like ParseSemver but panics on error.
+func MustParseSemver(s string) Semver {
+	v, err := ParseSemver(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// String returns the version in the form it was parsed.
+func (v Semver) String() string {
+	if v.s == "" {
+		return "0.0.0"
+	}
+	return v.s
+}
+
+func (v Semver) prefixed() string {
+	return "v" + strings.TrimPrefix(v.String(), "v")
+}
+
+// Major returns the major version number, such as "1".
+func (v Semver) Major() string {
+	return strings.TrimPrefix(semver.Major(v.prefixed()), "v")
+}
+
+// Prerelease returns the pre-release suffix including its "-", or "".
+func (v Semver) Prerelease() string {
+	return semver.Prerelease(v.prefixed())
+}
+
+// Build returns the build metadata suffix including its "+", or "".
+func (v Semver) Build() string {
+	return semver.Build(v.prefixed())
+}
+
+// Compare compares v and other by semantic version precedence, returning
+// -1, 0 or +1. Build metadata is ignored.
+func (v Semver) Compare(other Semver) int {
+	return semver.Compare(v.prefixed(), other.prefixed())
+}
+
+// Less reports whether v has lower precedence than other.
+func (v Semver) Less(other Semver) bool {
+	return v.Compare(other) < 0
+}
+
+// Scan implements the sql.Scanner interface for text columns.
+func (v *Semver) Scan(value any) error {
+	switch s := value.(type) {
+	case string:
+		return v.parse(s)
+	case []byte:
+		return v.parse(string(s))
+	case nil:
+		return fmt.Errorf("nullable: cannot scan NULL into Semver; use Nullable[Semver]")
+	default:
+		return fmt.Errorf("nullable: cannot scan %T into Semver", value)
+	}
+}
+
+func (v *Semver) parse(s string) error {
+	parsed, err := ParseSemver(s)
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
+// Value implements the driver.Valuer interface, returning the version text.
+func (v Semver) Value() (driver.Value, error) {
+	return v.String(), nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (v Semver) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (v *Semver) UnmarshalText(text []byte) error {
+	return v.parse(string(text))
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (v Semver) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, rejecting strings
+// that are not valid semantic versions.
+func (v *Semver) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return v.parse(s)
+}
@@ -0,0 +1,103 @@
+package nullable
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseSemver(t *testing.T) {
+	for _, in := range []string{"1.2.3", "v1.2.3", "0.1.0-rc.1", "2.0.0-alpha+build.5", " 3.0.0 "} {
+		if _, err := ParseSemver(in); err != nil {
+			t.Errorf("%q: Unexpected error: %v", in, err)
+		}
+	}
+	for _, in := range []string{"", "1", "1.2", "v1.2", "1.2.3.4", "01.2.3", "latest", "1.2.3-"} {
+		if _, err := ParseSemver(in); err == nil {
+			t.Errorf("%q: Expected error", in)
+		}
+	}
+
+	v := MustParseSemver("v2.5.1-beta.2+sha.abc")
+	if v.String() != "v2.5.1-beta.2+sha.abc" {
+		t.Errorf("Expected original form, got %s", v)
+	}
+	if v.Major() != "2" || v.Prerelease() != "-beta.2" || v.Build() != "+sha.abc" {
+		t.Errorf("Expected 2, -beta.2, +sha.abc, got %s, %s, %s", v.Major(), v.Prerelease(), v.Build())
+	}
+	if (Semver{}).String() != "0.0.0" {
+		t.Errorf("Expected zero value 0.0.0, got %s", Semver{})
+	}
+}
+
+func TestSemverCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.2.3", "v1.2.3", 0},
+		{"1.2.3", "1.10.0", -1},
+		{"1.0.0-rc.1", "1.0.0", -1},
+		{"1.0.0-alpha.10", "1.0.0-alpha.2", 1},
+		{"1.0.0+a", "1.0.0+b", 0},
+	}
+	for _, tt := range tests {
+		a, b := MustParseSemver(tt.a), MustParseSemver(tt.b)
+		if got := a.Compare(b); got != tt.want {
+			t.Errorf("%s vs %s: Expected %d, got %d", tt.a, tt.b, tt.want, got)
+		}
+		if got := a.Less(b); got != (tt.want < 0) {
+			t.Errorf("%s < %s: Expected %v, got %v", tt.a, tt.b, tt.want < 0, got)
+		}
+	}
+}
+
+func TestSemverJSON(t *testing.T) {
+	type manifest struct {
+		MinVersion Nullable[Semver] `json:"min_version"`
+	}
+	var m manifest
+	if err := json.Unmarshal([]byte(`{"min_version":"1.4.0"}`), &m); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !m.MinVersion.Valid || m.MinVersion.V.String() != "1.4.0" {
+		t.Errorf("Expected 1.4.0, got %+v", m.MinVersion)
+	}
+	data, _ := json.Marshal(m)
+	if want := `{"min_version":"1.4.0"}`; string(data) != want {
+		t.Errorf("Expected %s, got %s", want, data)
+	}
+
+	if err := json.Unmarshal([]byte(`{"min_version":null}`), &m); err != nil || m.MinVersion.Valid {
+		t.Errorf("Expected null, got %+v (%v)", m.MinVersion, err)
+	}
+	if err := json.Unmarshal([]byte(`{"min_version":"1.x"}`), &m); err == nil {
+		t.Error("Expected error for invalid version")
+	}
+	if err := json.Unmarshal([]byte(`{"min_version":1}`), &m); err == nil {
+		t.Error("Expected error for number")
+	}
+}
+
+func TestSemverScan(t *testing.T) {
+	var n Nullable[Semver]
+	if err := n.Scan([]byte("v0.9.0")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !n.Valid || n.V.String() != "v0.9.0" {
+		t.Errorf("Expected v0.9.0, got %+v", n)
+	}
+	if err := n.Scan(nil); err != nil || n.Valid {
+		t.Errorf("Expected null, got %+v (%v)", n, err)
+	}
+
+	var v Semver
+	if err := v.Scan("bogus"); err == nil {
+		t.Error("Expected error for invalid version")
+	}
+	if err := v.Scan(nil); err == nil {
+		t.Error("Expected error scanning NULL into Semver")
+	}
+	if val, err := MustParseSemver("1.0.0").Value(); err != nil || val != "1.0.0" {
+		t.Errorf("Expected 1.0.0, got %v (%v)", val, err)
+	}
+}
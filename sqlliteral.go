@@ -0,0 +1,173 @@
+package nullable
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Dialect selects the SQL syntax used to render literals.
+type Dialect int
+
+// Supported dialects.
+const (
+	Postgres Dialect = iota
+	MySQL
+	SQLite
+	SQLServer
+)
+
+// String returns the name of the dialect.
+func (d Dialect) String() string {
+	switch d {
+	case Postgres:
+		return "postgres"
+	case MySQL:
+		return "mysql"
+	case SQLite:
+		return "sqlite"
+	case SQLServer:
+		return "sqlserver"
+	}
+	return "Dialect(" + strconv.Itoa(int(d)) + ")"
+}
+
+// SQLLiteral renders n as a SQL literal for dialect d: NULL when null,
+// otherwise a correctly quoted and escaped literal. It is meant for debug
+// logging and seed files; queries should still use placeholders.
+//
+// Values implementing driver.Valuer are rendered from their Value result.
+func (n Nullable[T]) SQLLiteral(d Dialect) string {
+	if !n.Valid {
+		return "NULL"
+	}
+	return sqlLiteral(any(n.V), d)
+}
+
+func sqlLiteral(v any, d Dialect) string {
+	switch x := v.(type) {
+	case nil:
+		return "NULL"
+	case Decimal:
+		return x.String()
+	case time.Time:
+		return quoteSQL(formatSQLTime(x, d), d)
+	case []byte:
+		if x == nil {
+			return "NULL"
+		}
+		return bytesLiteral(x, d)
+	case driver.Valuer:
+		dv, err := x.Value()
+		if err != nil {
+			return "NULL"
+		}
+		return sqlLiteral(dv, d)
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return "NULL"
+		}
+		return sqlLiteral(rv.Elem().Interface(), d)
+	case reflect.String:
+		return quoteSQL(rv.String(), d)
+	case reflect.Bool:
+		switch {
+		case d == SQLite || d == SQLServer:
+			if rv.Bool() {
+				return "1"
+			}
+			return "0"
+		case rv.Bool():
+			return "TRUE"
+		}
+		return "FALSE"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rv.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		switch {
+		case math.IsNaN(f):
+			return quoteSQL("NaN", d)
+		case math.IsInf(f, 1):
+			return quoteSQL("Infinity", d)
+		case math.IsInf(f, -1):
+			return quoteSQL("-Infinity", d)
+		}
+		return strconv.FormatFloat(f, 'g', -1, rv.Type().Bits())
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return sqlLiteral(rv.Bytes(), d)
+		}
+	}
+	if s, ok := v.(fmt.Stringer); ok {
+		return quoteSQL(s.String(), d)
+	}
+	return quoteSQL(fmt.Sprint(v), d)
+}
+
+// quoteSQL quotes s as a string literal. Single quotes are doubled in every
+// dialect; MySQL also treats backslash as an escape character, and SQL
+// Server needs the N prefix for non-ASCII text.
+func quoteSQL(s string, d Dialect) string {
+	var b strings.Builder
+	if d == SQLServer && !isASCII(s) {
+		b.WriteByte('N')
+	}
+	b.WriteByte('\'')
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\'':
+			b.WriteString("''")
+		case c == '\\' && d == MySQL:
+			b.WriteString(`\\`)
+		case c == 0 && d == MySQL:
+			b.WriteString(`\0`)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteByte('\'')
+	return b.String()
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
+
+func bytesLiteral(b []byte, d Dialect) string {
+	h := hex.EncodeToString(b)
+	switch d {
+	case Postgres:
+		return `'\x` + h + `'`
+	case SQLServer:
+		return "0x" + h
+	}
+	return "X'" + h + "'"
+}
+
+func formatSQLTime(t time.Time, d Dialect) string {
+	switch d {
+	case MySQL:
+		return t.Format("2006-01-02 15:04:05.999999")
+	case SQLServer:
+		return t.Format("2006-01-02T15:04:05.9999999Z07:00")
+	}
+	return t.Format("2006-01-02 15:04:05.999999999Z07:00")
+}
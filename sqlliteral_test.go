@@ -0,0 +1,69 @@
+package nullable
+
+import (
+	"math"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestSQLLiteral(t *testing.T) {
+	ts := time.Date(2024, 3, 5, 10, 30, 0, 500000000, time.FixedZone("", 3600))
+	tests := []struct {
+		name string
+		lit  func(Dialect) string
+		want map[Dialect]string
+	}{
+		{"null", NewNull[string]().SQLLiteral, map[Dialect]string{Postgres: "NULL", MySQL: "NULL", SQLite: "NULL", SQLServer: "NULL"}},
+		{"string", NewNullable(`O'Brien \ co`).SQLLiteral, map[Dialect]string{
+			Postgres:  `'O''Brien \ co'`,
+			MySQL:     `'O''Brien \\ co'`,
+			SQLite:    `'O''Brien \ co'`,
+			SQLServer: `'O''Brien \ co'`,
+		}},
+		{"unicode", NewNullable("café").SQLLiteral, map[Dialect]string{Postgres: "'café'", SQLServer: "N'café'"}},
+		{"bool", NewNullable(true).SQLLiteral, map[Dialect]string{Postgres: "TRUE", MySQL: "TRUE", SQLite: "1", SQLServer: "1"}},
+		{"false", NewNullable(false).SQLLiteral, map[Dialect]string{Postgres: "FALSE", SQLServer: "0"}},
+		{"int", NewNullable(-42).SQLLiteral, map[Dialect]string{Postgres: "-42", MySQL: "-42"}},
+		{"uint", NewNullable(uint64(math.MaxUint64)).SQLLiteral, map[Dialect]string{SQLite: "18446744073709551615"}},
+		{"float", NewNullable(1.25).SQLLiteral, map[Dialect]string{Postgres: "1.25"}},
+		{"nan", NewNullable(math.NaN()).SQLLiteral, map[Dialect]string{Postgres: "'NaN'"}},
+		{"inf", NewNullable(math.Inf(-1)).SQLLiteral, map[Dialect]string{Postgres: "'-Infinity'"}},
+		{"bytes", NewNullable([]byte{0xde, 0xad}).SQLLiteral, map[Dialect]string{
+			Postgres:  `'\xdead'`,
+			MySQL:     "X'dead'",
+			SQLite:    "X'dead'",
+			SQLServer: "0xdead",
+		}},
+		{"time", NewNullable(ts).SQLLiteral, map[Dialect]string{
+			Postgres:  "'2024-03-05 10:30:00.5+01:00'",
+			MySQL:     "'2024-03-05 10:30:00.5'",
+			SQLite:    "'2024-03-05 10:30:00.5+01:00'",
+			SQLServer: "'2024-03-05T10:30:00.5+01:00'",
+		}},
+		{"decimal", NewNullable(MustParseDecimal("99.990")).SQLLiteral, map[Dialect]string{Postgres: "99.990"}},
+		{"valuer", NewNullable(NewHardwareAddr(net.HardwareAddr{0, 1, 2, 3, 4, 5})).SQLLiteral, map[Dialect]string{Postgres: "'00:01:02:03:04:05'"}},
+		{"null valuer", NewNullable(HardwareAddr{}).SQLLiteral, map[Dialect]string{Postgres: "NULL"}},
+		{"pointer", NewNullable(new(int)).SQLLiteral, map[Dialect]string{MySQL: "0"}},
+		{"nil pointer", NewNullable((*int)(nil)).SQLLiteral, map[Dialect]string{MySQL: "NULL"}},
+	}
+	for _, tt := range tests {
+		for d, want := range tt.want {
+			if got := tt.lit(d); got != want {
+				t.Errorf("%s/%s: Expected %s, got %s", tt.name, d, want, got)
+			}
+		}
+	}
+}
+
+func TestSQLLiteralMySQLNul(t *testing.T) {
+	if got := NewNullable("a\x00b").SQLLiteral(MySQL); got != `'a\0b'` {
+		t.Errorf(`Expected 'a\0b', got %s`, got)
+	}
+}
+
+func TestDialectString(t *testing.T) {
+	if Postgres.String() != "postgres" || SQLServer.String() != "sqlserver" || Dialect(9).String() != "Dialect(9)" {
+		t.Errorf("Unexpected dialect names: %s %s %s", Postgres, SQLServer, Dialect(9))
+	}
+}
@@ -0,0 +1,145 @@
+package nullable
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/manattan/nullable/internal/nullreflect"
+)
+
+// Named time formats accepted by the nullable:"format=..." struct tag.
+var timeFormats = map[string]string{
+	"date":        time.DateOnly,
+	"datetime":    time.DateTime,
+	"time":        time.TimeOnly,
+	"rfc3339":     time.RFC3339,
+	"rfc3339nano": time.RFC3339Nano,
+	"unix":        "unix",
+	"unixmilli":   "unixmilli",
+}
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// parseNullableTag parses a nullable struct tag into f. Options are separated
+// by commas; since layouts may contain commas, layout= must be the last
+// option.
+func parseNullableTag(tag string, f *structField) error {
+	for tag != "" {
+		if rest, ok := strings.CutPrefix(tag, "layout="); ok {
+			f.timeLayout = rest
+			break
+		}
+		var opt string
+		opt, tag, _ = strings.Cut(tag, ",")
+		key, value, _ := strings.Cut(opt, "=")
+		switch key {
+		case "format":
+			layout, ok := timeFormats[value]
+			if !ok {
+				return fmt.Errorf("nullable: unknown time format %q on field %s", value, f.name)
+			}
+			f.timeLayout = layout
+		}
+	}
+	if f.timeLayout != "" && !isTimeValued(f.typ) {
+		return fmt.Errorf("nullable: time layout on non-time field %s of type %s", f.name, f.typ)
+	}
+	return nil
+}
+
+// isTimeValued reports whether t is time.Time, *time.Time or
+// Nullable[time.Time].
+func isTimeValued(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if nullreflect.IsNullable(t) {
+		f, _ := t.FieldByName("V")
+		t = f.Type
+	}
+	return t == timeType
+}
+
+// appendTime appends t to buf formatted with layout.
+func appendTime(buf []byte, t time.Time, layout string) []byte {
+	switch layout {
+	case "unix":
+		return strconv.AppendInt(buf, t.Unix(), 10)
+	case "unixmilli":
+		return strconv.AppendInt(buf, t.UnixMilli(), 10)
+	}
+	buf = append(buf, '"')
+	buf = t.AppendFormat(buf, layout)
+	return append(buf, '"')
+}
+
+// parseTime parses the non-null JSON value data with layout.
+func parseTime(data []byte, layout string) (time.Time, error) {
+	switch layout {
+	case "unix":
+		return parseEpoch(string(data), time.Second)
+	case "unixmilli":
+		return parseEpoch(string(data), time.Millisecond)
+	}
+	s, err := strconv.Unquote(string(data))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("nullable: expected time string, got %s", data)
+	}
+	return time.Parse(layout, s)
+}
+
+// encodeTimeField appends the time-valued field v formatted with layout.
+func encodeTimeField(buf []byte, v reflect.Value, layout string, o *encodeOptions) []byte {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return append(buf, "null"...)
+		}
+		v = v.Elem()
+	}
+	if nullreflect.IsNullable(v.Type()) {
+		if !nullreflect.Valid(v) {
+			return append(buf, "null"...)
+		}
+		v = nullreflect.Inner(v)
+	}
+	t := v.Interface().(time.Time)
+	if o.zeroTimeAsNull && t.IsZero() {
+		return append(buf, "null"...)
+	}
+	return appendTime(buf, t, layout)
+}
+
+// decodeTimeField decodes data into the time-valued field v with layout.
+func decodeTimeField(data []byte, v reflect.Value, layout string, o *decodeOptions) error {
+	if v.Kind() == reflect.Pointer {
+		if isNull(data) {
+			v.SetZero()
+			return nil
+		}
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+	nullable := nullreflect.IsNullable(v.Type())
+	if isNull(data) {
+		if nullable {
+			v.SetZero()
+			v.FieldByName("Valid").SetBool(o.nullAsZero)
+		}
+		return nil
+	}
+	t, err := parseTime(data, layout)
+	if err != nil {
+		return err
+	}
+	if nullable {
+		v.FieldByName("Valid").SetBool(true)
+		v = nullreflect.Inner(v)
+	}
+	v.Set(reflect.ValueOf(t))
+	return nil
+}
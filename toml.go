@@ -0,0 +1,99 @@
+package nullable
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+
+	"github.com/manattan/nullable/internal/nullreflect"
+)
+
+// UnmarshalTOML implements the Unmarshaler interface of
+// github.com/BurntSushi/toml, which passes the decoded TOML value: a
+// string, int64, float64, bool, time.Time, []any or map[string]any. TOML
+// has no null, so a present key always makes n valid and a missing one
+// leaves it unchanged; encoding relies on MarshalText, and fields tagged
+// omitempty leave null values out. Strings are parsed as text for
+// non-string values, so that the quoted values MarshalText produces read
+// back. On error n is left unchanged.
+//
+// github.com/pelletier/go-toml/v2 decodes through UnmarshalText instead,
+// which it does not call for TOML datetimes; quote those for
+// Nullable[time.Time] fields.
+func (n *Nullable[T]) UnmarshalTOML(value any) error {
+	var v T
+	if err := unmarshalTOMLValue(reflect.ValueOf(&v).Elem(), value); err != nil {
+		return err
+	}
+	n.V, n.Valid = v, true
+	return nil
+}
+
+func unmarshalTOMLValue(v reflect.Value, value any) error {
+	if u, ok := v.Addr().Interface().(interface{ UnmarshalTOML(any) error }); ok {
+		return u.UnmarshalTOML(value)
+	}
+	if s, ok := value.(string); ok && v.Kind() != reflect.String {
+		return parseText(v, s)
+	}
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		switch value.(type) {
+		case int64, float64, bool:
+			return u.UnmarshalText(fmt.Append(nil, value))
+		}
+	}
+	if err := nullreflect.Assign(v, reflect.ValueOf(value)); err != nil {
+		return fmt.Errorf("nullable: toml: %w", err)
+	}
+	return nil
+}
+
+// UnmarshalTOML implements the BurntSushi/toml Unmarshaler interface like
+// Nullable.UnmarshalTOML, rejecting invalid addresses.
+func (e *Email) UnmarshalTOML(value any) error {
+	return decodeChecked(&e.Nullable, validateEmail, func(dst *Nullable[string]) error {
+		return dst.UnmarshalTOML(value)
+	})
+}
+
+// UnmarshalTOML implements the BurntSushi/toml Unmarshaler interface like
+// Nullable.UnmarshalTOML, rejecting numbers that are not E.164.
+func (p *Phone) UnmarshalTOML(value any) error {
+	return decodeChecked(&p.Nullable, validatePhone, func(dst *Nullable[string]) error {
+		return dst.UnmarshalTOML(value)
+	})
+}
+
+// UnmarshalTOML implements the BurntSushi/toml Unmarshaler interface like
+// Nullable.UnmarshalTOML, rejecting negative values.
+func (n *NonNegative[T]) UnmarshalTOML(value any) error {
+	return decodeChecked(&n.Nullable, validateNonNegative[T], func(dst *Nullable[T]) error {
+		return dst.UnmarshalTOML(value)
+	})
+}
+
+// UnmarshalTOML implements the BurntSushi/toml Unmarshaler interface like
+// Nullable.UnmarshalTOML, rejecting values that are not positive.
+func (n *Positive[T]) UnmarshalTOML(value any) error {
+	return decodeChecked(&n.Nullable, validatePositive[T], func(dst *Nullable[T]) error {
+		return dst.UnmarshalTOML(value)
+	})
+}
+
+// UnmarshalTOML implements the BurntSushi/toml Unmarshaler interface like
+// Nullable.UnmarshalTOML, rejecting values outside 0 to 100.
+func (n *Percentage[T]) UnmarshalTOML(value any) error {
+	return decodeChecked(&n.Nullable, validatePercentage[T], func(dst *Nullable[T]) error {
+		return dst.UnmarshalTOML(value)
+	})
+}
+
+// UnmarshalTOML implements the BurntSushi/toml Unmarshaler interface like
+// Nullable.UnmarshalTOML, marking o present.
+func (o *Omittable[T]) UnmarshalTOML(value any) error {
+	if err := o.Nullable.UnmarshalTOML(value); err != nil {
+		return err
+	}
+	o.present = true
+	return nil
+}
@@ -0,0 +1,201 @@
+package nullable
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	gotoml "github.com/pelletier/go-toml/v2"
+)
+
+type tomlConfig struct {
+	Name    Nullable[string]    `toml:"name,omitempty"`
+	Port    Nullable[int]       `toml:"port,omitempty"`
+	Ratio   Nullable[float64]   `toml:"ratio,omitempty"`
+	Debug   Nullable[bool]      `toml:"debug,omitempty"`
+	Started Nullable[time.Time] `toml:"started,omitempty"`
+	Limit   Nullable[Decimal]   `toml:"limit,omitempty"`
+}
+
+const tomlInput = `
+name = ""
+port = 8080
+ratio = 0.5
+debug = true
+started = 2024-01-02T03:04:05Z
+limit = 10.25
+`
+
+func checkTOMLConfig(t *testing.T, lib string, c tomlConfig) {
+	t.Helper()
+	started := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	if c.Port != NewNullable(8080) || c.Ratio != NewNullable(0.5) || c.Debug != NewNullable(true) {
+		t.Errorf("%s: Expected port, ratio and debug, got %+v", lib, c)
+	}
+	if !c.Started.Valid || !c.Started.V.Equal(started) {
+		t.Errorf("%s: Expected %v, got %v", lib, started, c.Started)
+	}
+	if !c.Limit.Valid || c.Limit.V.String() != "10.25" {
+		t.Errorf("%s: Expected 10.25, got %v", lib, c.Limit)
+	}
+}
+
+func TestUnmarshalTOML(t *testing.T) {
+	var c tomlConfig
+	if _, err := toml.Decode(tomlInput, &c); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	checkTOMLConfig(t, "BurntSushi", c)
+	if c.Name != NewNullable("") {
+		t.Errorf("Expected empty string to be valid, got %v", c.Name)
+	}
+
+	var missing tomlConfig
+	if _, err := toml.Decode(`port = 1`, &missing); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if missing.Name.Valid || missing.Debug.Valid {
+		t.Errorf("Expected missing keys to stay null, got %+v", missing)
+	}
+
+	var bad tomlConfig
+	if _, err := toml.Decode(`port = "x"`, &bad); err == nil {
+		t.Error("Expected error for invalid port")
+	}
+	n := NewNullable(3)
+	if err := n.UnmarshalTOML([]any{int64(1)}); err == nil || n != NewNullable(3) {
+		t.Errorf("Expected error and unchanged value, got %v (%v)", n, err)
+	}
+}
+
+func TestGoTOML(t *testing.T) {
+	// go-toml only hands string, integer, float and boolean values to
+	// UnmarshalText, so datetimes must be quoted.
+	input := strings.Replace(tomlInput, "2024-01-02T03:04:05Z", `"2024-01-02T03:04:05Z"`, 1)
+	var c tomlConfig
+	if err := gotoml.Unmarshal([]byte(input), &c); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	checkTOMLConfig(t, "go-toml", c)
+}
+
+func TestMarshalTOML(t *testing.T) {
+	in := tomlConfig{Port: NewNullable(8080), Debug: NewNullable(false)}
+
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.Contains(buf.String(), "name") || !strings.Contains(buf.String(), "port") {
+		t.Errorf("Expected only valid fields, got %q", buf.String())
+	}
+	var out tomlConfig
+	if _, err := toml.Decode(buf.String(), &out); err != nil || out != in {
+		t.Errorf("Expected %+v, got %+v (%v)", in, out, err)
+	}
+
+	data, err := gotoml.Marshal(in)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	out = tomlConfig{}
+	if err := gotoml.Unmarshal(data, &out); err != nil || out != in {
+		t.Errorf("Expected %+v, got %+v (%v)", in, out, err)
+	}
+}
+
+func TestMarshalText(t *testing.T) {
+	text, err := NewNullable(42).MarshalText()
+	if err != nil || string(text) != "42" {
+		t.Errorf("Expected 42, got %q (%v)", text, err)
+	}
+	if text, _ := NewNull[int]().MarshalText(); text == nil || len(text) != 0 {
+		t.Errorf("Expected empty non-nil text, got %#v", text)
+	}
+
+	var n Nullable[int]
+	if err := n.UnmarshalText([]byte("42")); err != nil || n != NewNullable(42) {
+		t.Errorf("Expected 42, got %v (%v)", n, err)
+	}
+	if err := n.UnmarshalText(nil); err != nil || n.Valid {
+		t.Errorf("Expected null, got %v (%v)", n, err)
+	}
+	n = NewNullable(1)
+	if err := n.UnmarshalText([]byte("x")); err == nil || n != NewNullable(1) {
+		t.Errorf("Expected error and unchanged value, got %v (%v)", n, err)
+	}
+}
+
+func TestValidatedTOML(t *testing.T) {
+	type config struct {
+		Email    Email               `toml:"email"`
+		Phone    Phone               `toml:"phone"`
+		Workers  Positive[int]       `toml:"workers"`
+		Retries  NonNegative[int]    `toml:"retries"`
+		Sampling Percentage[float64] `toml:"sampling"`
+	}
+	const in = `
+email = "ada@example.com"
+phone = "+14155550123"
+workers = 4
+retries = 0
+sampling = 12.5
+`
+	var c config
+	if _, err := toml.Decode(in, &c); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if c.Email.V != "ada@example.com" || c.Phone.V != "+14155550123" || c.Workers.V != 4 ||
+		!c.Retries.Valid || c.Sampling.V != 12.5 {
+		t.Errorf("Unexpected decode: %+v", c)
+	}
+
+	// Neither library wraps decoder errors, so only the message survives.
+	for _, in := range []string{
+		`email = "not-an-email"`,
+		`phone = "555"`,
+		`workers = 0`,
+		`retries = -1`,
+		`sampling = 101.0`,
+	} {
+		if _, err := toml.Decode(in, &c); err == nil || !strings.Contains(err.Error(), "nullable: invalid") {
+			t.Errorf("%s: Expected validation error, got %v", in, err)
+		}
+		if err := gotoml.Unmarshal([]byte(in), &c); err == nil || !strings.Contains(err.Error(), "nullable: invalid") {
+			t.Errorf("go-toml %s: Expected validation error, got %v", in, err)
+		}
+	}
+	if c.Email.V != "ada@example.com" || c.Workers.V != 4 || c.Sampling.V != 12.5 {
+		t.Errorf("Expected rejected values to leave fields unchanged, got %+v", c)
+	}
+
+	var e Email
+	if err := e.UnmarshalText([]byte("not-an-email")); err == nil || e.Valid {
+		t.Errorf("Expected error and null email, got %v (%v)", e, err)
+	}
+	if err := e.UnmarshalText(nil); err != nil || e.Valid {
+		t.Errorf("Expected empty text to decode as null, got %v (%v)", e, err)
+	}
+}
+
+func TestOmittableTOML(t *testing.T) {
+	var c struct {
+		Name  Omittable[string] `toml:"name"`
+		Port  Omittable[int]    `toml:"port"`
+		Debug Omittable[bool]   `toml:"debug"`
+	}
+	if _, err := toml.Decode(`name = "svc"`, &c); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !c.Name.IsPresent() || c.Name.V != "svc" || c.Port.IsPresent() {
+		t.Errorf("Expected only name present, got %+v", c)
+	}
+	if err := gotoml.Unmarshal([]byte(`port = 8080`), &c); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !c.Port.IsPresent() || c.Port.V != 8080 || c.Debug.IsPresent() {
+		t.Errorf("Expected port present, got %+v", c)
+	}
+}
@@ -0,0 +1,61 @@
+package nullable
+
+// Tracked is a Nullable that records whether it was assigned with Set or
+// SetNull, for building partial UPDATE statements from the fields a request
+// handler actually touched:
+//
+//	u.Name.Set("Ada")
+//	if u.Name.Dirty() {
+//		setClauses = append(setClauses, "name = ?")
+//	}
+//
+// Only Set, SetNull and an initializing GetOrInit mark a Tracked dirty, even
+// when the value does not change; Scan and UnmarshalJSON load a value without marking it, and so do
+// direct writes to V and Valid. Use Audited to detect changes by value
+// instead. The zero value is null and clean.
+type Tracked[T any] struct {
+	Nullable[T]
+	dirty bool
+}
+
+// NewTracked returns a clean Tracked holding n.
+func NewTracked[T any](n Nullable[T]) Tracked[T] {
+	return Tracked[T]{Nullable: n}
+}
+
+// Set makes t valid with value v and marks it dirty.
+func (t *Tracked[T]) Set(v T) {
+	t.Nullable.Set(v)
+	t.dirty = true
+}
+
+// SetNull makes t null and marks it dirty.
+func (t *Tracked[T]) SetNull() {
+	t.Nullable.SetNull()
+	t.dirty = true
+}
+
+// GetOrInit returns the value, first setting it to f() and marking t dirty
+// if it is null.
+func (t *Tracked[T]) GetOrInit(f func() T) T {
+	if !t.Valid {
+		t.Set(f())
+	}
+	return t.V
+}
+
+// Reset makes t null and clean again, like its zero value.
+func (t *Tracked[T]) Reset() {
+	*t = Tracked[T]{}
+}
+
+// Dirty reports whether Set or SetNull was called since t was created or
+// ResetDirty was last called.
+func (t Tracked[T]) Dirty() bool {
+	return t.dirty
+}
+
+// ResetDirty marks t clean, typically after its value has been saved.
+func (t *Tracked[T]) ResetDirty() {
+	t.dirty = false
+}
@@ -0,0 +1,59 @@
+package nullable
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestTracked(t *testing.T) {
+	tr := NewTracked(NewNullable("Ada"))
+	if tr.Dirty() {
+		t.Error("Expected a new Tracked to be clean")
+	}
+
+	tr.Set("Ada")
+	if !tr.Dirty() || tr.Nullable != NewNullable("Ada") {
+		t.Errorf("Expected dirty Ada, got %+v", tr)
+	}
+	tr.ResetDirty()
+	if tr.Dirty() {
+		t.Error("Expected clean after ResetDirty")
+	}
+
+	tr.SetNull()
+	if !tr.Dirty() || tr.Valid {
+		t.Errorf("Expected dirty null, got %+v", tr)
+	}
+
+	tr.Reset()
+	if tr.Dirty() || tr.Valid || tr.V != "" {
+		t.Errorf("Expected clean null after Reset, got %+v", tr)
+	}
+
+	if v := tr.GetOrInit(func() string { return "Grace" }); v != "Grace" || !tr.Dirty() || !tr.Valid {
+		t.Errorf("Expected dirty Grace after GetOrInit, got %+v", tr)
+	}
+	tr.ResetDirty()
+	if v := tr.GetOrInit(func() string { return "Ada" }); v != "Grace" || tr.Dirty() {
+		t.Errorf("Expected GetOrInit on a valid value to stay clean, got %+v", tr)
+	}
+}
+
+func TestTrackedLoad(t *testing.T) {
+	var row struct {
+		Name Tracked[string] `json:"name"`
+	}
+	if err := json.Unmarshal([]byte(`{"name":"Ada"}`), &row); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if row.Name.Dirty() || row.Name.V != "Ada" {
+		t.Errorf("Expected clean Ada after decoding, got %+v", row.Name)
+	}
+	if err := row.Name.Scan("Grace"); err != nil || row.Name.Dirty() || row.Name.V != "Grace" {
+		t.Errorf("Expected clean Grace after scanning, got %+v (%v)", row.Name, err)
+	}
+	data, _ := json.Marshal(row)
+	if string(data) != `{"name":"Grace"}` {
+		t.Errorf(`Expected {"name":"Grace"}, got %s`, data)
+	}
+}
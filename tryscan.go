@@ -0,0 +1,91 @@
+package nullable
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrScanTypeMismatch is wrapped by a ScanError when the source value's type
+// does not exactly match the target type.
+var ErrScanTypeMismatch = errors.New("type mismatch")
+
+// ScanError describes a failed TryScan.
+type ScanError struct {
+	// Column is the column name, if known.
+	Column string
+	// Src is the value returned by the driver.
+	Src any
+	// Target is the type of the Nullable being scanned into.
+	Target reflect.Type
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *ScanError) Error() string {
+	column := ""
+	if e.Column != "" {
+		column = fmt.Sprintf(" column %q", e.Column)
+	}
+	return fmt.Sprintf("nullable: scanning%s: cannot store %T (%v) into %s: %v", column, e.Src, e.Src, e.Target, e.Err)
+}
+
+func (e *ScanError) Unwrap() error {
+	return e.Err
+}
+
+// TryScan scans src into n without any of the implicit conversions of Scan:
+// src must have exactly the type T, or a type with the same underlying kind
+// that converts to T (such as string into a defined string type). If T
+// implements sql.Scanner through a pointer, its Scan method is used. Errors
+// are *ScanError values naming the source and target types.
+func (n *Nullable[T]) TryScan(src any) error {
+	return n.tryScan("", src)
+}
+
+// TryScanner returns an sql.Scanner calling TryScan on n, recording column
+// in errors, for use with Rows.Scan.
+func TryScanner[T any](n *Nullable[T], column string) sql.Scanner {
+	return scannerFunc(func(src any) error {
+		return n.tryScan(column, src)
+	})
+}
+
+func (n *Nullable[T]) tryScan(column string, src any) error {
+	var zero T
+	if src == nil {
+		n.V, n.Valid = zero, false
+		return nil
+	}
+	fail := func(err error) error {
+		n.V, n.Valid = zero, false
+		return &ScanError{Column: column, Src: src, Target: reflect.TypeOf(n).Elem(), Err: err}
+	}
+
+	if s, ok := any(&n.V).(sql.Scanner); ok {
+		if err := s.Scan(src); err != nil {
+			return fail(err)
+		}
+		n.Valid = true
+		return nil
+	}
+
+	target := reflect.TypeFor[T]()
+	sv := reflect.ValueOf(src)
+	switch {
+	case sv.Type() == target:
+	case sv.Kind() == target.Kind() && sv.Type().ConvertibleTo(target):
+		sv = sv.Convert(target)
+	default:
+		return fail(ErrScanTypeMismatch)
+	}
+
+	if sv.Kind() == reflect.Slice && sv.Type().Elem().Kind() == reflect.Uint8 {
+		// Drivers may reuse the buffer after Scan returns.
+		sv = reflect.ValueOf(bytes.Clone(sv.Bytes())).Convert(target)
+	}
+	n.V, n.Valid = sv.Interface().(T), true
+	return nil
+}
@@ -0,0 +1,88 @@
+package nullable
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestTryScan(t *testing.T) {
+	var s Nullable[string]
+	if err := s.TryScan("x"); err != nil || !s.Valid || s.V != "x" {
+		t.Errorf("Expected 'x', got %+v (%v)", s, err)
+	}
+	if err := s.TryScan(nil); err != nil || s.Valid {
+		t.Errorf("Expected null, got %+v (%v)", s, err)
+	}
+
+	var status Nullable[scanStatus]
+	if err := status.TryScan("active"); err != nil || status.V != "active" {
+		t.Errorf("Expected 'active', got %+v (%v)", status, err)
+	}
+
+	var d Nullable[Decimal]
+	if err := d.TryScan([]byte("1.5")); err != nil || d.V.String() != "1.5" {
+		t.Errorf("Expected scanner to be used, got %+v (%v)", d, err)
+	}
+
+	var b Nullable[[]byte]
+	buf := []byte("abc")
+	if err := b.TryScan(buf); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	buf[0] = 'z'
+	if string(b.V) != "abc" {
+		t.Errorf("Expected scanned bytes to be copied, got %q", b.V)
+	}
+
+	var raw Nullable[json.RawMessage]
+	buf = []byte(`{"a":1}`)
+	if err := raw.TryScan(buf); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	buf[0] = 'z'
+	if string(raw.V) != `{"a":1}` {
+		t.Errorf("Expected scanned json.RawMessage to be copied, got %q", raw.V)
+	}
+}
+
+func TestTryScanMismatch(t *testing.T) {
+	tests := []struct {
+		name string
+		scan func() error
+	}{
+		{"bytes into string", func() error { var n Nullable[string]; return n.TryScan([]byte("x")) }},
+		{"int64 into int32", func() error { var n Nullable[int32]; return n.TryScan(int64(1)) }},
+		{"string into int64", func() error { var n Nullable[int64]; return n.TryScan("1") }},
+		{"int64 into bool", func() error { var n Nullable[bool]; return n.TryScan(int64(1)) }},
+	}
+	for _, tt := range tests {
+		err := tt.scan()
+		if !errors.Is(err, ErrScanTypeMismatch) {
+			t.Errorf("%s: Expected ErrScanTypeMismatch, got %v", tt.name, err)
+		}
+	}
+
+	var n Nullable[int32]
+	n.V, n.Valid = 3, true
+	err := TryScanner(&n, "age").Scan(int64(70000))
+	var scanErr *ScanError
+	if !errors.As(err, &scanErr) {
+		t.Fatalf("Expected *ScanError, got %T", err)
+	}
+	msg := err.Error()
+	for _, want := range []string{`column "age"`, "int64 (70000)", "nullable.Nullable[int32]"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("Expected %q in %q", want, msg)
+		}
+	}
+	if n.Valid || n.V != 0 {
+		t.Errorf("Expected null after failure, got %+v", n)
+	}
+
+	var d Nullable[Decimal]
+	if err := d.TryScan(true); !errors.As(err, &scanErr) || errors.Is(err, ErrScanTypeMismatch) {
+		t.Errorf("Expected wrapped scanner error, got %v", err)
+	}
+}
@@ -0,0 +1,152 @@
+package nullable
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// ULID is a nullable ULID encoded in JSON as its 26-character Crockford
+// base32 string and stored in SQL as the same text. Use ULIDBytes for
+// BINARY(16) and BYTEA columns. Because ULIDs sort by creation time,
+// Compare and Less order them lexicographically, which matches the order
+// of their string and byte forms.
+type ULID struct {
+	Nullable[ulid.ULID]
+}
+
+// NewULID creates a valid ULID.
+func NewULID(id ulid.ULID) ULID {
+	return ULID{NewNullable(id)}
+}
+
+// ParseULID parses the 26-character string form of a ULID, rejecting
+// invalid characters. The empty string yields a null ULID.
+func ParseULID(s string) (ULID, error) {
+	if s == "" {
+		return ULID{}, nil
+	}
+	id, err := ulid.ParseStrict(s)
+	if err != nil {
+		return ULID{}, fmt.Errorf("nullable: invalid ULID %q: %w", s, err)
+	}
+	return NewULID(id), nil
+}
+
+// String returns the canonical string form of the ULID, or "null".
+func (u ULID) String() string {
+	if !u.Valid {
+		return "null"
+	}
+	return u.V.String()
+}
+
+// Time returns the timestamp encoded in the ULID, or null.
+func (u ULID) Time() Nullable[time.Time] {
+	if !u.Valid {
+		return NewNull[time.Time]()
+	}
+	return NewNullable(ulid.Time(u.V.Time()))
+}
+
+// Compare compares u and other by their bytes, returning -1, 0 or +1. A
+// null ULID sorts before every valid one.
+func (u ULID) Compare(other ULID) int {
+	switch {
+	case !u.Valid && !other.Valid:
+		return 0
+	case !u.Valid:
+		return -1
+	case !other.Valid:
+		return 1
+	}
+	return u.V.Compare(other.V)
+}
+
+// Less reports whether u sorts before other.
+func (u ULID) Less(other ULID) bool {
+	return u.Compare(other) < 0
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (u ULID) MarshalJSON() ([]byte, error) {
+	if !u.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(u.V.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (u *ULID) UnmarshalJSON(data []byte) error {
+	if isNull(data) {
+		u.V, u.Valid = ulid.ULID{}, false
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return u.scanText(s)
+}
+
+// Scan implements the sql.Scanner interface. It accepts the 26-character
+// text form as a string or bytes, and the raw 16-byte form.
+func (u *ULID) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		u.V, u.Valid = ulid.ULID{}, false
+		return nil
+	case string:
+		return u.scanText(v)
+	case []byte:
+		if len(v) == len(u.V) {
+			copy(u.V[:], v)
+			u.Valid = true
+			return nil
+		}
+		return u.scanText(string(v))
+	default:
+		return fmt.Errorf("nullable: cannot scan %T into ULID", value)
+	}
+}
+
+func (u *ULID) scanText(s string) error {
+	id, err := ulid.ParseStrict(s)
+	if err != nil {
+		return fmt.Errorf("nullable: invalid ULID %q: %w", s, err)
+	}
+	u.V, u.Valid = id, true
+	return nil
+}
+
+// Value implements the driver.Valuer interface, returning the ULID text or
+// nil.
+func (u ULID) Value() (driver.Value, error) {
+	if !u.Valid {
+		return nil, nil
+	}
+	return u.V.String(), nil
+}
+
+// ULIDBytes is a ULID stored in SQL as its raw 16 bytes. It encodes JSON
+// and scans exactly like ULID.
+type ULIDBytes struct {
+	ULID
+}
+
+// NewULIDBytes creates a valid ULIDBytes.
+func NewULIDBytes(id ulid.ULID) ULIDBytes {
+	return ULIDBytes{NewULID(id)}
+}
+
+// Value implements the driver.Valuer interface, returning the 16 bytes of
+// the ULID or nil.
+func (u ULIDBytes) Value() (driver.Value, error) {
+	if !u.Valid {
+		return nil, nil
+	}
+	return u.V.Bytes(), nil
+}
@@ -0,0 +1,134 @@
+package nullable
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+func TestULIDJSON(t *testing.T) {
+	type event struct {
+		ID ULID `json:"id"`
+	}
+	var e event
+	if err := json.Unmarshal([]byte(`{"id":"01ARZ3NDEKTSV4RRFFQ69G5FAV"}`), &e); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !e.ID.Valid || e.ID.String() != "01ARZ3NDEKTSV4RRFFQ69G5FAV" {
+		t.Errorf("Expected 01ARZ3NDEKTSV4RRFFQ69G5FAV, got %v", e.ID)
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := `{"id":"01ARZ3NDEKTSV4RRFFQ69G5FAV"}`; string(data) != want {
+		t.Errorf("Expected %s, got %s", want, data)
+	}
+
+	if err := json.Unmarshal([]byte(`{"id":null}`), &e); err != nil || e.ID.Valid {
+		t.Errorf("Expected null, got %+v (%v)", e.ID, err)
+	}
+	data, _ = json.Marshal(e)
+	if want := `{"id":null}`; string(data) != want {
+		t.Errorf("Expected %s, got %s", want, data)
+	}
+
+	if err := json.Unmarshal([]byte(`{"id":"01ARZ3NDEKTSV4RRFFQ69G5FA"}`), &e); err == nil {
+		t.Error("Expected error for short ULID")
+	}
+	if err := json.Unmarshal([]byte(`{"id":"01ARZ3NDEKTSV4RRFFQ69G5FAU"}`), &e); err == nil {
+		t.Error("Expected error for invalid character")
+	}
+}
+
+func TestParseULID(t *testing.T) {
+	u, err := ParseULID("01ARZ3NDEKTSV4RRFFQ69G5FAV")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := time.UnixMilli(1469922850259); !u.Time().Valid || !u.Time().V.Equal(want) {
+		t.Errorf("Expected %v, got %v", want, u.Time())
+	}
+	if u, err := ParseULID(""); err != nil || u.Valid || u.Time().Valid {
+		t.Errorf("Expected null for empty string, got %+v (%v)", u, err)
+	}
+	if _, err := ParseULID("not-a-ulid"); err == nil {
+		t.Error("Expected error for invalid ULID")
+	}
+}
+
+func TestULIDSQL(t *testing.T) {
+	id := ulid.MustParse("01ARZ3NDEKTSV4RRFFQ69G5FAV")
+
+	var u ULID
+	if err := u.Scan("01ARZ3NDEKTSV4RRFFQ69G5FAV"); err != nil || u.V != id {
+		t.Errorf("Expected %s from text, got %v (%v)", id, u, err)
+	}
+	if err := u.Scan(id.Bytes()); err != nil || u.V != id {
+		t.Errorf("Expected %s from bytes, got %v (%v)", id, u, err)
+	}
+	if err := u.Scan([]byte("01ARZ3NDEKTSV4RRFFQ69G5FAV")); err != nil || u.V != id {
+		t.Errorf("Expected %s from text bytes, got %v (%v)", id, u, err)
+	}
+	if v, err := u.Value(); err != nil || v != "01ARZ3NDEKTSV4RRFFQ69G5FAV" {
+		t.Errorf("Expected text value, got %v (%v)", v, err)
+	}
+	if err := u.Scan(nil); err != nil || u.Valid {
+		t.Errorf("Expected null, got %+v (%v)", u, err)
+	}
+	if v, err := u.Value(); err != nil || v != nil {
+		t.Errorf("Expected nil value, got %v (%v)", v, err)
+	}
+	if err := u.Scan([]byte{1, 2, 3}); err == nil {
+		t.Error("Expected error for 3 bytes")
+	}
+	if err := u.Scan(42); err == nil {
+		t.Error("Expected error for int")
+	}
+
+	b := NewULIDBytes(id)
+	v, err := b.Value()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if raw, ok := v.([]byte); !ok || !bytes.Equal(raw, id[:]) {
+		t.Errorf("Expected 16 bytes, got %v", v)
+	}
+	var scanned ULIDBytes
+	if err := scanned.Scan(v); err != nil || scanned.V != id {
+		t.Errorf("Expected %s, got %v (%v)", id, scanned, err)
+	}
+	if v, err := (ULIDBytes{}).Value(); err != nil || v != nil {
+		t.Errorf("Expected nil value, got %v (%v)", v, err)
+	}
+	data, _ := json.Marshal(b)
+	if want := `"01ARZ3NDEKTSV4RRFFQ69G5FAV"`; string(data) != want {
+		t.Errorf("Expected %s, got %s", want, data)
+	}
+}
+
+func TestULIDCompare(t *testing.T) {
+	early := NewULID(ulid.MustNew(1000, bytes.NewReader(make([]byte, 10))))
+	late := NewULID(ulid.MustNew(2000, bytes.NewReader(make([]byte, 10))))
+	null := ULID{}
+
+	if !early.Less(late) || late.Less(early) {
+		t.Errorf("Expected %s < %s", early, late)
+	}
+	if early.Compare(early) != 0 || null.Compare(ULID{}) != 0 {
+		t.Error("Expected equal ULIDs to compare as 0")
+	}
+	if null.Compare(early) != -1 || early.Compare(null) != 1 {
+		t.Error("Expected null to sort first")
+	}
+
+	ids := []ULID{late, null, early}
+	slices.SortFunc(ids, ULID.Compare)
+	if ids[0].Valid || ids[1] != early || ids[2] != late {
+		t.Errorf("Expected [null %s %s], got %v", early, late, ids)
+	}
+}
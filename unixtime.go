@@ -0,0 +1,333 @@
+package nullable
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// UnixTime is a nullable time encoded as a number of seconds since the Unix
+// epoch, for interop with JavaScript-centric APIs. Every encoding Nullable
+// supports uses the number, not only JSON.
+type UnixTime struct {
+	Nullable[time.Time]
+}
+
+// NewUnixTime creates a valid UnixTime.
+func NewUnixTime(t time.Time) UnixTime {
+	return UnixTime{NewNullable(t)}
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (u UnixTime) MarshalJSON() ([]byte, error) {
+	if !u.Valid {
+		return []byte("null"), nil
+	}
+	return strconv.AppendInt(nil, u.V.Unix(), 10), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Fractional
+// seconds are accepted.
+func (u *UnixTime) UnmarshalJSON(data []byte) error {
+	return unmarshalEpoch(&u.Nullable, data, time.Second)
+}
+
+// wire returns u as the epoch seconds it is encoded as.
+func (u UnixTime) wire() Nullable[int64] {
+	return epochWire(u.Nullable, time.Second)
+}
+
+// setWire stores decoded epoch seconds.
+func (u *UnixTime) setWire(n Nullable[int64]) error {
+	u.Nullable = epochNullable(n, time.Second)
+	return nil
+}
+
+// AppendText implements the encoding.TextAppender interface.
+func (u UnixTime) AppendText(b []byte) ([]byte, error) {
+	return u.wire().AppendText(b)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (u UnixTime) MarshalText() ([]byte, error) {
+	return u.wire().MarshalText()
+}
+
+// MarshalXML implements the xml.Marshaler interface.
+func (u UnixTime) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return u.wire().MarshalXML(e, start)
+}
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface.
+func (u UnixTime) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return u.wire().MarshalXMLAttr(name)
+}
+
+// MarshalCBOR implements the cbor.Marshaler interface.
+func (u UnixTime) MarshalCBOR() ([]byte, error) {
+	return u.wire().MarshalCBOR()
+}
+
+// EncodeMsgpack implements the msgpack.CustomEncoder interface.
+func (u UnixTime) EncodeMsgpack(enc *msgpack.Encoder) error {
+	return u.wire().EncodeMsgpack(enc)
+}
+
+// MarshalBSONValue implements the bson.ValueMarshaler interface.
+func (u UnixTime) MarshalBSONValue() (byte, []byte, error) {
+	return u.wire().MarshalBSONValue()
+}
+
+// GobEncode implements the gob.GobEncoder interface.
+func (u UnixTime) GobEncode() ([]byte, error) {
+	return u.wire().GobEncode()
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (u *UnixTime) UnmarshalText(text []byte) error {
+	return decodeWire(u.setWire, func(n *Nullable[int64]) error {
+		return n.UnmarshalText(text)
+	})
+}
+
+// UnmarshalTOML implements the BurntSushi/toml Unmarshaler interface.
+func (u *UnixTime) UnmarshalTOML(value any) error {
+	return decodeWire(u.setWire, func(n *Nullable[int64]) error {
+		return n.UnmarshalTOML(value)
+	})
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface.
+func (u *UnixTime) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return decodeWire(u.setWire, func(n *Nullable[int64]) error {
+		return n.UnmarshalXML(d, start)
+	})
+}
+
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface.
+func (u *UnixTime) UnmarshalXMLAttr(attr xml.Attr) error {
+	return decodeWire(u.setWire, func(n *Nullable[int64]) error {
+		return n.UnmarshalXMLAttr(attr)
+	})
+}
+
+// UnmarshalCBOR implements the cbor.Unmarshaler interface.
+func (u *UnixTime) UnmarshalCBOR(data []byte) error {
+	return decodeWire(u.setWire, func(n *Nullable[int64]) error {
+		return n.UnmarshalCBOR(data)
+	})
+}
+
+// DecodeMsgpack implements the msgpack.CustomDecoder interface.
+func (u *UnixTime) DecodeMsgpack(dec *msgpack.Decoder) error {
+	return decodeWire(u.setWire, func(n *Nullable[int64]) error {
+		return n.DecodeMsgpack(dec)
+	})
+}
+
+// UnmarshalBSONValue implements the bson.ValueUnmarshaler interface.
+func (u *UnixTime) UnmarshalBSONValue(typ byte, data []byte) error {
+	return decodeWire(u.setWire, func(n *Nullable[int64]) error {
+		return n.UnmarshalBSONValue(typ, data)
+	})
+}
+
+// GobDecode implements the gob.GobDecoder interface.
+func (u *UnixTime) GobDecode(data []byte) error {
+	return decodeWire(u.setWire, func(n *Nullable[int64]) error {
+		return n.GobDecode(data)
+	})
+}
+
+// UnixMilliTime is a nullable time encoded as a number of milliseconds since
+// the Unix epoch, in JSON and every other encoding Nullable supports.
+type UnixMilliTime struct {
+	Nullable[time.Time]
+}
+
+// NewUnixMilliTime creates a valid UnixMilliTime.
+func NewUnixMilliTime(t time.Time) UnixMilliTime {
+	return UnixMilliTime{NewNullable(t)}
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (u UnixMilliTime) MarshalJSON() ([]byte, error) {
+	if !u.Valid {
+		return []byte("null"), nil
+	}
+	return strconv.AppendInt(nil, u.V.UnixMilli(), 10), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (u *UnixMilliTime) UnmarshalJSON(data []byte) error {
+	return unmarshalEpoch(&u.Nullable, data, time.Millisecond)
+}
+
+// wire returns u as the epoch milliseconds it is encoded as.
+func (u UnixMilliTime) wire() Nullable[int64] {
+	return epochWire(u.Nullable, time.Millisecond)
+}
+
+// setWire stores decoded epoch milliseconds.
+func (u *UnixMilliTime) setWire(n Nullable[int64]) error {
+	u.Nullable = epochNullable(n, time.Millisecond)
+	return nil
+}
+
+// AppendText implements the encoding.TextAppender interface.
+func (u UnixMilliTime) AppendText(b []byte) ([]byte, error) {
+	return u.wire().AppendText(b)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (u UnixMilliTime) MarshalText() ([]byte, error) {
+	return u.wire().MarshalText()
+}
+
+// MarshalXML implements the xml.Marshaler interface.
+func (u UnixMilliTime) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return u.wire().MarshalXML(e, start)
+}
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface.
+func (u UnixMilliTime) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return u.wire().MarshalXMLAttr(name)
+}
+
+// MarshalCBOR implements the cbor.Marshaler interface.
+func (u UnixMilliTime) MarshalCBOR() ([]byte, error) {
+	return u.wire().MarshalCBOR()
+}
+
+// EncodeMsgpack implements the msgpack.CustomEncoder interface.
+func (u UnixMilliTime) EncodeMsgpack(enc *msgpack.Encoder) error {
+	return u.wire().EncodeMsgpack(enc)
+}
+
+// MarshalBSONValue implements the bson.ValueMarshaler interface.
+func (u UnixMilliTime) MarshalBSONValue() (byte, []byte, error) {
+	return u.wire().MarshalBSONValue()
+}
+
+// GobEncode implements the gob.GobEncoder interface.
+func (u UnixMilliTime) GobEncode() ([]byte, error) {
+	return u.wire().GobEncode()
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (u *UnixMilliTime) UnmarshalText(text []byte) error {
+	return decodeWire(u.setWire, func(n *Nullable[int64]) error {
+		return n.UnmarshalText(text)
+	})
+}
+
+// UnmarshalTOML implements the BurntSushi/toml Unmarshaler interface.
+func (u *UnixMilliTime) UnmarshalTOML(value any) error {
+	return decodeWire(u.setWire, func(n *Nullable[int64]) error {
+		return n.UnmarshalTOML(value)
+	})
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface.
+func (u *UnixMilliTime) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return decodeWire(u.setWire, func(n *Nullable[int64]) error {
+		return n.UnmarshalXML(d, start)
+	})
+}
+
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface.
+func (u *UnixMilliTime) UnmarshalXMLAttr(attr xml.Attr) error {
+	return decodeWire(u.setWire, func(n *Nullable[int64]) error {
+		return n.UnmarshalXMLAttr(attr)
+	})
+}
+
+// UnmarshalCBOR implements the cbor.Unmarshaler interface.
+func (u *UnixMilliTime) UnmarshalCBOR(data []byte) error {
+	return decodeWire(u.setWire, func(n *Nullable[int64]) error {
+		return n.UnmarshalCBOR(data)
+	})
+}
+
+// DecodeMsgpack implements the msgpack.CustomDecoder interface.
+func (u *UnixMilliTime) DecodeMsgpack(dec *msgpack.Decoder) error {
+	return decodeWire(u.setWire, func(n *Nullable[int64]) error {
+		return n.DecodeMsgpack(dec)
+	})
+}
+
+// UnmarshalBSONValue implements the bson.ValueUnmarshaler interface.
+func (u *UnixMilliTime) UnmarshalBSONValue(typ byte, data []byte) error {
+	return decodeWire(u.setWire, func(n *Nullable[int64]) error {
+		return n.UnmarshalBSONValue(typ, data)
+	})
+}
+
+// GobDecode implements the gob.GobDecoder interface.
+func (u *UnixMilliTime) GobDecode(data []byte) error {
+	return decodeWire(u.setWire, func(n *Nullable[int64]) error {
+		return n.GobDecode(data)
+	})
+}
+
+func unmarshalEpoch(n *Nullable[time.Time], data []byte, unit time.Duration) error {
+	if isNull(data) {
+		n.V, n.Valid = time.Time{}, false
+		return nil
+	}
+	var num json.Number
+	if err := json.Unmarshal(data, &num); err != nil {
+		return err
+	}
+	t, err := parseEpoch(num.String(), unit)
+	if err != nil {
+		return err
+	}
+	n.V, n.Valid = t, true
+	return nil
+}
+
+// parseEpoch converts a decimal number of units since the Unix epoch into a
+// UTC time.
+func parseEpoch(s string, unit time.Duration) (time.Time, error) {
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return epochTime(i, unit), nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("nullable: invalid epoch time %q", s)
+	}
+	return time.Unix(0, int64(f*float64(unit))).UTC(), nil
+}
+
+// epochTime converts an integer number of units since the Unix epoch into a
+// UTC time.
+func epochTime(i int64, unit time.Duration) time.Time {
+	if unit == time.Second {
+		return time.Unix(i, 0).UTC()
+	}
+	return time.UnixMilli(i).UTC()
+}
+
+// epochWire converts n into a number of units since the Unix epoch.
+func epochWire(n Nullable[time.Time], unit time.Duration) Nullable[int64] {
+	if !n.Valid {
+		return NewNull[int64]()
+	}
+	if unit == time.Second {
+		return NewNullable(n.V.Unix())
+	}
+	return NewNullable(n.V.UnixMilli())
+}
+
+// epochNullable converts a number of units since the Unix epoch into a time.
+func epochNullable(n Nullable[int64], unit time.Duration) Nullable[time.Time] {
+	if !n.Valid {
+		return NewNull[time.Time]()
+	}
+	return NewNullable(epochTime(n.V, unit))
+}
@@ -0,0 +1,104 @@
+package nullable
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestUnixTimeJSON(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 600_000_000, time.UTC)
+
+	data, err := json.Marshal(NewUnixTime(ts))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(data) != "1704164645" {
+		t.Errorf("Expected 1704164645, got %s", data)
+	}
+
+	var u UnixTime
+	if err := json.Unmarshal([]byte("1704164645.5"), &u); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !u.Valid || !u.V.Equal(ts.Truncate(time.Second).Add(500*time.Millisecond)) {
+		t.Errorf("Unexpected time %v", u.V)
+	}
+
+	if err := json.Unmarshal([]byte("null"), &u); err != nil || u.Valid {
+		t.Errorf("Expected null, got %+v (%v)", u, err)
+	}
+	data, _ = json.Marshal(u)
+	if string(data) != "null" {
+		t.Errorf("Expected null, got %s", data)
+	}
+
+	if err := json.Unmarshal([]byte(`"2024-01-02"`), &u); err == nil {
+		t.Error("Expected error for string input")
+	}
+}
+
+func TestUnixMilliTimeJSON(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 678_000_000, time.UTC)
+
+	data, err := json.Marshal(NewUnixMilliTime(ts))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(data) != "1704164645678" {
+		t.Errorf("Expected 1704164645678, got %s", data)
+	}
+
+	var u UnixMilliTime
+	if err := json.Unmarshal(data, &u); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !u.Valid || !u.V.Equal(ts) {
+		t.Errorf("Expected %v, got %v", ts, u.V)
+	}
+}
+
+func TestUnixTimeCodecs(t *testing.T) {
+	type epochDoc struct {
+		S Nullable[int64] `xml:"s" cbor:"s" msgpack:"s" bson:"s"`
+		M Nullable[int64] `xml:"m" cbor:"m" msgpack:"m" bson:"m"`
+		N Nullable[int64] `xml:"n" cbor:"n" msgpack:"n" bson:"n"`
+	}
+	type unixDoc struct {
+		S UnixTime      `xml:"s" cbor:"s" msgpack:"s" bson:"s"`
+		M UnixMilliTime `xml:"m" cbor:"m" msgpack:"m" bson:"m"`
+		N UnixTime      `xml:"n" cbor:"n" msgpack:"n" bson:"n"`
+	}
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 600_000_000, time.UTC)
+	in := unixDoc{S: NewUnixTime(ts), M: NewUnixMilliTime(ts)}
+	for _, c := range wireCodecs {
+		data, err := c.marshal(in)
+		if err != nil {
+			t.Fatalf("%s: Unexpected error: %v", c.name, err)
+		}
+		var epochs epochDoc
+		if err := c.unmarshal(data, &epochs); err != nil {
+			t.Fatalf("%s: Unexpected error: %v", c.name, err)
+		}
+		if epochs.S != NewNullable(int64(1704164645)) || epochs.M != NewNullable(int64(1704164645600)) || epochs.N.Valid {
+			t.Errorf("%s: Expected epoch numbers, got %+v", c.name, epochs)
+		}
+
+		var out unixDoc
+		if err := c.unmarshal(data, &out); err != nil {
+			t.Fatalf("%s: Unexpected error: %v", c.name, err)
+		}
+		if !out.S.V.Equal(ts.Truncate(time.Second)) || !out.M.V.Equal(ts) || out.N.Valid {
+			t.Errorf("%s: Expected %v, got %+v", c.name, ts, out)
+		}
+	}
+
+	text, err := NewUnixMilliTime(ts).MarshalText()
+	if err != nil || string(text) != "1704164645600" {
+		t.Errorf("Expected 1704164645600, got %s (%v)", text, err)
+	}
+	var u UnixTime
+	if err := u.UnmarshalText([]byte("1704164645")); err != nil || !u.V.Equal(ts.Truncate(time.Second)) {
+		t.Errorf("Expected %v, got %v (%v)", ts.Truncate(time.Second), u.V, err)
+	}
+}
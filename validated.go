@@ -0,0 +1,165 @@
+package nullable
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"reflect"
+	"strings"
+)
+
+// FormatError reports a string that does not have the format required by a
+// validated wrapper type such as Email or Phone.
+type FormatError struct {
+	Kind   string // "email" or "E.164 phone number"
+	Value  string
+	Reason string
+}
+
+func (e *FormatError) Error() string {
+	return fmt.Sprintf("nullable: invalid %s %q: %s", e.Kind, e.Value, e.Reason)
+}
+
+// Email is a nullable email address. NewEmail and the decoding methods
+// reject strings that are not a bare address such as "ada@example.com".
+type Email struct {
+	Nullable[string]
+}
+
+// NewEmail validates s and returns a valid Email, or a *FormatError.
+func NewEmail(s string) (Email, error) {
+	if err := validateEmail(s); err != nil {
+		return Email{}, err
+	}
+	return Email{NewNullable(s)}, nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (e *Email) UnmarshalJSON(data []byte) error {
+	return unmarshalValidated(&e.Nullable, data, validateEmail)
+}
+
+func validateEmail(s string) error {
+	fail := func(reason string) error {
+		return &FormatError{Kind: "email", Value: s, Reason: reason}
+	}
+	local, domain, ok := strings.Cut(s, "@")
+	switch {
+	case s == "":
+		return fail("empty address")
+	case !ok:
+		return fail("missing @")
+	case local == "":
+		return fail("missing local part")
+	case domain == "":
+		return fail("missing domain")
+	case !strings.Contains(domain, ".") || strings.HasPrefix(domain, ".") || strings.HasSuffix(domain, "."):
+		return fail("domain must contain a dot between labels")
+	}
+	addr, err := mail.ParseAddress(s)
+	if err != nil || addr.Address != s || addr.Name != "" {
+		return fail("not a bare address")
+	}
+	return nil
+}
+
+// Phone is a nullable phone number in E.164 format: a "+", a non-zero
+// country code digit and at most 15 digits in total, such as "+14155550123".
+// NewPhone and the decoding methods reject other forms.
+type Phone struct {
+	Nullable[string]
+}
+
+// NewPhone validates s and returns a valid Phone, or a *FormatError.
+func NewPhone(s string) (Phone, error) {
+	if err := validatePhone(s); err != nil {
+		return Phone{}, err
+	}
+	return Phone{NewNullable(s)}, nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (p *Phone) UnmarshalJSON(data []byte) error {
+	return unmarshalValidated(&p.Nullable, data, validatePhone)
+}
+
+func validatePhone(s string) error {
+	fail := func(reason string) error {
+		return &FormatError{Kind: "E.164 phone number", Value: s, Reason: reason}
+	}
+	digits, ok := strings.CutPrefix(s, "+")
+	switch {
+	case !ok:
+		return fail(`must start with "+"`)
+	case digits == "":
+		return fail("missing digits")
+	case digits[0] == '0':
+		return fail("country code cannot start with 0")
+	case len(digits) > 15:
+		return fail("more than 15 digits")
+	case len(digits) < 2:
+		return fail("too short")
+	}
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return fail("must contain only digits after the +")
+		}
+	}
+	return nil
+}
+
+func unmarshalValidated(n *Nullable[string], data []byte, validate func(string) error) error {
+	if isNull(data) {
+		n.V, n.Valid = "", false
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if err := validate(s); err != nil {
+		return err
+	}
+	n.V, n.Valid = s, true
+	return nil
+}
+
+// validator is implemented by the validated and constrained wrapper types,
+// for decoders that fill them field by field, such as DecodeBinary.
+type validator interface {
+	validate() error
+}
+
+var validatorType = reflect.TypeFor[validator]()
+
+func (e Email) validate() error {
+	return validateIfValid(e.Nullable, validateEmail)
+}
+
+func (p Phone) validate() error {
+	return validateIfValid(p.Nullable, validatePhone)
+}
+
+// validateIfValid calls validate on the value of n if it is valid.
+func validateIfValid[T any](n Nullable[T], validate func(T) error) error {
+	if !n.Valid {
+		return nil
+	}
+	return validate(n.V)
+}
+
+// decodeChecked runs decode on a copy of n and stores the result only when
+// it is null or passes validate, so that the decoders the validated and
+// constrained wrappers inherit from Nullable cannot bypass validation. On
+// error n is left unchanged.
+func decodeChecked[T any](n *Nullable[T], validate func(T) error, decode func(*Nullable[T]) error) error {
+	decoded := *n
+	if err := decode(&decoded); err != nil {
+		return err
+	}
+	if err := validateIfValid(decoded, validate); err != nil {
+		return err
+	}
+	*n = decoded
+	return nil
+}
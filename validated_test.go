@@ -0,0 +1,93 @@
+package nullable
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestNewEmail(t *testing.T) {
+	e, err := NewEmail("ada@example.com")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !e.Valid || e.V != "ada@example.com" {
+		t.Errorf("Expected ada@example.com, got %+v", e)
+	}
+
+	for in, reason := range map[string]string{
+		"":                      "empty address",
+		"ada.example.com":       "missing @",
+		"@example.com":          "missing local part",
+		"ada@":                  "missing domain",
+		"ada@localhost":         "domain must contain a dot between labels",
+		"Ada <ada@example.com>": "not a bare address",
+		"a b@example.com":       "not a bare address",
+	} {
+		_, err := NewEmail(in)
+		var fe *FormatError
+		if !errors.As(err, &fe) {
+			t.Errorf("%q: Expected *FormatError, got %v", in, err)
+			continue
+		}
+		if fe.Kind != "email" || fe.Value != in || fe.Reason != reason {
+			t.Errorf("%q: Expected reason %q, got %+v", in, reason, fe)
+		}
+	}
+}
+
+func TestNewPhone(t *testing.T) {
+	if p, err := NewPhone("+14155550123"); err != nil || p.V != "+14155550123" {
+		t.Errorf("Expected +14155550123, got %+v (%v)", p, err)
+	}
+
+	for in, reason := range map[string]string{
+		"14155550123":       `must start with "+"`,
+		"+":                 "missing digits",
+		"+0123":             "country code cannot start with 0",
+		"+1234567890123456": "more than 15 digits",
+		"+1":                "too short",
+		"+1 415 555 0123":   "must contain only digits after the +",
+	} {
+		_, err := NewPhone(in)
+		var fe *FormatError
+		if !errors.As(err, &fe) || fe.Reason != reason {
+			t.Errorf("%q: Expected reason %q, got %v", in, reason, err)
+		}
+	}
+
+	_, err := NewPhone("555")
+	if want := `nullable: invalid E.164 phone number "555": must start with "+"`; err == nil || err.Error() != want {
+		t.Errorf("Expected %s, got %v", want, err)
+	}
+}
+
+func TestValidatedJSON(t *testing.T) {
+	type contact struct {
+		Email Email `json:"email"`
+		Phone Phone `json:"phone"`
+	}
+	var c contact
+	if err := json.Unmarshal([]byte(`{"email":"ada@example.com","phone":null}`), &c); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !c.Email.Valid || c.Phone.Valid {
+		t.Errorf("Expected valid email and null phone, got %+v", c)
+	}
+	data, _ := json.Marshal(c)
+	if want := `{"email":"ada@example.com","phone":null}`; string(data) != want {
+		t.Errorf("Expected %s, got %s", want, data)
+	}
+
+	err := json.Unmarshal([]byte(`{"phone":"0800 123"}`), &c)
+	var fe *FormatError
+	if !errors.As(err, &fe) || fe.Kind != "E.164 phone number" {
+		t.Errorf("Expected phone FormatError, got %v", err)
+	}
+	if err := json.Unmarshal([]byte(`{"email":"nope"}`), &c); !errors.As(err, &fe) {
+		t.Errorf("Expected email FormatError, got %v", err)
+	}
+	if err := json.Unmarshal([]byte(`{"email":42}`), &c); err == nil {
+		t.Error("Expected error for number")
+	}
+}
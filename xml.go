@@ -0,0 +1,203 @@
+package nullable
+
+import (
+	"encoding/xml"
+	"fmt"
+	"reflect"
+)
+
+// XSINamespace is the XML Schema instance namespace of the xsi:nil
+// attribute.
+const XSINamespace = "http://www.w3.org/2001/XMLSchema-instance"
+
+// MarshalXML implements the xml.Marshaler interface. A valid value is
+// encoded like the value itself; a null one as an empty element carrying
+// xsi:nil="true", declaring the xsi prefix on the element:
+//
+//	<age xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:nil="true"></age>
+func (n Nullable[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if n.Valid {
+		return e.EncodeElement(n.V, start)
+	}
+	start.Attr = append(start.Attr,
+		xml.Attr{Name: xml.Name{Local: "xmlns:xsi"}, Value: XSINamespace},
+		xml.Attr{Name: xml.Name{Local: "xsi:nil"}, Value: "true"},
+	)
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	return e.EncodeToken(start.End())
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface. An element with
+// xsi:nil set to true or 1 decodes as null, skipping any content;
+// otherwise the element is decoded into the value. Note that, as with
+// encoding/xml, an empty element without xsi:nil decodes as the valid zero
+// value, and a missing one leaves n unchanged.
+func (n *Nullable[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	if isXSINil(start.Attr) {
+		if err := d.Skip(); err != nil {
+			return err
+		}
+		n.Reset()
+		return nil
+	}
+	var v T
+	if err := d.DecodeElement(&v, &start); err != nil {
+		return err
+	}
+	n.V, n.Valid = v, true
+	return nil
+}
+
+// isXSINil reports whether attrs holds a true xsi:nil attribute. The
+// prefix is accepted even when the document does not declare it.
+func isXSINil(attrs []xml.Attr) bool {
+	for _, a := range attrs {
+		if a.Name.Local == "nil" && (a.Name.Space == XSINamespace || a.Name.Space == "xsi") {
+			return a.Value == "true" || a.Value == "1"
+		}
+	}
+	return false
+}
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface, so Nullables
+// can be used in ",attr" fields. A null value omits the attribute; a valid
+// one is encoded with the value's own MarshalXMLAttr method when it has
+// one, and in its text form otherwise.
+func (n Nullable[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	if !n.Valid {
+		return xml.Attr{}, nil
+	}
+	if m, ok := any(n.V).(xml.MarshalerAttr); ok {
+		return m.MarshalXMLAttr(name)
+	}
+	text, err := n.AppendText(nil)
+	if err != nil {
+		return xml.Attr{}, err
+	}
+	return xml.Attr{Name: name, Value: string(text)}, nil
+}
+
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface. A present
+// attribute makes n valid, using the value's own UnmarshalXMLAttr or
+// UnmarshalText method when it has one; a missing attribute leaves n
+// unchanged. On error n is left unchanged.
+func (n *Nullable[T]) UnmarshalXMLAttr(attr xml.Attr) error {
+	var v T
+	if err := unmarshalXMLAttrValue(reflect.ValueOf(&v).Elem(), attr); err != nil {
+		return err
+	}
+	n.V, n.Valid = v, true
+	return nil
+}
+
+func unmarshalXMLAttrValue(v reflect.Value, attr xml.Attr) error {
+	if u, ok := v.Addr().Interface().(xml.UnmarshalerAttr); ok {
+		return u.UnmarshalXMLAttr(attr)
+	}
+	if err := parseText(v, attr.Value); err != nil {
+		return fmt.Errorf("%w in XML attribute %s", err, attr.Name.Local)
+	}
+	return nil
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface like
+// Nullable.UnmarshalXML, rejecting invalid addresses.
+func (e *Email) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return decodeChecked(&e.Nullable, validateEmail, func(dst *Nullable[string]) error {
+		return dst.UnmarshalXML(d, start)
+	})
+}
+
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface like
+// Nullable.UnmarshalXMLAttr, rejecting invalid addresses.
+func (e *Email) UnmarshalXMLAttr(attr xml.Attr) error {
+	return decodeChecked(&e.Nullable, validateEmail, func(dst *Nullable[string]) error {
+		return dst.UnmarshalXMLAttr(attr)
+	})
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface like
+// Nullable.UnmarshalXML, rejecting numbers that are not E.164.
+func (p *Phone) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return decodeChecked(&p.Nullable, validatePhone, func(dst *Nullable[string]) error {
+		return dst.UnmarshalXML(d, start)
+	})
+}
+
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface like
+// Nullable.UnmarshalXMLAttr, rejecting numbers that are not E.164.
+func (p *Phone) UnmarshalXMLAttr(attr xml.Attr) error {
+	return decodeChecked(&p.Nullable, validatePhone, func(dst *Nullable[string]) error {
+		return dst.UnmarshalXMLAttr(attr)
+	})
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface like
+// Nullable.UnmarshalXML, rejecting negative values.
+func (n *NonNegative[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return decodeChecked(&n.Nullable, validateNonNegative[T], func(dst *Nullable[T]) error {
+		return dst.UnmarshalXML(d, start)
+	})
+}
+
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface like
+// Nullable.UnmarshalXMLAttr, rejecting negative values.
+func (n *NonNegative[T]) UnmarshalXMLAttr(attr xml.Attr) error {
+	return decodeChecked(&n.Nullable, validateNonNegative[T], func(dst *Nullable[T]) error {
+		return dst.UnmarshalXMLAttr(attr)
+	})
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface like
+// Nullable.UnmarshalXML, rejecting values that are not positive.
+func (n *Positive[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return decodeChecked(&n.Nullable, validatePositive[T], func(dst *Nullable[T]) error {
+		return dst.UnmarshalXML(d, start)
+	})
+}
+
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface like
+// Nullable.UnmarshalXMLAttr, rejecting values that are not positive.
+func (n *Positive[T]) UnmarshalXMLAttr(attr xml.Attr) error {
+	return decodeChecked(&n.Nullable, validatePositive[T], func(dst *Nullable[T]) error {
+		return dst.UnmarshalXMLAttr(attr)
+	})
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface like
+// Nullable.UnmarshalXML, rejecting values outside 0 to 100.
+func (n *Percentage[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return decodeChecked(&n.Nullable, validatePercentage[T], func(dst *Nullable[T]) error {
+		return dst.UnmarshalXML(d, start)
+	})
+}
+
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface like
+// Nullable.UnmarshalXMLAttr, rejecting values outside 0 to 100.
+func (n *Percentage[T]) UnmarshalXMLAttr(attr xml.Attr) error {
+	return decodeChecked(&n.Nullable, validatePercentage[T], func(dst *Nullable[T]) error {
+		return dst.UnmarshalXMLAttr(attr)
+	})
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface like
+// Nullable.UnmarshalXML, marking o present.
+func (o *Omittable[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	if err := o.Nullable.UnmarshalXML(d, start); err != nil {
+		return err
+	}
+	o.present = true
+	return nil
+}
+
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface like
+// Nullable.UnmarshalXMLAttr, marking o present.
+func (o *Omittable[T]) UnmarshalXMLAttr(attr xml.Attr) error {
+	if err := o.Nullable.UnmarshalXMLAttr(attr); err != nil {
+		return err
+	}
+	o.present = true
+	return nil
+}
@@ -0,0 +1,164 @@
+package nullable
+
+import (
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type xmlPerson struct {
+	XMLName xml.Name            `xml:"person"`
+	ID      Nullable[int]       `xml:"id,attr"`
+	Rank    Nullable[float64]   `xml:"rank,attr"`
+	Name    Nullable[string]    `xml:"name"`
+	Age     Nullable[int]       `xml:"age"`
+	Born    Nullable[time.Time] `xml:"born"`
+}
+
+func TestMarshalXML(t *testing.T) {
+	born := time.Date(1815, 12, 10, 0, 0, 0, 0, time.UTC)
+	p := xmlPerson{ID: NewNullable(7), Name: NewNullable("Ada"), Born: NewNullable(born)}
+	data, err := xml.Marshal(p)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := `<person id="7"><name>Ada</name>` +
+		`<age xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:nil="true"></age>` +
+		`<born>1815-12-10T00:00:00Z</born></person>`
+	if string(data) != want {
+		t.Errorf("Expected %s, got %s", want, data)
+	}
+
+	var out xmlPerson
+	if err := xml.Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if out.ID != p.ID || out.Rank.Valid || out.Name != p.Name || out.Age.Valid || !out.Born.V.Equal(born) {
+		t.Errorf("Expected %+v, got %+v", p, out)
+	}
+}
+
+func TestUnmarshalXML(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  Nullable[int]
+	}{
+		{"value", `<age>42</age>`, NewNullable(42)},
+		{"nil", `<age xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:nil="true"/>`, NewNull[int]()},
+		{"nil with content", `<age xmlns:x="http://www.w3.org/2001/XMLSchema-instance" x:nil="1">42</age>`, NewNull[int]()},
+		{"undeclared prefix", `<age xsi:nil="true"/>`, NewNull[int]()},
+		{"nil false", `<age xsi:nil="false">3</age>`, NewNullable(3)},
+		{"empty", `<age></age>`, NewNullable(0)},
+	}
+	for _, tt := range tests {
+		n := NewNullable(-1)
+		if err := xml.Unmarshal([]byte(tt.input), &n); err != nil {
+			t.Errorf("%s: Unexpected error: %v", tt.name, err)
+			continue
+		}
+		if n != tt.want {
+			t.Errorf("%s: Expected %v, got %v", tt.name, tt.want, n)
+		}
+	}
+
+	n := NewNullable(5)
+	if err := xml.Unmarshal([]byte(`<age>abc</age>`), &n); err == nil || n != NewNullable(5) {
+		t.Errorf("Expected error and unchanged value, got %v (%v)", n, err)
+	}
+}
+
+func TestXMLAttr(t *testing.T) {
+	var p xmlPerson
+	if err := xml.Unmarshal([]byte(`<person id="3" rank="1.5"/>`), &p); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if p.ID != NewNullable(3) || p.Rank != NewNullable(1.5) {
+		t.Errorf("Expected id 3 and rank 1.5, got %v and %v", p.ID, p.Rank)
+	}
+
+	err := xml.Unmarshal([]byte(`<person id="x"/>`), &p)
+	if err == nil || !strings.Contains(err.Error(), `"x" in XML attribute id`) {
+		t.Errorf("Expected invalid attribute error, got %v", err)
+	}
+
+	type tagged struct {
+		At   Nullable[time.Time] `xml:"at,attr"`
+		Flag Nullable[bool]      `xml:"flag,attr"`
+	}
+	at := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	data, err := xml.Marshal(tagged{At: NewNullable(at)})
+	if err != nil || string(data) != `<tagged at="2024-01-02T00:00:00Z"></tagged>` {
+		t.Errorf("Expected time attribute, got %s (%v)", data, err)
+	}
+	var out tagged
+	if err := xml.Unmarshal(data, &out); err != nil || !out.At.V.Equal(at) || out.Flag.Valid {
+		t.Errorf("Expected %v, got %+v (%v)", at, out, err)
+	}
+}
+
+func TestValidatedXML(t *testing.T) {
+	type contact struct {
+		ID       Positive[int]       `xml:"id,attr"`
+		Email    Email               `xml:"email"`
+		Phone    Phone               `xml:"phone,attr"`
+		Quantity NonNegative[int]    `xml:"quantity"`
+		Discount Percentage[float64] `xml:"discount"`
+	}
+	var c contact
+	in := `<contact id="3" phone="+14155550123"><email>ada@example.com</email>` +
+		`<quantity>0</quantity><discount xsi:nil="true"/></contact>`
+	if err := xml.Unmarshal([]byte(in), &c); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if c.ID.V != 3 || c.Email.V != "ada@example.com" || c.Phone.V != "+14155550123" ||
+		!c.Quantity.Valid || c.Discount.Valid {
+		t.Errorf("Unexpected decode: %+v", c)
+	}
+
+	for _, in := range []string{
+		`<contact><email>nope</email></contact>`,
+		`<contact phone="555"></contact>`,
+	} {
+		var fe *FormatError
+		if err := xml.Unmarshal([]byte(in), &c); !errors.As(err, &fe) {
+			t.Errorf("%s: Expected *FormatError, got %v", in, err)
+		}
+	}
+	for _, in := range []string{
+		`<contact id="0"></contact>`,
+		`<contact><quantity>-1</quantity></contact>`,
+		`<contact><discount>150</discount></contact>`,
+	} {
+		var re *RangeError
+		if err := xml.Unmarshal([]byte(in), &c); !errors.As(err, &re) {
+			t.Errorf("%s: Expected *RangeError, got %v", in, err)
+		}
+	}
+	if c.ID.V != 3 || c.Email.V != "ada@example.com" || c.Quantity.V != 0 {
+		t.Errorf("Expected rejected values to leave fields unchanged, got %+v", c)
+	}
+}
+
+func TestOmittableXML(t *testing.T) {
+	type patch struct {
+		ID    Omittable[int]    `xml:"id,attr"`
+		Rank  Omittable[int]    `xml:"rank,attr"`
+		Name  Omittable[string] `xml:"name"`
+		Age   Omittable[int]    `xml:"age"`
+		Email Omittable[string] `xml:"email"`
+	}
+	var p patch
+	in := `<patch id="3"><name>Ada</name><age xsi:nil="true"/></patch>`
+	if err := xml.Unmarshal([]byte(in), &p); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if p.ID != NewOmittable(3) || p.Name != NewOmittable("Ada") || !p.Age.IsNull() {
+		t.Errorf("Expected id, name and null age present, got %+v", p)
+	}
+	if p.Rank.IsPresent() || p.Email.IsPresent() {
+		t.Errorf("Expected rank and email absent, got %v and %v", p.Rank, p.Email)
+	}
+}
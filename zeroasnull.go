@@ -0,0 +1,171 @@
+package nullable
+
+import (
+	"encoding/json"
+	"encoding/xml"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// ZeroAsNull is a Nullable that treats the zero value of T as null. A valid
+// zero value is encoded as null, and a decoded zero value is stored as null,
+// for systems where zero and absent mean the same thing. The rule applies to
+// every encoding Nullable supports, not only JSON.
+type ZeroAsNull[T comparable] struct {
+	Nullable[T]
+}
+
+// NewZeroAsNull creates a ZeroAsNull that is null if value is the zero value.
+func NewZeroAsNull[T comparable](value T) ZeroAsNull[T] {
+	var zero T
+	if value == zero {
+		return ZeroAsNull[T]{NewNull[T]()}
+	}
+	return ZeroAsNull[T]{NewNullable(value)}
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (z ZeroAsNull[T]) MarshalJSON() ([]byte, error) {
+	var zero T
+	if !z.Valid || z.V == zero {
+		return []byte("null"), nil
+	}
+	return json.Marshal(z.V)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (z *ZeroAsNull[T]) UnmarshalJSON(data []byte) error {
+	if err := z.Nullable.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	var zero T
+	if z.V == zero {
+		z.Valid = false
+	}
+	return nil
+}
+
+// wire returns z as it is encoded: null if z holds the zero value.
+func (z ZeroAsNull[T]) wire() Nullable[T] {
+	var zero T
+	if z.V == zero {
+		return NewNull[T]()
+	}
+	return z.Nullable
+}
+
+// setWire stores a decoded value, treating the zero value as null.
+func (z *ZeroAsNull[T]) setWire(n Nullable[T]) error {
+	var zero T
+	if n.V == zero {
+		n.Valid = false
+	}
+	z.Nullable = n
+	return nil
+}
+
+// decodeWire decodes into a fresh Nullable with decode and hands the result
+// to set, which applies the wrapper's rule for stored values.
+func decodeWire[W any](set func(Nullable[W]) error, decode func(*Nullable[W]) error) error {
+	var n Nullable[W]
+	if err := decode(&n); err != nil {
+		return err
+	}
+	return set(n)
+}
+
+// AppendText implements the encoding.TextAppender interface.
+func (z ZeroAsNull[T]) AppendText(b []byte) ([]byte, error) {
+	return z.wire().AppendText(b)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (z ZeroAsNull[T]) MarshalText() ([]byte, error) {
+	return z.wire().MarshalText()
+}
+
+// MarshalXML implements the xml.Marshaler interface.
+func (z ZeroAsNull[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return z.wire().MarshalXML(e, start)
+}
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface.
+func (z ZeroAsNull[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return z.wire().MarshalXMLAttr(name)
+}
+
+// MarshalCBOR implements the cbor.Marshaler interface.
+func (z ZeroAsNull[T]) MarshalCBOR() ([]byte, error) {
+	return z.wire().MarshalCBOR()
+}
+
+// EncodeMsgpack implements the msgpack.CustomEncoder interface.
+func (z ZeroAsNull[T]) EncodeMsgpack(enc *msgpack.Encoder) error {
+	return z.wire().EncodeMsgpack(enc)
+}
+
+// MarshalBSONValue implements the bson.ValueMarshaler interface.
+func (z ZeroAsNull[T]) MarshalBSONValue() (byte, []byte, error) {
+	return z.wire().MarshalBSONValue()
+}
+
+// GobEncode implements the gob.GobEncoder interface.
+func (z ZeroAsNull[T]) GobEncode() ([]byte, error) {
+	return z.wire().GobEncode()
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (z *ZeroAsNull[T]) UnmarshalText(text []byte) error {
+	return decodeWire(z.setWire, func(n *Nullable[T]) error {
+		return n.UnmarshalText(text)
+	})
+}
+
+// UnmarshalTOML implements the BurntSushi/toml Unmarshaler interface.
+func (z *ZeroAsNull[T]) UnmarshalTOML(value any) error {
+	return decodeWire(z.setWire, func(n *Nullable[T]) error {
+		return n.UnmarshalTOML(value)
+	})
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface.
+func (z *ZeroAsNull[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return decodeWire(z.setWire, func(n *Nullable[T]) error {
+		return n.UnmarshalXML(d, start)
+	})
+}
+
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface.
+func (z *ZeroAsNull[T]) UnmarshalXMLAttr(attr xml.Attr) error {
+	return decodeWire(z.setWire, func(n *Nullable[T]) error {
+		return n.UnmarshalXMLAttr(attr)
+	})
+}
+
+// UnmarshalCBOR implements the cbor.Unmarshaler interface.
+func (z *ZeroAsNull[T]) UnmarshalCBOR(data []byte) error {
+	return decodeWire(z.setWire, func(n *Nullable[T]) error {
+		return n.UnmarshalCBOR(data)
+	})
+}
+
+// DecodeMsgpack implements the msgpack.CustomDecoder interface.
+func (z *ZeroAsNull[T]) DecodeMsgpack(dec *msgpack.Decoder) error {
+	return decodeWire(z.setWire, func(n *Nullable[T]) error {
+		return n.DecodeMsgpack(dec)
+	})
+}
+
+// UnmarshalBSONValue implements the bson.ValueUnmarshaler interface.
+func (z *ZeroAsNull[T]) UnmarshalBSONValue(typ byte, data []byte) error {
+	return decodeWire(z.setWire, func(n *Nullable[T]) error {
+		return n.UnmarshalBSONValue(typ, data)
+	})
+}
+
+// GobDecode implements the gob.GobDecoder interface.
+func (z *ZeroAsNull[T]) GobDecode(data []byte) error {
+	return decodeWire(z.setWire, func(n *Nullable[T]) error {
+		return n.GobDecode(data)
+	})
+}
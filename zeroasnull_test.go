@@ -0,0 +1,127 @@
+package nullable
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/vmihailenco/msgpack/v5"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// wireCodecs are the struct encodings that wrappers such as ZeroAsNull must
+// apply their rule to, besides JSON.
+var wireCodecs = []struct {
+	name      string
+	marshal   func(any) ([]byte, error)
+	unmarshal func([]byte, any) error
+}{
+	{"xml", xml.Marshal, xml.Unmarshal},
+	{"cbor", cbor.Marshal, cbor.Unmarshal},
+	{"msgpack", msgpack.Marshal, msgpack.Unmarshal},
+	{"bson", bson.Marshal, bson.Unmarshal},
+	{"gob", func(v any) ([]byte, error) {
+		var buf bytes.Buffer
+		err := gob.NewEncoder(&buf).Encode(v)
+		return buf.Bytes(), err
+	}, func(data []byte, v any) error {
+		return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+	}},
+}
+
+type zeroDoc struct {
+	A ZeroAsNull[int] `xml:"a" cbor:"a" msgpack:"a" bson:"a"`
+}
+
+type zeroNullableDoc struct {
+	A Nullable[int] `xml:"a" cbor:"a" msgpack:"a" bson:"a"`
+}
+
+func TestNewZeroAsNull(t *testing.T) {
+	if z := NewZeroAsNull(0); z.Valid {
+		t.Error("Expected zero value to be null")
+	}
+	if z := NewZeroAsNull(5); !z.Valid || z.V != 5 {
+		t.Errorf("Expected valid 5, got %+v", z)
+	}
+}
+
+func TestZeroAsNullMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		in   ZeroAsNull[string]
+		want string
+	}{
+		{"value", NewZeroAsNull("x"), `"x"`},
+		{"null", ZeroAsNull[string]{NewNull[string]()}, "null"},
+		{"valid zero", ZeroAsNull[string]{NewNullable("")}, "null"},
+	}
+	for _, tt := range tests {
+		data, err := json.Marshal(tt.in)
+		if err != nil {
+			t.Errorf("%s: Unexpected error: %v", tt.name, err)
+		}
+		if string(data) != tt.want {
+			t.Errorf("%s: Expected %s, got %s", tt.name, tt.want, data)
+		}
+	}
+}
+
+func TestZeroAsNullUnmarshalJSON(t *testing.T) {
+	var s struct {
+		A ZeroAsNull[int] `json:"a"`
+		B ZeroAsNull[int] `json:"b"`
+		C ZeroAsNull[int] `json:"c"`
+	}
+	if err := json.Unmarshal([]byte(`{"a":0,"b":null,"c":7}`), &s); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if s.A.Valid {
+		t.Error("Expected a to be null")
+	}
+	if s.B.Valid {
+		t.Error("Expected b to be null")
+	}
+	if !s.C.Valid || s.C.V != 7 {
+		t.Errorf("Expected c to be 7, got %+v", s.C)
+	}
+
+	if err := json.Unmarshal([]byte(`{"a":"x"}`), &s); err == nil {
+		t.Error("Expected error for mismatched type")
+	}
+}
+
+func TestZeroAsNullCodecs(t *testing.T) {
+	for _, c := range wireCodecs {
+		got, err := c.marshal(zeroDoc{ZeroAsNull[int]{NewNullable(0)}})
+		if err != nil {
+			t.Fatalf("%s: Unexpected error: %v", c.name, err)
+		}
+		var plain zeroNullableDoc
+		if err := c.unmarshal(got, &plain); err != nil || plain.A.Valid {
+			t.Errorf("%s: Expected a valid zero to encode as null, got %+v (%v)", c.name, plain.A, err)
+		}
+
+		for _, v := range []int{0, 5} {
+			data, _ := c.marshal(zeroNullableDoc{NewNullable(v)})
+			var out zeroDoc
+			if err := c.unmarshal(data, &out); err != nil {
+				t.Fatalf("%s: Unexpected error: %v", c.name, err)
+			}
+			if out.A.Valid != (v != 0) || out.A.V != v {
+				t.Errorf("%s: Expected %v to decode as NewZeroAsNull(%v), got %+v", c.name, v, v, out.A)
+			}
+		}
+	}
+
+	if text, err := (ZeroAsNull[int]{NewNullable(0)}).MarshalText(); err != nil || len(text) != 0 {
+		t.Errorf("Expected empty text, got %q (%v)", text, err)
+	}
+	var z ZeroAsNull[int]
+	if err := z.UnmarshalText([]byte("0")); err != nil || z.Valid {
+		t.Errorf("Expected null, got %+v (%v)", z, err)
+	}
+}